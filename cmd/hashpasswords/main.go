@@ -0,0 +1,12 @@
+package main
+
+import (
+	"FGW_WEB/internal/app"
+	"log"
+)
+
+func main() {
+	if err := app.HashPerformerPasswords(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"FGW_WEB/internal/app"
+	"flag"
+	"log"
+)
+
+func main() {
+	filePath := flag.String("file", "", "путь к выгрузке сотрудников из Галактики (CSV, Windows-1251)")
+	editUser := flag.Int("user", 1, "ИД пользователя редактирования (PfEditUser)")
+	flag.Parse()
+
+	if *filePath == "" {
+		log.Fatal("Не указан путь к файлу выгрузки: -file")
+	}
+
+	if err := app.ImportAFormsPerformers(*filePath, *editUser); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"FGW_WEB/internal/app"
+	"flag"
+	"log"
+)
+
+func main() {
+	from := flag.String("from", "", "дата начала периода ГГГГ-ММ-ДД, по умолчанию вчера")
+	to := flag.String("to", "", "дата окончания периода ГГГГ-ММ-ДД, по умолчанию равна -from")
+	flag.Parse()
+
+	if err := app.ExportSap(*from, *to); err != nil {
+		log.Fatal(err)
+	}
+}
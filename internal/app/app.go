@@ -36,8 +36,6 @@ func StartApp() {
 	}
 	defer logger.Close()
 
-	authMiddleware := handler.NewAuthMiddleware(config.Store, logger)
-
 	configDB, err := config.NewMSSQLCfg(logger, fileEnv)
 	if err != nil {
 		logger.LogE(msg.E3000, err)
@@ -52,19 +50,89 @@ func StartApp() {
 	}
 	defer db.Close(mssqlDB)
 
+	var repoSession repository.SessionRepository = repository.NewSessionMemoryRepo()
+	if config.GetSessionRegistry() == config.SessionRegistryDB {
+		repoSession = repository.NewSessionRepo(mssqlDB, logger)
+	}
+	serviceSession := service.NewSessionService(repoSession, logger)
+
 	repoRole := repository.NewRoleRepo(mssqlDB, logger)
-	serviceRole := service.NewRoleService(repoRole, logger)
-	handlerRoleJSON := json_api.NewRoleHandlerJSON(serviceRole, logger)
+	repoPermission := repository.NewPermissionRepo(mssqlDB, logger)
+	servicePermission := service.NewPermissionService(repoPermission, repoRole, logger)
 
 	repoPerformer := repository.NewPerformerRepo(mssqlDB, logger)
-	servicePerformer := service.NewPerformerService(repoPerformer, logger)
-	handlerPerformerJSON := json_api.NewPerformerHandlerJSON(servicePerformer, logger)
+	repoApiToken := repository.NewApiTokenRepo(mssqlDB, logger)
+	serviceApiToken := service.NewApiTokenService(repoApiToken, repoPerformer, logger)
+	serviceAccessToken := service.NewAccessTokenService(config.NewJWTCfg(), repoPerformer, serviceSession, logger)
+	authMiddleware := handler.NewAuthMiddleware(config.Store, serviceSession, servicePermission, serviceApiToken, serviceAccessToken, logger)
+
+	serviceRole := service.NewRoleService(repoRole, logger)
+	handlerRoleJSON := json_api.NewRoleHandlerJSON(serviceRole, logger, authMiddleware)
+
+	serviceLoginThrottle := service.NewLoginThrottleService(config.NewLoginThrottleCfg(), logger)
+
+	servicePerformer := service.NewPerformerService(repoPerformer, logger, newAuthProviders(repoPerformer, logger)...)
+	repoPassword := repository.NewPasswordRepo(mssqlDB, logger)
+	servicePassword := service.NewPasswordService(repoPerformer, repoPassword, config.NewPasswordPolicyCfg(), logger)
+	repoTwoFactor := repository.NewTwoFactorRepo(mssqlDB, logger)
+	configTOTP, err := config.NewTOTPCfg()
+	if err != nil {
+		log.Fatal(err)
+	}
+	serviceTwoFactor := service.NewTwoFactorService(repoTwoFactor, configTOTP, logger)
+	if err = serviceTwoFactor.CheckKey(ctx); err != nil {
+		log.Fatal(err)
+	}
+	handlerPerformerJSON := json_api.NewPerformerHandlerJSON(servicePerformer, serviceLoginThrottle, servicePassword, serviceTwoFactor, logger, authMiddleware)
+
+	repoCatalog := repository.NewCatalogRepo(mssqlDB, logger)
+	repoPallet := repository.NewPalletRepo(mssqlDB, logger)
+	serviceCatalog := service.NewCatalogService(repoCatalog, repoPallet, logger)
+	handlerCatalogJSON := json_api.NewCatalogHandlerJSON(serviceCatalog, logger, authMiddleware)
+	handlerStorageAreaHTML := http_web.NewStorageAreaHandlerHTML(serviceCatalog, logger, authMiddleware)
+
+	repoAFormsPerformer := repository.NewAFormsPerformerRepo(mssqlDB, logger)
+	serviceAFormsPerformer := service.NewAFormsPerformerService(repoAFormsPerformer, logger)
+	handlerAFormsPerformerHTML := admin.NewAFormsPerformerHandlerHTML(serviceAFormsPerformer, logger, authMiddleware)
+
+	handlerRoleHTML := admin.NewRoleHandlerHTML(serviceRole, servicePermission, logger, authMiddleware, servicePerformer)
+	handlerPerformerHTML := admin.NewPerformerHandlerHTML(servicePerformer, serviceRole, serviceAFormsPerformer, servicePassword, logger, authMiddleware)
+
+	repoSector := repository.NewSectorRepo(mssqlDB, logger)
+	serviceSector := service.NewSectorService(repoSector, logger)
+	handlerSectorHTML := admin.NewSectorHandlerHTML(serviceSector, serviceAFormsPerformer, servicePerformer, serviceRole, logger, authMiddleware)
+
+	repoProduct := repository.NewProductRepo(mssqlDB, logger)
+	repoProductHistory := repository.NewProductHistoryRepo(mssqlDB, logger)
+	serviceProduct := service.NewProductService(repoProduct, repoSector, repoProductHistory, repoCatalog, logger)
+	handlerProductJSON := json_api.NewProductHandlerJSON(serviceProduct, logger, authMiddleware)
+	handlerProductHTML := admin.NewProductHandlerHTML(serviceProduct, servicePerformer, serviceRole, logger, authMiddleware)
 
-	handlerRoleHTML := admin.NewRoleHandlerHTML(serviceRole, logger, authMiddleware, servicePerformer)
-	handlerPerformerHTML := admin.NewPerformerHandlerHTML(servicePerformer, serviceRole, logger, authMiddleware)
+	repoDeclaration := repository.NewDeclarationRepo(mssqlDB, logger)
+	serviceCompliance := service.NewComplianceService(repoDeclaration, repoProduct, logger)
+	handlerComplianceJSON := json_api.NewComplianceHandlerJSON(serviceCompliance, logger, authMiddleware)
+	handlerDeclarationHTML := admin.NewDeclarationHandlerHTML(serviceCompliance, logger, authMiddleware)
 
-	handlerAuthHTML := http_web.NewAuthHandlerHTML(servicePerformer, serviceRole, logger, authMiddleware)
-	handlerAuthJSON := json_api.NewAuthHandlerJSON(servicePerformer, logger)
+	repoShiftTask := repository.NewShiftTaskRepo(mssqlDB, logger)
+	serviceShiftTask := service.NewShiftTaskService(repoShiftTask, repoSector, repoProduct, logger)
+	handlerShiftTaskJSON := json_api.NewShiftTaskHandlerJSON(serviceShiftTask, logger, authMiddleware)
+	handlerShiftTaskHTML := http_web.NewShiftTaskHandlerHTML(serviceShiftTask, serviceSector, serviceProduct, logger, authMiddleware)
+
+	repoPackStation := repository.NewPackStationRepo(mssqlDB, logger)
+	servicePackStation := service.NewPackStationService(repoPackStation, repoPallet, serviceCompliance, repoCatalog, repoSector, logger)
+	handlerPackStationJSON := json_api.NewPackStationHandlerJSON(servicePackStation, logger, authMiddleware)
+	handlerPackStationHTML := admin.NewPackStationHandlerHTML(servicePackStation, logger, authMiddleware)
+
+	servicePallet := service.NewPalletService(repoPallet, repoCatalog, logger)
+	handlerPalletJSON := json_api.NewPalletHandlerJSON(servicePallet, logger, authMiddleware)
+
+	handlerSessionHTML := admin.NewSessionHandlerHTML(serviceSession, serviceLoginThrottle, servicePerformer, serviceRole, logger, authMiddleware)
+	handlerApiTokenHTML := admin.NewApiTokenHandlerHTML(serviceApiToken, servicePerformer, serviceRole, logger, authMiddleware)
+
+	handlerAuthHTML := http_web.NewAuthHandlerHTML(servicePerformer, serviceRole, serviceLoginThrottle, servicePassword, serviceTwoFactor, logger, authMiddleware)
+	badgeCfg := config.NewBadgeLoginCfg()
+	handlerAuthJSON := json_api.NewAuthHandlerJSON(servicePerformer, serviceLoginThrottle, serviceTwoFactor, badgeCfg, authMiddleware, logger)
+	handlerTokenJSON := json_api.NewTokenHandlerJSON(servicePerformer, serviceAccessToken, serviceLoginThrottle, servicePassword, serviceTwoFactor, badgeCfg, logger)
 
 	mux := http.NewServeMux()
 
@@ -74,12 +142,36 @@ func StartApp() {
 	handlerPerformerJSON.ServeHTTPJSONRouter(mux)
 	handlerPerformerHTML.ServeHTTPHTMLRouter(mux)
 
+	handlerSectorHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerProductJSON.ServeHTTPJSONRouter(mux)
+	handlerProductHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerComplianceJSON.ServeHTTPJSONRouter(mux)
+	handlerDeclarationHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerShiftTaskJSON.ServeHTTPJSONRouter(mux)
+	handlerShiftTaskHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerPackStationJSON.ServeHTTPJSONRouter(mux)
+	handlerPackStationHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerPalletJSON.ServeHTTPJSONRouter(mux)
+
+	handlerSessionHTML.ServeHTTPHTMLRouter(mux)
+	handlerApiTokenHTML.ServeHTTPHTMLRouter(mux)
+
+	handlerCatalogJSON.ServeHTTPJSONRouter(mux)
+	handlerStorageAreaHTML.ServeHTTPHTMLRouter(mux)
+	handlerAFormsPerformerHTML.ServeHTTPHTMLRouter(mux)
+
 	handlerAuthHTML.ServerHTTPRouter(mux)
 	handlerAuthJSON.ServeHTTPJSONRouter(mux)
+	handlerTokenJSON.ServeHTTPJSONRouter(mux)
 
 	mux.Handle("/web/", http.StripPrefix("/web/", http.FileServer(http.Dir("web/"))))
 
-	server := config.NewServer(addr, mux, logger)
+	server := config.NewServer(addr, authMiddleware.ProtectCSRF(mux), logger)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -99,3 +191,25 @@ func StartApp() {
 		logger.LogE(msg.E3102, err)
 	}
 }
+
+// newAuthProviders способы проверки пароля из AUTH_PROVIDERS в порядке опроса.
+func newAuthProviders(repoPerformer repository.PerformerRepository, logger *common.Logger) []service.AuthProvider {
+	var providers []service.AuthProvider
+
+	for _, name := range config.GetAuthProviders() {
+		switch name {
+		case config.AuthProviderDB:
+			providers = append(providers, service.NewDBAuthProvider(repoPerformer, logger))
+		case config.AuthProviderLDAP:
+			ldapCfg := config.NewLDAPCfg()
+			if ldapCfg.URL == "" || ldapCfg.BaseDN == "" {
+				logger.LogW("AUTH_PROVIDERS: ldap пропущен, не заданы LDAP_URL или LDAP_BASE_DN")
+
+				continue
+			}
+			providers = append(providers, service.NewLDAPAuthProvider(ldapCfg, logger))
+		}
+	}
+
+	return providers
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"context"
+	"fmt"
+)
+
+// HashPerformerPasswords заменить хешем пароли сотрудников, которые хранятся в открытом виде.
+func HashPerformerPasswords() error {
+	logger, err := common.NewLogger("")
+	if err != nil {
+		return err
+	}
+	defer logger.Close()
+
+	configDB, err := config.NewMSSQLCfg(logger, fileEnv)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	mssqlDB, err := db.NewConnMSSQL(ctx, configDB, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close(mssqlDB)
+
+	repoPerformer := repository.NewPerformerRepo(mssqlDB, logger)
+	servicePerformer := service.NewPerformerService(repoPerformer, logger)
+
+	report, err := servicePerformer.HashLegacyPasswords(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Захешировано паролей: %d, ошибок: %d\n", report.Hashed, len(report.Errors))
+	for _, reason := range report.Errors {
+		fmt.Println("  " + reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"context"
+	"fmt"
+	"os"
+)
+
+// ImportAFormsPerformers импортирует сотрудников AForms из выгрузки Галактики в svTB_Performer.
+func ImportAFormsPerformers(filePath string, editUser int) error {
+	logger, err := common.NewLogger("")
+	if err != nil {
+		return err
+	}
+	defer logger.Close()
+
+	configDB, err := config.NewMSSQLCfg(logger, fileEnv)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	mssqlDB, err := db.NewConnMSSQL(ctx, configDB, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close(mssqlDB)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	repoAFormsPerformer := repository.NewAFormsPerformerRepo(mssqlDB, logger)
+	serviceAFormsPerformer := service.NewAFormsPerformerService(repoAFormsPerformer, logger)
+
+	report, err := serviceAFormsPerformer.ImportGalaktika(ctx, file, editUser)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Добавлено: %d, обновлено: %d, пропущено: %d\n", report.Created, report.Updated, report.Skipped)
+	for _, reason := range report.Errors {
+		fmt.Println("  " + reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExportSap выгрузка в SAP за период ГГГГ-ММ-ДД, пустые даты - вчерашний день.
+func ExportSap(from, to string) error {
+	start, end, err := model.ParseSapExportRange(from, to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger("")
+	if err != nil {
+		return err
+	}
+	defer logger.Close()
+
+	configDB, err := config.NewMSSQLCfg(logger, fileEnv)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	mssqlDB, err := db.NewConnMSSQL(ctx, configDB, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close(mssqlDB)
+
+	repoSapExport := repository.NewSapExportRepo(mssqlDB, logger)
+	serviceSapExport := service.NewSapExportService(repoSapExport, config.NewSapExportCfg(), logger)
+
+	reports, err := serviceSapExport.ExportRange(ctx, start, end)
+	for _, report := range reports {
+		fmt.Printf("%s: приход %d, отгрузка %d, остаток %d\n", report.Date,
+			report.Rows[model.SapKindReceipts.Name], report.Rows[model.SapKindShipments.Name], report.Rows[model.SapKindStock.Name])
+		for _, file := range report.Files {
+			fmt.Println("  " + file)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"crypto/subtle"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	SessionDeviceKey     = "device_type"   // SessionDeviceKey - тип устройства, с которого выполнен вход по бейджу.
+	DeviceTypeHeader     = "X-Device-Type" // DeviceTypeHeader - заголовок, в котором клиент сообщает тип устройства.
+	DeviceIdHeader       = "X-Device-Id"   // DeviceIdHeader - заголовок с ид устройства из BADGE_DEVICES.
+	DeviceKeyHeader      = "X-Device-Key"  // DeviceKeyHeader - заголовок с ключом устройства из BADGE_DEVICES.
+	defaultBadgeDevices  = "tsd,terminal"  // defaultBadgeDevices - ТСД и терминалы цеха.
+	defaultBadgeMaxAge   = 900             // defaultBadgeMaxAge - 15 минут.
+	badgeDeviceKeyMinLen = 16              // badgeDeviceKeyMinLen - наименьшая длина ключа устройства.
+)
+
+// BadgeDevice устройство, с которого разрешен вход по бейджу.
+type BadgeDevice struct {
+	Id   string // Id - ид устройства, например инвентарный номер ТСД.
+	Type string // Type - тип устройства.
+	Key  []byte // Key - ключ устройства, передается в X-Device-Key.
+}
+
+// BadgeLoginCfg настройки входа по штрих-коду бейджа.
+type BadgeLoginCfg struct {
+	DeviceTypes map[string]bool         // DeviceTypes - типы устройств, которым разрешен вход по бейджу.
+	Devices     map[string]*BadgeDevice // Devices - зарегистрированные устройства по ид.
+	MaxAge      int                     // MaxAge - время жизни сессии, сек.
+}
+
+// NewBadgeLoginCfg читает BADGE_LOGIN_DEVICES (типы устройств через запятую), BADGE_DEVICES (ид:тип:ключ через
+// запятую) и BADGE_SESSION_MAX_AGE (сек.). Вход по бейджу возможен только с устройства из BADGE_DEVICES: тип
+// устройства берется из реестра, а не из заголовка клиента. Без BADGE_DEVICES вход по бейджу закрыт.
+func NewBadgeLoginCfg() *BadgeLoginCfg {
+	devices := os.Getenv("BADGE_LOGIN_DEVICES")
+	if strings.TrimSpace(devices) == "" {
+		devices = defaultBadgeDevices
+	}
+
+	deviceTypes := make(map[string]bool)
+	for _, device := range strings.Split(devices, ",") {
+		if device = strings.ToLower(strings.TrimSpace(device)); device != "" {
+			deviceTypes[device] = true
+		}
+	}
+
+	maxAge, err := strconv.Atoi(os.Getenv("BADGE_SESSION_MAX_AGE"))
+	if err != nil || maxAge <= 0 {
+		maxAge = defaultBadgeMaxAge
+	}
+
+	return &BadgeLoginCfg{DeviceTypes: deviceTypes, Devices: parseBadgeDevices(os.Getenv("BADGE_DEVICES")), MaxAge: maxAge}
+}
+
+// parseBadgeDevices разбор реестра устройств ид:тип:ключ, записи с коротким ключом пропускаются.
+func parseBadgeDevices(value string) map[string]*BadgeDevice {
+	devices := make(map[string]*BadgeDevice)
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			continue
+		}
+
+		if len(parts[2]) < badgeDeviceKeyMinLen {
+			log.Printf("Устройство %q пропущено: ключ короче %d символов", parts[0], badgeDeviceKeyMinLen)
+
+			continue
+		}
+
+		devices[parts[0]] = &BadgeDevice{Id: parts[0], Type: strings.ToLower(strings.TrimSpace(parts[1])), Key: []byte(parts[2])}
+	}
+
+	if len(devices) == 0 {
+		log.Println("BADGE_DEVICES не задан: вход по бейджу закрыт")
+	}
+
+	return devices
+}
+
+// AllowDevice разрешен ли вход по бейджу с устройства.
+func (c *BadgeLoginCfg) AllowDevice(deviceType string) bool {
+	return c.DeviceTypes[strings.ToLower(strings.TrimSpace(deviceType))]
+}
+
+// AuthDevice устройство по ид и ключу, false - устройство не зарегистрировано, ключ неверный или типу устройства
+// вход по бейджу запрещен.
+func (c *BadgeLoginCfg) AuthDevice(id, key string) (*BadgeDevice, bool) {
+	device, ok := c.Devices[strings.TrimSpace(id)]
+	if !ok || subtle.ConstantTimeCompare(device.Key, []byte(key)) != 1 || !c.AllowDevice(device.Type) {
+		return nil, false
+	}
+
+	return device, true
+}
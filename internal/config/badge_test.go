@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBadgeLoginCfg(t *testing.T) {
+	t.Setenv("BADGE_LOGIN_DEVICES", "")
+	t.Setenv("BADGE_DEVICES", "")
+	t.Setenv("BADGE_SESSION_MAX_AGE", "")
+
+	cfg := NewBadgeLoginCfg()
+	assert.True(t, cfg.AllowDevice("TSD"))
+	assert.True(t, cfg.AllowDevice(" terminal "))
+	assert.False(t, cfg.AllowDevice("pc"))
+	assert.False(t, cfg.AllowDevice(""))
+	assert.Empty(t, cfg.Devices, "без реестра вход по бейджу закрыт")
+	assert.Equal(t, defaultBadgeMaxAge, cfg.MaxAge)
+
+	t.Setenv("BADGE_LOGIN_DEVICES", "tsd, kiosk")
+	t.Setenv("BADGE_SESSION_MAX_AGE", "600")
+
+	cfg = NewBadgeLoginCfg()
+	assert.True(t, cfg.AllowDevice("kiosk"))
+	assert.False(t, cfg.AllowDevice("terminal"))
+	assert.Equal(t, 600, cfg.MaxAge)
+}
+
+func TestBadgeLoginCfg_AuthDevice(t *testing.T) {
+	t.Setenv("BADGE_LOGIN_DEVICES", "tsd")
+	t.Setenv("BADGE_DEVICES", "tsd-01:TSD:0123456789abcdef, term-01:terminal:0123456789abcdef, tsd-02:tsd:short")
+
+	cfg := NewBadgeLoginCfg()
+	assert.Len(t, cfg.Devices, 2, "устройство с коротким ключом пропущено")
+
+	device, ok := cfg.AuthDevice("tsd-01", "0123456789abcdef")
+	assert.True(t, ok)
+	assert.Equal(t, "tsd", device.Type)
+
+	_, ok = cfg.AuthDevice("tsd-01", "0123456789abcdeX")
+	assert.False(t, ok, "неверный ключ")
+	_, ok = cfg.AuthDevice("term-01", "0123456789abcdef")
+	assert.False(t, ok, "типу устройства вход по бейджу запрещен")
+	_, ok = cfg.AuthDevice("tsd-02", "short")
+	assert.False(t, ok, "устройство не зарегистрировано")
+}
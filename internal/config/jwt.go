@@ -0,0 +1,72 @@
+package config
+
+import (
+	"crypto/rand"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	JWTIssuer            = "fgw_web" // JWTIssuer - издатель токенов (iss).
+	defaultJWTKid        = "local"   // defaultJWTKid - ид ключа, сгенерированного при запуске.
+	defaultJWTAccessTTL  = 900       // defaultJWTAccessTTL - 15 минут.
+	defaultJWTRefreshTTL = 604800    // defaultJWTRefreshTTL - 7 дней.
+	jwtKeyMinLen         = 32        // jwtKeyMinLen - наименьшая длина ключа подписи HS256, байт.
+)
+
+// JWTCfg настройки токенов доступа для ТСД и других клиентов без сессии.
+type JWTCfg struct {
+	Keys       map[string][]byte // Keys - ключи подписи по ид (kid), токен проверяется ключом из своего заголовка.
+	ActiveKid  string            // ActiveKid - ид ключа, которым подписываются новые токены.
+	AccessTTL  time.Duration     // AccessTTL - время жизни токена доступа.
+	RefreshTTL time.Duration     // RefreshTTL - время жизни токена обновления.
+}
+
+// NewJWTCfg читает JWT_KEYS (kid:ключ через запятую), JWT_ACTIVE_KID (по умолчанию первый ключ), JWT_ACCESS_TTL и
+// JWT_REFRESH_TTL (сек.). Для смены ключа новый ключ добавляется в JWT_KEYS и назначается активным, старый
+// удаляется, когда истекут подписанные им токены обновления. Без JWT_KEYS ключ генерируется при запуске: токены
+// не переживают перезапуск и не принимаются другими экземплярами.
+func NewJWTCfg() *JWTCfg {
+	cfg := &JWTCfg{
+		Keys:       make(map[string][]byte),
+		AccessTTL:  time.Duration(getEnvPositiveInt("JWT_ACCESS_TTL", defaultJWTAccessTTL)) * time.Second,
+		RefreshTTL: time.Duration(getEnvPositiveInt("JWT_REFRESH_TTL", defaultJWTRefreshTTL)) * time.Second,
+	}
+
+	for _, entry := range strings.Split(os.Getenv("JWT_KEYS"), ",") {
+		kid, key, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || kid == "" {
+			continue
+		}
+
+		if len(key) < jwtKeyMinLen {
+			log.Printf("Ключ JWT %q пропущен: короче %d символов", kid, jwtKeyMinLen)
+
+			continue
+		}
+
+		cfg.Keys[kid] = []byte(key)
+		if cfg.ActiveKid == "" {
+			cfg.ActiveKid = kid
+		}
+	}
+
+	if kid := strings.TrimSpace(os.Getenv("JWT_ACTIVE_KID")); cfg.Keys[kid] != nil {
+		cfg.ActiveKid = kid
+	}
+
+	if len(cfg.Keys) == 0 {
+		key := make([]byte, jwtKeyMinLen)
+		if _, err := rand.Read(key); err != nil {
+			panic("Не удалось сгенерировать ключ JWT: " + err.Error())
+		}
+
+		cfg.Keys[defaultJWTKid] = key
+		cfg.ActiveKid = defaultJWTKid
+		log.Println("JWT_KEYS не задан: токены доступа подписываются ключом, сгенерированным при запуске")
+	}
+
+	return cfg
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJWTCfg(t *testing.T) {
+	t.Setenv("JWT_KEYS", "")
+	t.Setenv("JWT_ACTIVE_KID", "")
+	t.Setenv("JWT_ACCESS_TTL", "")
+	t.Setenv("JWT_REFRESH_TTL", "")
+
+	cfg := NewJWTCfg()
+	assert.Equal(t, defaultJWTKid, cfg.ActiveKid)
+	assert.Len(t, cfg.Keys[defaultJWTKid], jwtKeyMinLen)
+	assert.Equal(t, defaultJWTAccessTTL*time.Second, cfg.AccessTTL)
+	assert.Equal(t, defaultJWTRefreshTTL*time.Second, cfg.RefreshTTL)
+
+	keyA, keyB := strings.Repeat("a", jwtKeyMinLen), strings.Repeat("b", jwtKeyMinLen)
+	t.Setenv("JWT_KEYS", "2025:"+keyA+", 2026:"+keyB+", short:123")
+	t.Setenv("JWT_ACCESS_TTL", "300")
+
+	cfg = NewJWTCfg()
+	assert.Equal(t, "2025", cfg.ActiveKid, "по умолчанию активен первый ключ")
+	assert.Len(t, cfg.Keys, 2, "короткий ключ пропущен")
+	assert.Equal(t, 300*time.Second, cfg.AccessTTL)
+
+	t.Setenv("JWT_ACTIVE_KID", "2026")
+	assert.Equal(t, "2026", NewJWTCfg().ActiveKid)
+
+	t.Setenv("JWT_ACTIVE_KID", "short")
+	assert.Equal(t, "2025", NewJWTCfg().ActiveKid, "неизвестный ключ не назначается активным")
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AuthProviderDB   = "db"   // AuthProviderDB - пароль из svPerformers.
+	AuthProviderLDAP = "ldap" // AuthProviderLDAP - пароль доменной учетной записи (LDAP/Active Directory).
+
+	defaultAuthProviders  = AuthProviderDB
+	defaultLDAPTabnumAttr = "employeeID"
+	defaultLDAPUserFilter = "(objectClass=person)"
+	defaultLDAPTimeout    = 5 // defaultLDAPTimeout - сек.
+)
+
+// LDAPCfg настройки проверки пароля в каталоге LDAP/Active Directory. Учетная запись сотрудника ищется по
+// табельному номеру в атрибуте TabnumAttr, пароль проверяется подключением (bind) под найденной учетной записью.
+type LDAPCfg struct {
+	URL          string        // URL - адрес сервера: ldap://host:389 или ldaps://host:636.
+	StartTLS     bool          // StartTLS - перейти на TLS после подключения по ldap://.
+	BindDN       string        // BindDN - учетная запись для поиска, пусто - анонимный поиск.
+	BindPassword string        // BindPassword - пароль учетной записи для поиска.
+	BaseDN       string        // BaseDN - где искать учетные записи сотрудников.
+	TabnumAttr   string        // TabnumAttr - атрибут с табельным номером.
+	UserFilter   string        // UserFilter - дополнительный фильтр учетных записей.
+	Timeout      time.Duration // Timeout - время ожидания подключения и ответа сервера.
+}
+
+// GetAuthProviders читает AUTH_PROVIDERS: способы проверки пароля через запятую в порядке опроса, db (по умолчанию)
+// и ldap. Неизвестные значения пропускаются.
+func GetAuthProviders() []string {
+	value := os.Getenv("AUTH_PROVIDERS")
+	if strings.TrimSpace(value) == "" {
+		value = defaultAuthProviders
+	}
+
+	var providers []string
+	for _, provider := range strings.Split(value, ",") {
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if provider == AuthProviderDB || provider == AuthProviderLDAP {
+			providers = append(providers, provider)
+		}
+	}
+
+	if len(providers) == 0 {
+		providers = []string{defaultAuthProviders}
+	}
+
+	return providers
+}
+
+// NewLDAPCfg читает LDAP_URL, LDAP_START_TLS (true/false), LDAP_BIND_DN, LDAP_BIND_PASSWORD, LDAP_BASE_DN,
+// LDAP_TABNUM_ATTR, LDAP_USER_FILTER и LDAP_TIMEOUT (сек.).
+func NewLDAPCfg() *LDAPCfg {
+	startTLS, _ := strconv.ParseBool(os.Getenv("LDAP_START_TLS"))
+
+	cfg := &LDAPCfg{
+		URL:          strings.TrimSpace(os.Getenv("LDAP_URL")),
+		StartTLS:     startTLS,
+		BindDN:       os.Getenv("LDAP_BIND_DN"),
+		BindPassword: os.Getenv("LDAP_BIND_PASSWORD"),
+		BaseDN:       os.Getenv("LDAP_BASE_DN"),
+		TabnumAttr:   strings.TrimSpace(os.Getenv("LDAP_TABNUM_ATTR")),
+		UserFilter:   strings.TrimSpace(os.Getenv("LDAP_USER_FILTER")),
+		Timeout:      time.Duration(getEnvPositiveInt("LDAP_TIMEOUT", defaultLDAPTimeout)) * time.Second,
+	}
+
+	if cfg.TabnumAttr == "" {
+		cfg.TabnumAttr = defaultLDAPTabnumAttr
+	}
+
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = defaultLDAPUserFilter
+	}
+
+	return cfg
+}
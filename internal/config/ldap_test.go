@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuthProviders(t *testing.T) {
+	t.Setenv("AUTH_PROVIDERS", "")
+	assert.Equal(t, []string{AuthProviderDB}, GetAuthProviders())
+
+	t.Setenv("AUTH_PROVIDERS", " LDAP, kerberos, db ")
+	assert.Equal(t, []string{AuthProviderLDAP, AuthProviderDB}, GetAuthProviders())
+
+	t.Setenv("AUTH_PROVIDERS", "kerberos")
+	assert.Equal(t, []string{AuthProviderDB}, GetAuthProviders(), "без известных способов остается БД")
+}
+
+func TestNewLDAPCfg(t *testing.T) {
+	t.Setenv("LDAP_URL", "ldap://dc.corp.local:389")
+	t.Setenv("LDAP_START_TLS", "true")
+	t.Setenv("LDAP_BASE_DN", "OU=Staff,DC=corp,DC=local")
+	t.Setenv("LDAP_TABNUM_ATTR", "")
+	t.Setenv("LDAP_USER_FILTER", "")
+	t.Setenv("LDAP_TIMEOUT", "")
+
+	cfg := NewLDAPCfg()
+	assert.True(t, cfg.StartTLS)
+	assert.Equal(t, defaultLDAPTabnumAttr, cfg.TabnumAttr)
+	assert.Equal(t, defaultLDAPUserFilter, cfg.UserFilter)
+	assert.Equal(t, defaultLDAPTimeout*time.Second, cfg.Timeout)
+
+	t.Setenv("LDAP_TABNUM_ATTR", "employeeNumber")
+	t.Setenv("LDAP_TIMEOUT", "2")
+	cfg = NewLDAPCfg()
+	assert.Equal(t, "employeeNumber", cfg.TabnumAttr)
+	assert.Equal(t, 2*time.Second, cfg.Timeout)
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultLoginMaxFailures     = 5   // defaultLoginMaxFailures - неудачных попыток до блокировки учетной записи.
+	defaultLoginMaxFailuresAddr = 20  // defaultLoginMaxFailuresAddr - неудачных попыток до блокировки адреса клиента.
+	defaultLoginBackoffBase     = 1   // defaultLoginBackoffBase - пауза после первой неудачной попытки, сек.
+	defaultLoginBackoffMax      = 60  // defaultLoginBackoffMax - наибольшая пауза между попытками, сек.
+	defaultLoginLockout         = 900 // defaultLoginLockout - 15 минут.
+)
+
+// LoginThrottleCfg настройки защиты входа по паролю от подбора.
+type LoginThrottleCfg struct {
+	MaxFailures     int           // MaxFailures - неудачных попыток по табельному номеру до блокировки.
+	MaxFailuresAddr int           // MaxFailuresAddr - неудачных попыток с адреса клиента до блокировки.
+	BackoffBase     time.Duration // BackoffBase - пауза после первой неудачной попытки, удваивается с каждой следующей.
+	BackoffMax      time.Duration // BackoffMax - наибольшая пауза между попытками.
+	Lockout         time.Duration // Lockout - время блокировки, за это же время без ошибок счетчик сбрасывается.
+}
+
+// NewLoginThrottleCfg читает LOGIN_MAX_FAILURES, LOGIN_MAX_FAILURES_ADDR, LOGIN_BACKOFF_BASE, LOGIN_BACKOFF_MAX и
+// LOGIN_LOCKOUT (сек.).
+func NewLoginThrottleCfg() *LoginThrottleCfg {
+	return &LoginThrottleCfg{
+		MaxFailures:     getEnvPositiveInt("LOGIN_MAX_FAILURES", defaultLoginMaxFailures),
+		MaxFailuresAddr: getEnvPositiveInt("LOGIN_MAX_FAILURES_ADDR", defaultLoginMaxFailuresAddr),
+		BackoffBase:     time.Duration(getEnvPositiveInt("LOGIN_BACKOFF_BASE", defaultLoginBackoffBase)) * time.Second,
+		BackoffMax:      time.Duration(getEnvPositiveInt("LOGIN_BACKOFF_MAX", defaultLoginBackoffMax)) * time.Second,
+		Lockout:         time.Duration(getEnvPositiveInt("LOGIN_LOCKOUT", defaultLoginLockout)) * time.Second,
+	}
+}
+
+// getEnvPositiveInt положительное целое из переменной окружения или значение по умолчанию.
+func getEnvPositiveInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
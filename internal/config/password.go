@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPasswordMinLength  = 8 // defaultPasswordMinLength - наименьшая длина пароля, символов.
+	defaultPasswordMinClasses = 3 // defaultPasswordMinClasses - групп символов из 4: строчные, прописные, цифры, прочие.
+	defaultPasswordHistory    = 5 // defaultPasswordHistory - прежних паролей, которые нельзя повторить.
+	passwordClassesTotal      = 4
+)
+
+// PasswordPolicyCfg требования к паролю, который сотрудник задает при смене.
+type PasswordPolicyCfg struct {
+	MinLength  int // MinLength - наименьшая длина пароля, символов.
+	MinClasses int // MinClasses - сколько групп символов должно быть в пароле: строчные, прописные буквы, цифры, прочие.
+	History    int // History - сколько прежних паролей нельзя повторить, 0 - проверяется только текущий.
+	// DBPasswords - пароли проверяются по svPerformers (db в AUTH_PROVIDERS), только тогда их можно менять и сбрасывать.
+	DBPasswords bool
+}
+
+// NewPasswordPolicyCfg читает PASSWORD_MIN_LENGTH, PASSWORD_MIN_CLASSES (1-4), PASSWORD_HISTORY (0 - без истории) и
+// AUTH_PROVIDERS.
+func NewPasswordPolicyCfg() *PasswordPolicyCfg {
+	cfg := &PasswordPolicyCfg{
+		MinLength:  getEnvPositiveInt("PASSWORD_MIN_LENGTH", defaultPasswordMinLength),
+		MinClasses: getEnvPositiveInt("PASSWORD_MIN_CLASSES", defaultPasswordMinClasses),
+		History:    defaultPasswordHistory,
+	}
+
+	for _, provider := range GetAuthProviders() {
+		if provider == AuthProviderDB {
+			cfg.DBPasswords = true
+		}
+	}
+
+	if cfg.MinClasses > passwordClassesTotal {
+		cfg.MinClasses = passwordClassesTotal
+	}
+
+	if history, err := strconv.Atoi(strings.TrimSpace(os.Getenv("PASSWORD_HISTORY"))); err == nil && history >= 0 {
+		cfg.History = history
+	}
+
+	return cfg
+}
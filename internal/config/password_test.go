@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPasswordPolicyCfg(t *testing.T) {
+	t.Setenv("PASSWORD_MIN_LENGTH", "")
+	t.Setenv("PASSWORD_MIN_CLASSES", "")
+	t.Setenv("PASSWORD_HISTORY", "")
+	t.Setenv("AUTH_PROVIDERS", "")
+
+	cfg := NewPasswordPolicyCfg()
+	assert.Equal(t, defaultPasswordMinLength, cfg.MinLength)
+	assert.Equal(t, defaultPasswordMinClasses, cfg.MinClasses)
+	assert.Equal(t, defaultPasswordHistory, cfg.History)
+	assert.True(t, cfg.DBPasswords, "по умолчанию пароли в svPerformers")
+
+	t.Setenv("PASSWORD_MIN_LENGTH", "12")
+	t.Setenv("PASSWORD_MIN_CLASSES", "7")
+	t.Setenv("PASSWORD_HISTORY", "0")
+
+	cfg = NewPasswordPolicyCfg()
+	assert.Equal(t, 12, cfg.MinLength)
+	assert.Equal(t, passwordClassesTotal, cfg.MinClasses, "групп символов всего четыре")
+	assert.Equal(t, 0, cfg.History, "история отключена")
+
+	t.Setenv("PASSWORD_MIN_LENGTH", "-1")
+	t.Setenv("PASSWORD_HISTORY", "-3")
+
+	cfg = NewPasswordPolicyCfg()
+	assert.Equal(t, defaultPasswordMinLength, cfg.MinLength)
+	assert.Equal(t, defaultPasswordHistory, cfg.History)
+
+	t.Setenv("AUTH_PROVIDERS", "ldap")
+	assert.False(t, NewPasswordPolicyCfg().DBPasswords, "пароли только в LDAP")
+
+	t.Setenv("AUTH_PROVIDERS", "ldap,db")
+	assert.True(t, NewPasswordPolicyCfg().DBPasswords)
+}
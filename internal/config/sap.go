@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	SapFormatCSV      = "csv"        // SapFormatCSV - CSV, разделитель ';', UTF-8.
+	SapFormatXML      = "xml"        // SapFormatXML - XML в структуре IDoc.
+	defaultSapDir     = "export/sap" // defaultSapDir - каталог выгрузки по умолчанию.
+	defaultSapFormats = "csv,xml"
+)
+
+// SapExportCfg настройки выгрузки в SAP.
+type SapExportCfg struct {
+	Dir     string   // Dir - каталог, в который пишутся файлы выгрузки.
+	Formats []string // Formats - форматы файлов выгрузки.
+}
+
+// NewSapExportCfg читает SAP_EXPORT_DIR (каталог выгрузки) и SAP_EXPORT_FORMATS (csv, xml через запятую).
+func NewSapExportCfg() *SapExportCfg {
+	dir := strings.TrimSpace(os.Getenv("SAP_EXPORT_DIR"))
+	if dir == "" {
+		dir = defaultSapDir
+	}
+
+	formats := os.Getenv("SAP_EXPORT_FORMATS")
+	if strings.TrimSpace(formats) == "" {
+		formats = defaultSapFormats
+	}
+
+	cfg := &SapExportCfg{Dir: dir}
+	for _, format := range strings.Split(formats, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == SapFormatCSV || format == SapFormatXML {
+			cfg.Formats = append(cfg.Formats, format)
+		}
+	}
+
+	return cfg
+}
@@ -6,17 +6,32 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gorilla/sessions"
 )
 
 const (
-	sessionName          = "fgw_session"
-	SessionPerformerKey  = "performer_id"
-	SessionRoleKey       = "role_id"
-	SessionAuthPerformer = "authenticated"
-	maxAge               = 86400 * 7
-	pathToDefault        = "/"
+	sessionName                  = "fgw_session"
+	SessionPerformerKey          = "performer_id"
+	SessionRoleKey               = "role_id"     // SessionRoleKey - роль сотрудника в AForms.
+	SessionRoleFGWKey            = "role_fgw_id" // SessionRoleFGWKey - роль сотрудника в складском приложении FGW.
+	SessionAuthPerformer         = "authenticated"
+	SessionCSRFKey               = "csrf_token"            // SessionCSRFKey - CSRF-токен сессии.
+	SessionPermissionsKey        = "permissions"           // SessionPermissionsKey - коды прав роли AForms через запятую.
+	SessionPermissionsFGWKey     = "permissions_fgw"       // SessionPermissionsFGWKey - коды прав роли FGW через запятую.
+	SessionPermissionsVerKey     = "permissions_ver"       // SessionPermissionsVerKey - версия прав ролей на момент кеширования.
+	SessionPassMustChangeKey     = "pass_must_change"      // SessionPassMustChangeKey - вход по временному паролю, доступна только его смена.
+	SessionTwoFactorEnrollKey    = "two_factor_enroll"     // SessionTwoFactorEnrollKey - 2FA обязательна, но не подключена: доступно только подключение.
+	SessionTwoFactorPendingKey   = "two_factor_pending"    // SessionTwoFactorPendingKey - табельный номер, пароль проверен, ожидается код 2FA.
+	SessionTwoFactorPendingAtKey = "two_factor_pending_at" // SessionTwoFactorPendingAtKey - время проверки пароля, Unix.
+	CSRFHeader                   = "X-CSRF-Token"          // CSRFHeader - заголовок, в котором fetch-запросы передают CSRF-токен.
+	CSRFFormField                = "csrf_token"            // CSRFFormField - поле HTML-формы с CSRF-токеном.
+	maxAge                       = 86400 * 7
+	pathToDefault                = "/"
+
+	SessionRegistryMemory = "memory" // SessionRegistryMemory - реестр сессий в памяти процесса.
+	SessionRegistryDB     = "db"     // SessionRegistryDB - реестр сессий в БД, общий для нескольких экземпляров.
 )
 
 var Store *sessions.CookieStore
@@ -49,6 +64,15 @@ func getSecretKey() string {
 	return base64.StdEncoding.EncodeToString(key)
 }
 
+// GetSessionRegistry читает SESSION_REGISTRY: memory (по умолчанию) или db.
+func GetSessionRegistry() string {
+	if strings.ToLower(strings.TrimSpace(os.Getenv("SESSION_REGISTRY"))) == SessionRegistryDB {
+		return SessionRegistryDB
+	}
+
+	return SessionRegistryMemory
+}
+
 func GetSessionName() string {
 	return sessionName
 }
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTOTPIssuer = "FGW_WEB" // defaultTOTPIssuer - название системы в приложении-аутентификаторе.
+	totpKeyMinLen     = 32        // totpKeyMinLen - наименьшая длина ключа шифрования секретов, символов.
+)
+
+// TOTPCfg настройки двухфакторной аутентификации по одноразовым кодам (TOTP, RFC 6238).
+type TOTPCfg struct {
+	Issuer        string       // Issuer - название системы в приложении-аутентификаторе.
+	RequiredRoles map[int]bool // RequiredRoles - роли AForms или FGW, которым 2FA обязательна.
+	Key           []byte       // Key - ключ шифрования секретов в БД (AES-256), nil - подключение 2FA закрыто.
+}
+
+// NewTOTPCfg читает TOTP_ISSUER, TOTP_REQUIRED_ROLES (ид ролей через запятую) и TOTP_KEY (не короче 32 символов).
+// Без TOTP_KEY подключить 2FA нельзя, а короткий ключ или TOTP_REQUIRED_ROLES без ключа - ошибка запуска: секреты
+// открыто не хранятся. Заданный ключ менять нельзя - подключенная 2FA перестанет работать.
+func NewTOTPCfg() (*TOTPCfg, error) {
+	cfg := &TOTPCfg{
+		Issuer:        strings.TrimSpace(os.Getenv("TOTP_ISSUER")),
+		RequiredRoles: make(map[int]bool),
+	}
+
+	if cfg.Issuer == "" {
+		cfg.Issuer = defaultTOTPIssuer
+	}
+
+	for _, role := range strings.Split(os.Getenv("TOTP_REQUIRED_ROLES"), ",") {
+		if roleId, err := strconv.Atoi(strings.TrimSpace(role)); err == nil && roleId > 0 {
+			cfg.RequiredRoles[roleId] = true
+		}
+	}
+
+	switch key := os.Getenv("TOTP_KEY"); {
+	case len(key) >= totpKeyMinLen:
+		hash := sha256.Sum256([]byte(key))
+		cfg.Key = hash[:]
+	case key != "":
+		return nil, fmt.Errorf("TOTP_KEY короче %d символов", totpKeyMinLen)
+	case len(cfg.RequiredRoles) > 0:
+		return nil, errors.New("TOTP_REQUIRED_ROLES задан без TOTP_KEY: секреты 2FA не шифруются")
+	default:
+		log.Println("TOTP_KEY не задан: подключение 2FA закрыто")
+	}
+
+	return cfg, nil
+}
+
+// Enabled задан ли ключ шифрования секретов, без него 2FA не подключается.
+func (c *TOTPCfg) Enabled() bool {
+	return len(c.Key) > 0
+}
+
+// IsRequired обязательна ли 2FA сотруднику с ролями roleIds.
+func (c *TOTPCfg) IsRequired(roleIds ...int) bool {
+	for _, roleId := range roleIds {
+		if c.RequiredRoles[roleId] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTOTPCfg(t *testing.T) {
+	t.Setenv("TOTP_ISSUER", "")
+	t.Setenv("TOTP_REQUIRED_ROLES", "")
+	t.Setenv("TOTP_KEY", "")
+
+	cfg, err := NewTOTPCfg()
+	require.NoError(t, err)
+	assert.Equal(t, defaultTOTPIssuer, cfg.Issuer)
+	assert.Empty(t, cfg.RequiredRoles, "по умолчанию 2FA необязательна")
+	assert.Nil(t, cfg.Key)
+	assert.False(t, cfg.Enabled(), "без ключа 2FA не подключается")
+	assert.False(t, cfg.IsRequired(3, 5))
+
+	t.Setenv("TOTP_ISSUER", "Завод")
+	t.Setenv("TOTP_REQUIRED_ROLES", " 3, x, -1, 7")
+	t.Setenv("TOTP_KEY", "0123456789abcdef0123456789abcdef")
+
+	cfg, err = NewTOTPCfg()
+	require.NoError(t, err)
+	assert.Equal(t, "Завод", cfg.Issuer)
+	assert.Equal(t, map[int]bool{3: true, 7: true}, cfg.RequiredRoles)
+	assert.Len(t, cfg.Key, 32)
+	assert.True(t, cfg.Enabled())
+	assert.True(t, cfg.IsRequired(4, 3), "обязательна по одной из ролей")
+	assert.False(t, cfg.IsRequired(4, 5))
+
+	t.Setenv("TOTP_KEY", "short")
+	_, err = NewTOTPCfg()
+	assert.Error(t, err, "короткий ключ - ошибка запуска")
+
+	t.Setenv("TOTP_KEY", "")
+	_, err = NewTOTPCfg()
+	assert.Error(t, err, "обязательная 2FA без ключа - ошибка запуска")
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"net/http"
+)
+
+const (
+	maxImportFileSize = 10 << 20 // 10 МБ
+	importFileField   = "file"
+)
+
+type AFormsPerformerHandlerHTML struct {
+	aformsPerformerService service.AFormsPerformerUseCase
+	logg                   *common.Logger
+	authMiddleware         *handler.AuthMiddleware
+}
+
+func NewAFormsPerformerHandlerHTML(aformsPerformerService service.AFormsPerformerUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *AFormsPerformerHandlerHTML {
+	return &AFormsPerformerHandlerHTML{aformsPerformerService: aformsPerformerService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (a *AFormsPerformerHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/aforms-performers/import", a.authMiddleware.RequirePermission(model.AppAForms, model.PermPerformersEdit, a.HandleImportGalaktika))
+}
+
+// HandleImportGalaktika загрузка выгрузки сотрудников из Галактики.
+func (a *AFormsPerformerHandlerHTML) HandleImportGalaktika(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	editUser, ok := a.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	file, _, err := r.FormFile(importFileField)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+	defer file.Close()
+
+	report, err := a.aformsPerformerService.ImportGalaktika(r.Context(), file, editUser)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "Импорт сотрудников завершен",
+		"report":  report,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, r)
+}
@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+const (
+	tmplAdminApiTokensHTML = "api_tokens.html"
+)
+
+type ApiTokenHandlerHTML struct {
+	apiTokenService  service.ApiTokenUseCase
+	performerService service.PerformerUseCase
+	roleService      service.RoleUseCase
+	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
+}
+
+func NewApiTokenHandlerHTML(
+	apiTokenService service.ApiTokenUseCase,
+	performerService service.PerformerUseCase,
+	roleService service.RoleUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *ApiTokenHandlerHTML {
+
+	return &ApiTokenHandlerHTML{
+		apiTokenService:  apiTokenService,
+		performerService: performerService,
+		roleService:      roleService,
+		logg:             logg,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (a *ApiTokenHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/api-tokens", a.authMiddleware.RequirePermission(model.AppAForms, model.PermApiTokensManage, a.AllApiTokensHTML))
+	mux.HandleFunc("/admin/api-tokens/add", a.authMiddleware.RequirePermission(model.AppAForms, model.PermApiTokensManage, a.HandleJSONAdd))
+	mux.HandleFunc("/admin/api-tokens/revoke", a.authMiddleware.RequirePermission(model.AppAForms, model.PermApiTokensManage, a.HandleJSONRevoke))
+}
+
+// AllApiTokensHTML страница API-токенов: выданные токены и форма выдачи.
+func (a *ApiTokenHandlerHTML) AllApiTokensHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", a.logg, r)
+
+		return
+	}
+
+	performerId, performerRoleId, err := a.getSessionPerformerData(w, r)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, err.Error(), a.logg, r)
+
+		return
+	}
+
+	apiTokens, err := a.apiTokenService.GetApiTokens(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), a.logg, r)
+
+		return
+	}
+
+	performer, err := a.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), a.logg, r)
+
+		return
+	}
+
+	role, err := a.roleService.FindRoleById(r.Context(), performerRoleId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), a.logg, r)
+
+		return
+	}
+
+	data := struct {
+		Title         string
+		CurrentPage   string
+		ApiTokens     []*model.ApiToken
+		Scopes        []string
+		PerformerFIO  string
+		PerformerId   int
+		PerformerRole string
+	}{
+		Title:         "API-токены",
+		CurrentPage:   "api_tokens",
+		ApiTokens:     apiTokens,
+		Scopes:        model.ApiTokenScopes,
+		PerformerFIO:  performer.FIO,
+		PerformerId:   performerId,
+		PerformerRole: role.Name,
+	}
+
+	a.renderPages(w, tmplAdminHTML, data, r, tmplAdminApiTokensHTML, tmplAdminPerformersHTML, tmplAdminRolesHTML, tmplAdminSectorsHTML, tmplAdminProductsHTML, tmplAdminSessionsHTML)
+}
+
+// HandleJSONAdd выдать токен. Токен есть только в ответе, в БД хранится его хеш.
+func (a *ApiTokenHandlerHTML) HandleJSONAdd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var create model.ApiTokenCreate
+	if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	performerId, _ := a.authMiddleware.GetPerformerId(r)
+
+	created, err := a.apiTokenService.CreateApiToken(r.Context(), &create, performerId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json_api.WriteJSON(w, created, r)
+}
+
+// HandleJSONRevoke отозвать токен: ?tokenId=N.
+func (a *ApiTokenHandlerHTML) HandleJSONRevoke(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	tokenId := convert.ConvStrToInt(r.URL.Query().Get("tokenId"))
+	if err := a.apiTokenService.RevokeApiToken(r.Context(), tokenId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "Токен отозван",
+		"tokenId": tokenId,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, r)
+}
+
+func (a *ApiTokenHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	a.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	a.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (a *ApiTokenHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+		}).ParseFiles(prefixTmplAdmin + tmpl)
+	if err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+func (a *ApiTokenHandlerHTML) renderPages(
+	w http.ResponseWriter, tmpl string, data interface{}, r *http.Request, addTemplates ...string) {
+
+	templatePaths := []string{prefixDefaultTmpl + tmpl}
+
+	for _, addTmpl := range addTemplates {
+		templatePaths = append(templatePaths, prefixAdminTmpl+addTmpl)
+	}
+
+	parseTmpl, err := template.New(tmpl).Funcs(template.FuncMap{
+		"formatDateTime": convert.FormatDateTime,
+		"add":            func(a, b int) int { return a + b },
+		"sub":            func(a, b int) int { return a - b },
+		"csrfToken":      func() string { return a.authMiddleware.CSRFToken(r) },
+	}).ParseFiles(templatePaths...)
+	if err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+// getSessionPerformerData получить данные о сеансе сотрудника.
+func (a *ApiTokenHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r *http.Request) (int, int, error) {
+	performerId, ok := a.authMiddleware.GetPerformerId(r)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, a.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	performerRole, ok := a.authMiddleware.GetRoleId(r, model.AppAForms)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, a.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	return performerId, performerRole, nil
+}
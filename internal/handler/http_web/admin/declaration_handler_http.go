@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"encoding/json"
+	"net/http"
+)
+
+type DeclarationHandlerHTML struct {
+	complianceService service.ComplianceUseCase
+	logg              *common.Logger
+	authMiddleware    *handler.AuthMiddleware
+}
+
+func NewDeclarationHandlerHTML(complianceService service.ComplianceUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *DeclarationHandlerHTML {
+	return &DeclarationHandlerHTML{complianceService: complianceService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (d *DeclarationHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/declarations/add", d.authMiddleware.RequirePermission(model.AppAForms, model.PermDeclarationsEdit, d.HandleJSONAdd))
+}
+
+// HandleJSONAdd прикрепить декларацию о соответствии к продукции.
+func (d *DeclarationHandlerHTML) HandleJSONAdd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := d.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	var declaration model.Declaration
+	if err := json.NewDecoder(r.Body).Decode(&declaration); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if err := d.complianceService.AddDeclaration(r.Context(), &declaration, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json_api.WriteJSON(w, model.DeclarationUpdate{Success: true, Message: "Декларация добавлена"}, r)
+}
@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"net/http"
+)
+
+type PackStationHandlerHTML struct {
+	packStationService service.PackStationUseCase
+	logg               *common.Logger
+	authMiddleware     *handler.AuthMiddleware
+}
+
+func NewPackStationHandlerHTML(packStationService service.PackStationUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *PackStationHandlerHTML {
+	return &PackStationHandlerHTML{packStationService: packStationService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (p *PackStationHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/pack-stations/add", p.authMiddleware.RequirePermission(model.AppAForms, model.PermPackStationsEdit, p.HandleJSONAdd))
+	mux.HandleFunc("/admin/pack-stations/upd", p.authMiddleware.RequirePermission(model.AppAForms, model.PermPackStationsEdit, p.HandleJSONUpd))
+}
+
+// HandleJSONAdd добавить станцию упаковки.
+func (p *PackStationHandlerHTML) HandleJSONAdd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	var station model.PackStation
+	if err := json.NewDecoder(r.Body).Decode(&station); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if err := p.packStationService.AddPackStation(r.Context(), &station, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json_api.WriteJSON(w, model.PackStationUpdate{Success: true, Message: "Станция упаковки добавлена"}, r)
+}
+
+// HandleJSONUpd обновить станцию упаковки: ?stationId=N.
+func (p *PackStationHandlerHTML) HandleJSONUpd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPut {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	stationId := convert.ConvStrToInt(r.URL.Query().Get("stationId"))
+
+	var station model.PackStation
+	if err := json.NewDecoder(r.Body).Decode(&station); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	exists, err := p.packStationService.ExistPackStation(r.Context(), stationId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return
+	}
+
+	if err = p.packStationService.UpdPackStation(r.Context(), stationId, &station, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, model.PackStationUpdate{Success: true, Message: "Станция упаковки обновлена"}, r)
+}
@@ -36,19 +36,22 @@ const (
 var authPerformerId int
 
 type PerformerHandlerHTML struct {
-	performerService service.PerformerUseCase
-	roleService      service.RoleUseCase
-	logg             *common.Logger
-	authMiddleware   *handler.AuthMiddleware
+	performerService       service.PerformerUseCase
+	roleService            service.RoleUseCase
+	aformsPerformerService service.AFormsPerformerUseCase
+	passwordService        service.PasswordUseCase
+	logg                   *common.Logger
+	authMiddleware         *handler.AuthMiddleware
 }
 
-func NewPerformerHandlerHTML(performerService service.PerformerUseCase, roleService service.RoleUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *PerformerHandlerHTML {
-	return &PerformerHandlerHTML{performerService: performerService, roleService: roleService, logg: logg, authMiddleware: authMiddleware}
+func NewPerformerHandlerHTML(performerService service.PerformerUseCase, roleService service.RoleUseCase, aformsPerformerService service.AFormsPerformerUseCase, passwordService service.PasswordUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *PerformerHandlerHTML {
+	return &PerformerHandlerHTML{performerService: performerService, roleService: roleService, aformsPerformerService: aformsPerformerService, passwordService: passwordService, logg: logg, authMiddleware: authMiddleware}
 }
 
 func (p *PerformerHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
-	mux.HandleFunc("/admin/performers", p.authMiddleware.RequireAuth(p.authMiddleware.RequireRole([]int{3}, p.AllPerformersHTML)))
-	mux.HandleFunc("/admin/performers/upd", p.authMiddleware.RequireAuth(p.authMiddleware.RequireRole([]int{3}, p.HandleJSONUpdate)))
+	mux.HandleFunc("/admin/performers", p.authMiddleware.RequirePermission(model.AppAForms, model.PermPerformersEdit, p.AllPerformersHTML))
+	mux.HandleFunc("/admin/performers/upd", p.authMiddleware.RequirePermission(model.AppAForms, model.PermPerformersEdit, p.HandleJSONUpdate))
+	mux.HandleFunc("/admin/performers/password-reset", p.authMiddleware.RequirePermission(model.AppAForms, model.PermPasswordReset, p.HandleJSONPasswordReset))
 }
 
 func (p *PerformerHandlerHTML) AllPerformersHTML(w http.ResponseWriter, r *http.Request) {
@@ -89,6 +92,13 @@ func (p *PerformerHandlerHTML) AllPerformersHTML(w http.ResponseWriter, r *http.
 		return
 	}
 
+	sectors, err := p.aformsPerformerService.GetSectorNamesByTabnum(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), p.logg, r)
+
+		return
+	}
+
 	performer, err := p.performerService.FindByIdPerformer(r.Context(), performerId)
 	if err != nil {
 		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), p.logg, r)
@@ -120,19 +130,22 @@ func (p *PerformerHandlerHTML) AllPerformersHTML(w http.ResponseWriter, r *http.
 	data := struct {
 		Title         string
 		CurrentPage   string
-		Performers    []*model.Performer
+		Performers    []*json_api.PerformerDetail
 		Roles         []*model.Role
+		Sectors       map[int]string
 		PerformerFIO  string
 		PerformerId   int
 		PerformerRole string
 		Pagination    model.Pagination
 		SearchQuery   string
 		IsSearch      bool
+		CanResetPass  bool
 	}{
 		Title:         "Список сотрудников",
 		CurrentPage:   "performers",
-		Performers:    performers,
+		Performers:    newPerformerDetails(performers),
 		Roles:         roles,
+		Sectors:       sectors,
 		PerformerFIO:  performer.FIO,
 		PerformerId:   performerId,
 		PerformerRole: role.Name,
@@ -146,11 +159,12 @@ func (p *PerformerHandlerHTML) AllPerformersHTML(w http.ResponseWriter, r *http.
 			EndItem:        endItem,
 			PerformerIdStr: performerId,
 		},
-		SearchQuery: searchPattern,
-		IsSearch:    searchPattern != "",
+		SearchQuery:  searchPattern,
+		IsSearch:     searchPattern != "",
+		CanResetPass: p.authMiddleware.HasPermission(r, model.AppAForms, model.PermPasswordReset),
 	}
 
-	p.renderPages(w, tmplAdminHTML, data, r, tmplAdminPerformersHTML, tmplAdminRolesHTML)
+	p.renderPages(w, tmplAdminHTML, data, r, tmplAdminPerformersHTML, tmplAdminRolesHTML, tmplAdminSectorsHTML, tmplAdminProductsHTML, tmplAdminSessionsHTML, tmplAdminApiTokensHTML)
 }
 
 // searchPerformerWithPagination поиск сотрудника с пагинацией.
@@ -258,6 +272,41 @@ func (p *PerformerHandlerHTML) HandleJSONUpdate(w http.ResponseWriter, r *http.R
 	json_api.WriteJSON(w, response, r)
 }
 
+// HandleJSONPasswordReset сбросить пароль сотрудника: ?performerId=N. Сотрудник получает временный пароль, его
+// сессии и токены обновления отзываются.
+func (p *PerformerHandlerHTML) HandleJSONPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	adminId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	performerId := convert.ConvStrToInt(r.URL.Query().Get("performerId"))
+	reset, err := p.passwordService.ResetPassword(r.Context(), performerId, adminId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if reset.Revoked, err = p.authMiddleware.RevokePerformerSessions(r.Context(), performerId); err != nil {
+		p.logg.LogE(msg.E3216, err)
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, reset, r)
+}
+
 func (p *PerformerHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
 	data := struct {
 		Title      string
@@ -284,6 +333,7 @@ func (p *PerformerHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, da
 			"formatDateTime": convert.FormatDateTime,
 			"add":            func(a, b int) int { return a + b },
 			"sub":            func(a, b int) int { return a - b },
+			"csrfToken":      func() string { return p.authMiddleware.CSRFToken(r) },
 		}).ParseFiles(prefixDefaultTmpl + tmpl)
 
 	if err != nil {
@@ -313,6 +363,7 @@ func (p *PerformerHandlerHTML) renderPages(
 			"formatDateTime": convert.FormatDateTime,
 			"add":            func(a, b int) int { return a + b },
 			"sub":            func(a, b int) int { return a - b },
+			"csrfToken":      func() string { return p.authMiddleware.CSRFToken(r) },
 		}).ParseFiles(templatePaths...)
 	if err != nil {
 		p.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
@@ -337,7 +388,7 @@ func (p *PerformerHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r
 	}
 	authPerformerId = performerId
 
-	performerRole, ok := p.authMiddleware.GetRoleId(r)
+	performerRole, ok := p.authMiddleware.GetRoleId(r, model.AppAForms)
 	if !ok {
 		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, p.logg, r)
 
@@ -346,3 +397,13 @@ func (p *PerformerHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r
 
 	return performerId, performerRole, nil
 }
+
+// newPerformerDetails сотрудники для страницы без пароля и кода доступа.
+func newPerformerDetails(performers []*model.Performer) []*json_api.PerformerDetail {
+	details := make([]*json_api.PerformerDetail, 0, len(performers))
+	for _, performer := range performers {
+		details = append(details, json_api.NewPerformerDetail(performer))
+	}
+
+	return details
+}
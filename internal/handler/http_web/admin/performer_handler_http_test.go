@@ -0,0 +1,199 @@
+package admin
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPassHash     = "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z3ZoGBf1vpgGdN6ZbfEXXVQy"
+	testBadge        = "2000000010011"
+	testTempPassword = "Tmp-7rQ2xK9m"
+)
+
+// fakePerformerService сотрудники админки, неиспользуемые методы не реализованы.
+type fakePerformerService struct {
+	service.PerformerUseCase
+	performers []*model.Performer
+}
+
+func (f *fakePerformerService) GetPerformersCount(_ context.Context) (int, error) {
+	return len(f.performers), nil
+}
+
+func (f *fakePerformerService) GetPerformersWithPagination(_ context.Context, _, _ int) ([]*model.Performer, error) {
+	return f.performers, nil
+}
+
+func (f *fakePerformerService) SearchPerformerById(_ context.Context, _ string) ([]*model.Performer, error) {
+	return f.performers, nil
+}
+
+func (f *fakePerformerService) FindByIdPerformer(_ context.Context, id int) (*model.Performer, error) {
+	for _, performer := range f.performers {
+		if performer.Id == id {
+			return performer, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakePerformerService) ExistPerformer(ctx context.Context, id int) (bool, error) {
+	performer, err := f.FindByIdPerformer(ctx, id)
+
+	return performer != nil, err
+}
+
+func (f *fakePerformerService) UpdPerformer(_ context.Context, _ int, _ *model.Performer) error {
+	return nil
+}
+
+type fakeRoleService struct {
+	service.RoleUseCase
+}
+
+func (f *fakeRoleService) GetAllRole(_ context.Context) ([]*model.Role, error) {
+	return []*model.Role{{Id: 3, Name: "Администратор"}, {Id: 5, Name: "Оператор"}}, nil
+}
+
+func (f *fakeRoleService) FindRoleById(_ context.Context, id int) (*model.Role, error) {
+	return &model.Role{Id: id, Name: "Администратор"}, nil
+}
+
+type fakeAFormsPerformerService struct {
+	service.AFormsPerformerUseCase
+}
+
+func (f *fakeAFormsPerformerService) GetSectorNamesByTabnum(_ context.Context) (map[int]string, error) {
+	return map[int]string{1001: "ВП №1"}, nil
+}
+
+type fakePasswordService struct {
+	service.PasswordUseCase
+}
+
+func (f *fakePasswordService) ResetPassword(_ context.Context, id, _ int) (*model.PasswordReset, error) {
+	return &model.PasswordReset{Success: true, PerformerId: id, TempPassword: testTempPassword}, nil
+}
+
+// fakePermissionRepo репозиторий прав: роль 3 управляет сотрудниками и сбрасывает пароли.
+type fakePermissionRepo struct {
+	repository.PermissionRepository
+}
+
+func (f *fakePermissionRepo) CodesByRole(_ context.Context, roleId int) ([]string, error) {
+	if roleId == 3 {
+		return []string{model.PermPerformersEdit, model.PermPasswordReset}, nil
+	}
+
+	return nil, nil
+}
+
+func (f *fakePermissionRepo) Version(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+// newTestAdminSession cookie и CSRF-токен сессии администратора, сессия собирается как при входе.
+func newTestAdminSession(t *testing.T, authMiddleware *handler.AuthMiddleware, performer *model.Performer) ([]*http.Cookie, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	session, err := config.Store.Get(req, config.GetSessionName())
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+	csrfToken := config.GenerateSessionToken()
+
+	session.Values[config.SessionAuthPerformer] = true
+	session.Values[config.SessionPerformerKey] = performer.Id
+	session.Values[config.SessionRoleKey] = performer.IdRoleAForms
+	session.Values[config.SessionRoleFGWKey] = performer.IdRoleAFGW
+	session.Values["session_token"] = config.GenerateSessionToken()
+	session.Values[config.SessionCSRFKey] = csrfToken
+	session.Values["created_at"] = now
+	session.Values["last_activity"] = now
+
+	require.NoError(t, authMiddleware.CachePermissions(req, session))
+	require.NoError(t, authMiddleware.RegisterSession(req, session))
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, session.Save(req, rec))
+
+	return rec.Result().Cookies(), csrfToken
+}
+
+func TestPerformerResponses_NoSecrets(t *testing.T) {
+	// Шаблоны страниц ищутся от корня репозитория.
+	t.Chdir("../../../..")
+
+	store := sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef"))
+	prevStore := config.Store
+	config.Store = store
+	t.Cleanup(func() { config.Store = prevStore })
+
+	sessionService := service.NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+	permissionService := service.NewPermissionService(&fakePermissionRepo{}, nil, &common.Logger{})
+	authMiddleware := handler.NewAuthMiddleware(store, sessionService, permissionService, nil, nil, &common.Logger{})
+
+	admin := &model.Performer{Id: 1, FIO: "Админ А.А.", BC: "2000000000015", Pass: testPassHash, IdRoleAForms: 3, IdRoleAFGW: 3}
+	performers := &fakePerformerService{performers: []*model.Performer{
+		admin,
+		{Id: 1001, FIO: "Иванов И.И.", BC: testBadge, Pass: testPassHash, IdRoleAForms: 5, IdRoleAFGW: 5},
+	}}
+
+	mux := http.NewServeMux()
+	NewPerformerHandlerHTML(performers, &fakeRoleService{}, &fakeAFormsPerformerService{}, &fakePasswordService{},
+		&common.Logger{}, authMiddleware).ServeHTTPHTMLRouter(mux)
+	protected := authMiddleware.ProtectCSRF(mux)
+
+	cookies, csrfToken := newTestAdminSession(t, authMiddleware, admin)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+	}{
+		{name: "список", method: http.MethodGet, url: "/admin/performers"},
+		{name: "поиск", method: http.MethodGet, url: "/admin/performers?search=1001"},
+		{name: "изменение ролей", method: http.MethodPost, url: "/admin/performers/upd", body: `{"performerId":1001,"idRoleAForms":5,"idRoleAFGW":5}`},
+		{name: "сброс пароля", method: http.MethodPost, url: "/admin/performers/password-reset?performerId=1001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set(config.CSRFHeader, csrfToken)
+			for _, cookie := range cookies {
+				req.AddCookie(cookie)
+			}
+			rec := httptest.NewRecorder()
+
+			protected.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			body := rec.Body.String()
+			assert.Contains(t, body, "1001")
+			assert.NotContains(t, body, testPassHash)
+			assert.NotContains(t, body, testBadge)
+			assert.NotContains(t, body, admin.BC)
+			assert.NotContains(t, body, `"bc"`)
+			assert.NotContains(t, body, `"password"`)
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+const (
+	tmplAdminProductsHTML = "products.html"
+)
+
+type ProductHandlerHTML struct {
+	productService   service.ProductUseCase
+	performerService service.PerformerUseCase
+	roleService      service.RoleUseCase
+	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
+}
+
+func NewProductHandlerHTML(
+	productService service.ProductUseCase,
+	performerService service.PerformerUseCase,
+	roleService service.RoleUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *ProductHandlerHTML {
+
+	return &ProductHandlerHTML{
+		productService:   productService,
+		performerService: performerService,
+		roleService:      roleService,
+		logg:             logg,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (p *ProductHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/products", p.authMiddleware.RequirePermission(model.AppAForms, model.PermProductsEdit, p.AllProductsHTML))
+	mux.HandleFunc("/admin/products/archive", p.authMiddleware.RequirePermission(model.AppAForms, model.PermProductsEdit, p.HandleJSONArchive))
+	mux.HandleFunc("/admin/products/unarchive", p.authMiddleware.RequirePermission(model.AppAForms, model.PermProductsEdit, p.HandleJSONUnarchive))
+	mux.HandleFunc("/admin/products/history/restore", p.authMiddleware.RequirePermission(model.AppAForms, model.PermProductsEdit, p.HandleJSONRestore))
+	mux.HandleFunc("/admin/products/catalogs/map", p.authMiddleware.RequirePermission(model.AppAForms, model.PermProductsEdit, p.HandleJSONMapCatalogs))
+}
+
+// AllProductsHTML страница продукции: действующая и архивная отдельными списками.
+func (p *ProductHandlerHTML) AllProductsHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", p.logg, r)
+
+		return
+	}
+
+	performerId, performerRoleId, err := p.getSessionPerformerData(w, r)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, err.Error(), p.logg, r)
+
+		return
+	}
+
+	filter := model.ProductFilter{
+		ColorId:  convert.ConvStrToInt(r.URL.Query().Get("colorId")),
+		DesignId: convert.ConvStrToInt(r.URL.Query().Get("designId")),
+	}
+
+	products, err := p.productService.GetProductsByArchive(r.Context(), filter)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), p.logg, r)
+
+		return
+	}
+
+	catalogs, err := p.productService.GetProductCatalogs(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), p.logg, r)
+
+		return
+	}
+
+	performer, err := p.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), p.logg, r)
+
+		return
+	}
+
+	role, err := p.roleService.FindRoleById(r.Context(), performerRoleId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), p.logg, r)
+
+		return
+	}
+
+	data := struct {
+		Title         string
+		CurrentPage   string
+		Products      *model.ProductArchiveList
+		Catalogs      *model.ProductCatalogs
+		Filter        model.ProductFilter
+		PerformerFIO  string
+		PerformerId   int
+		PerformerRole string
+	}{
+		Title:         "Продукция",
+		CurrentPage:   "products",
+		Products:      products,
+		Catalogs:      catalogs,
+		Filter:        filter,
+		PerformerFIO:  performer.FIO,
+		PerformerId:   performerId,
+		PerformerRole: role.Name,
+	}
+
+	p.renderPages(w, tmplAdminHTML, data, r, tmplAdminProductsHTML, tmplAdminPerformersHTML, tmplAdminRolesHTML, tmplAdminSectorsHTML, tmplAdminSessionsHTML, tmplAdminApiTokensHTML)
+}
+
+// HandleJSONArchive архивировать продукцию: ?productId=N.
+func (p *ProductHandlerHTML) HandleJSONArchive(w http.ResponseWriter, r *http.Request) {
+	p.handleArchive(w, r, true)
+}
+
+// HandleJSONUnarchive вернуть продукцию из архива: ?productId=N.
+func (p *ProductHandlerHTML) HandleJSONUnarchive(w http.ResponseWriter, r *http.Request) {
+	p.handleArchive(w, r, false)
+}
+
+// HandleJSONRestore восстановить продукцию из версии истории: ?productId=N&version=N.
+func (p *ProductHandlerHTML) HandleJSONRestore(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, productId, ok := p.checkProductRequest(w, r)
+	if !ok {
+		return
+	}
+
+	version := convert.ConvStrToInt(r.URL.Query().Get("version"))
+
+	if err := p.productService.RestoreProductVersion(r.Context(), productId, version, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	response := model.ProductUpdate{
+		Success: true,
+		Message: fmt.Sprintf("Продукция восстановлена из версии %d", version),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, r)
+}
+
+// HandleJSONMapCatalogs привязать продукцию к справочникам цветов и конструкторских наименований по текстовым
+// значениям, в ответе - несопоставленные значения.
+func (p *ProductHandlerHTML) HandleJSONMapCatalogs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	report, err := p.productService.MapProductCatalogs(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, report, r)
+}
+
+func (p *ProductHandlerHTML) handleArchive(w http.ResponseWriter, r *http.Request, archive bool) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, productId, ok := p.checkProductRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+	message := "Продукция перенесена в архив"
+	if archive {
+		err = p.productService.ArchiveProduct(r.Context(), productId, performerId)
+	} else {
+		err = p.productService.UnarchiveProduct(r.Context(), productId, performerId)
+		message = "Продукция возвращена из архива"
+	}
+
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, model.ProductUpdate{Success: true, Message: message}, r)
+}
+
+// checkProductRequest сотрудник сессии и ид существующей продукции из запроса.
+func (p *ProductHandlerHTML) checkProductRequest(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return 0, 0, false
+	}
+
+	productId := convert.ConvStrToInt(r.URL.Query().Get("productId"))
+
+	exists, err := p.productService.ExistProduct(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return 0, 0, false
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return 0, 0, false
+	}
+
+	return performerId, productId, true
+}
+
+func (p *ProductHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	p.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	p.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (p *ProductHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+		}).ParseFiles(prefixTmplAdmin + tmpl)
+	if err != nil {
+		p.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		p.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+func (p *ProductHandlerHTML) renderPages(
+	w http.ResponseWriter, tmpl string, data interface{}, r *http.Request, addTemplates ...string) {
+
+	templatePaths := []string{prefixDefaultTmpl + tmpl}
+
+	for _, addTmpl := range addTemplates {
+		templatePaths = append(templatePaths, prefixAdminTmpl+addTmpl)
+	}
+
+	parseTmpl, err := template.New(tmpl).Funcs(template.FuncMap{
+		"formatDateTime": convert.FormatDateTime,
+		"add":            func(a, b int) int { return a + b },
+		"sub":            func(a, b int) int { return a - b },
+		"csrfToken":      func() string { return p.authMiddleware.CSRFToken(r) },
+	}).ParseFiles(templatePaths...)
+	if err != nil {
+		p.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		p.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+// getSessionPerformerData получить данные о сеансе сотрудника.
+func (p *ProductHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r *http.Request) (int, int, error) {
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, p.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	performerRole, ok := p.authMiddleware.GetRoleId(r, model.AppAForms)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, p.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	return performerId, performerRole, nil
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"slices"
 	"time"
 )
 
@@ -23,21 +24,23 @@ const (
 )
 
 type RoleHandlerHTML struct {
-	roleService      service.RoleUseCase
-	performerService service.PerformerUseCase
-	logg             *common.Logger
-	authMiddleware   *handler.AuthMiddleware
+	roleService       service.RoleUseCase
+	permissionService service.PermissionUseCase
+	performerService  service.PerformerUseCase
+	logg              *common.Logger
+	authMiddleware    *handler.AuthMiddleware
 }
 
-func NewRoleHandlerHTML(roleService service.RoleUseCase, logger *common.Logger, authMiddleware *handler.AuthMiddleware, performerService service.PerformerUseCase) *RoleHandlerHTML {
-	return &RoleHandlerHTML{roleService: roleService, logg: logger, authMiddleware: authMiddleware, performerService: performerService}
+func NewRoleHandlerHTML(roleService service.RoleUseCase, permissionService service.PermissionUseCase, logger *common.Logger, authMiddleware *handler.AuthMiddleware, performerService service.PerformerUseCase) *RoleHandlerHTML {
+	return &RoleHandlerHTML{roleService: roleService, permissionService: permissionService, logg: logger, authMiddleware: authMiddleware, performerService: performerService}
 }
 
 func (r *RoleHandlerHTML) ServerHTTPHTMLRouter(mux *http.ServeMux) {
-	mux.HandleFunc("/admin/roles", r.authMiddleware.RequireAuth(r.authMiddleware.RequireRole([]int{3}, r.AllRoleHTML)))
-	mux.HandleFunc("/admin/roles/add", r.authMiddleware.RequireAuth(r.authMiddleware.RequireRole([]int{3}, r.HandleJSONAdd)))
-	mux.HandleFunc("/admin/roles/upd", r.authMiddleware.RequireAuth(r.authMiddleware.RequireRole([]int{3}, r.HandleJSONUpdate)))
-	mux.HandleFunc("/admin/roles/del", r.authMiddleware.RequireAuth(r.authMiddleware.RequireRole([]int{3}, r.HandleJSONDelete)))
+	mux.HandleFunc("/admin/roles", r.authMiddleware.RequirePermission(model.AppAForms, model.PermRolesEdit, r.AllRoleHTML))
+	mux.HandleFunc("/admin/roles/add", r.authMiddleware.RequirePermission(model.AppAForms, model.PermRolesEdit, r.HandleJSONAdd))
+	mux.HandleFunc("/admin/roles/upd", r.authMiddleware.RequirePermission(model.AppAForms, model.PermRolesEdit, r.HandleJSONUpdate))
+	mux.HandleFunc("/admin/roles/del", r.authMiddleware.RequirePermission(model.AppAForms, model.PermRolesEdit, r.HandleJSONDelete))
+	mux.HandleFunc("/admin/roles/permissions", r.authMiddleware.RequirePermission(model.AppAForms, model.PermRolesEdit, r.HandleJSONPermissions))
 }
 
 func (r *RoleHandlerHTML) AllRoleHTML(w http.ResponseWriter, req *http.Request) {
@@ -77,23 +80,41 @@ func (r *RoleHandlerHTML) AllRoleHTML(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	permissions, err := r.permissionService.GetPermissions(req.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), r.logg, req)
+
+		return
+	}
+
+	rolePermissions, err := r.permissionService.GetRolePermissions(req.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), r.logg, req)
+
+		return
+	}
+
 	data := struct {
-		Title         string
-		CurrentPage   string
-		Roles         []*model.Role
-		PerformerId   int
-		PerformerRole string
-		PerformerFIO  string
+		Title           string
+		CurrentPage     string
+		Roles           []*model.Role
+		Permissions     []*model.Permission
+		RolePermissions map[int]map[string]bool
+		PerformerId     int
+		PerformerRole   string
+		PerformerFIO    string
 	}{
-		Title:         "Список ролей",
-		CurrentPage:   "roles",
-		Roles:         roles,
-		PerformerId:   performerId,
-		PerformerRole: role.Name,
-		PerformerFIO:  performer.FIO,
+		Title:           "Список ролей",
+		CurrentPage:     "roles",
+		Roles:           roles,
+		Permissions:     permissions,
+		RolePermissions: rolePermissions,
+		PerformerId:     performerId,
+		PerformerRole:   role.Name,
+		PerformerFIO:    performer.FIO,
 	}
 
-	r.renderPages(w, tmplAdminHTML, data, req, tmplAdminRolesHTML, tmplAdminPerformersHTML)
+	r.renderPages(w, tmplAdminHTML, data, req, tmplAdminRolesHTML, tmplAdminPerformersHTML, tmplAdminSectorsHTML, tmplAdminProductsHTML, tmplAdminSessionsHTML, tmplAdminApiTokensHTML)
 }
 
 func (r *RoleHandlerHTML) HandleJSONAdd(w http.ResponseWriter, req *http.Request) {
@@ -276,6 +297,64 @@ func (r *RoleHandlerHTML) HandleJSONDelete(w http.ResponseWriter, req *http.Requ
 	json_api.WriteJSON(w, response, req)
 }
 
+// HandleJSONPermissions заменяет права роли. Снять с собственной роли право на управление ролями нельзя, иначе
+// администратор потеряет доступ к этой странице.
+func (r *RoleHandlerHTML) HandleJSONPermissions(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if req.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7004, "Method not allowed", req)
+
+		return
+	}
+
+	performerId, performerRoleId, err := r.getSessionPerformerData(w, req)
+	if err != nil {
+		return
+	}
+
+	var update model.RolePermissionsUpdate
+	if err = json.NewDecoder(req.Body).Decode(&update); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), req)
+
+		return
+	}
+
+	exists, err := r.roleService.ExistRole(req.Context(), update.RoleId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), req)
+
+		return
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", req)
+
+		return
+	}
+
+	if update.RoleId == performerRoleId && !slices.Contains(update.Codes, model.PermRolesEdit) {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, "Нельзя снять право на управление ролями с собственной роли", req)
+
+		return
+	}
+
+	if err = r.permissionService.SetRolePermissions(req.Context(), &update, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), req)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "Права роли обновлены",
+		"roleId":  update.RoleId,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, req)
+}
+
 func (r *RoleHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, req *http.Request) {
 	data := struct {
 		Title      string
@@ -327,6 +406,7 @@ func (r *RoleHandlerHTML) renderPages(
 		"formatDateTime": convert.FormatDateTime,
 		"add":            func(a, b int) int { return a + b },
 		"sub":            func(a, b int) int { return a - b },
+		"csrfToken":      func() string { return r.authMiddleware.CSRFToken(req) },
 	}).ParseFiles(templatePaths...)
 
 	if err != nil {
@@ -352,7 +432,7 @@ func (r *RoleHandlerHTML) getSessionPerformerData(w http.ResponseWriter, req *ht
 	}
 	authPerformerId = performerId
 
-	performerRole, ok := r.authMiddleware.GetRoleId(req)
+	performerRole, ok := r.authMiddleware.GetRoleId(req, model.AppAForms)
 	if !ok {
 		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, r.logg, req)
 
@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+const (
+	tmplAdminSectorsHTML = "sectors.html"
+)
+
+type SectorHandlerHTML struct {
+	sectorService          service.SectorUseCase
+	aformsPerformerService service.AFormsPerformerUseCase
+	performerService       service.PerformerUseCase
+	roleService            service.RoleUseCase
+	logg                   *common.Logger
+	authMiddleware         *handler.AuthMiddleware
+}
+
+func NewSectorHandlerHTML(
+	sectorService service.SectorUseCase,
+	aformsPerformerService service.AFormsPerformerUseCase,
+	performerService service.PerformerUseCase,
+	roleService service.RoleUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *SectorHandlerHTML {
+
+	return &SectorHandlerHTML{
+		sectorService:          sectorService,
+		aformsPerformerService: aformsPerformerService,
+		performerService:       performerService,
+		roleService:            roleService,
+		logg:                   logg,
+		authMiddleware:         authMiddleware,
+	}
+}
+
+func (s *SectorHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/sectors", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSectorsEdit, s.AllSectorsHTML))
+	mux.HandleFunc("/admin/sectors/assign", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSectorsEdit, s.HandleJSONAssign))
+}
+
+// AllSectorsHTML страница привязки сотрудников AForms к печкам.
+func (s *SectorHandlerHTML) AllSectorsHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", s.logg, r)
+
+		return
+	}
+
+	performerId, performerRoleId, err := s.getSessionPerformerData(w, r)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, err.Error(), s.logg, r)
+
+		return
+	}
+
+	sectors, err := s.sectorService.GetAllSectors(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+		return
+	}
+
+	aformsPerformers, err := s.aformsPerformerService.GetAllAFormsPerformers(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+		return
+	}
+
+	performer, err := s.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), s.logg, r)
+
+		return
+	}
+
+	role, err := s.roleService.FindRoleById(r.Context(), performerRoleId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), s.logg, r)
+
+		return
+	}
+
+	data := struct {
+		Title            string
+		CurrentPage      string
+		Sectors          []*model.Sector
+		AFormsPerformers []*model.AFormsPerformer
+		PerformerFIO     string
+		PerformerId      int
+		PerformerRole    string
+	}{
+		Title:            "Привязка операторов к печам",
+		CurrentPage:      "sectors",
+		Sectors:          sectors,
+		AFormsPerformers: aformsPerformers,
+		PerformerFIO:     performer.FIO,
+		PerformerId:      performerId,
+		PerformerRole:    role.Name,
+	}
+
+	s.renderPages(w, tmplAdminHTML, data, r, tmplAdminSectorsHTML, tmplAdminPerformersHTML, tmplAdminRolesHTML, tmplAdminProductsHTML, tmplAdminSessionsHTML, tmplAdminApiTokensHTML)
+}
+
+// HandleJSONAssign обработчик для JSON запросов от Fetch API, привязывает сотрудников к печке.
+func (s *SectorHandlerHTML) HandleJSONAssign(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var req model.SectorAssign
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	exists, err := s.sectorService.ExistSector(r.Context(), req.SectorId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return
+	}
+
+	if err = s.aformsPerformerService.AssignSector(r.Context(), &req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success":      true,
+		"message":      fmt.Sprintf("Печь назначена сотрудникам: %d", len(req.PerformerIds)),
+		"sectorId":     req.SectorId,
+		"performerIds": req.PerformerIds,
+		"updatedAt":    time.Now().Format("02.01.2006 15:04:05"),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, r)
+}
+
+func (s *SectorHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	s.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	s.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (s *SectorHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+		}).ParseFiles(prefixTmplAdmin + tmpl)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+func (s *SectorHandlerHTML) renderPages(
+	w http.ResponseWriter, tmpl string, data interface{}, r *http.Request, addTemplates ...string) {
+
+	templatePaths := []string{prefixDefaultTmpl + tmpl}
+
+	for _, addTmpl := range addTemplates {
+		templatePaths = append(templatePaths, prefixAdminTmpl+addTmpl)
+	}
+
+	parseTmpl, err := template.New(tmpl).Funcs(template.FuncMap{
+		"formatDateTime": convert.FormatDateTime,
+		"add":            func(a, b int) int { return a + b },
+		"sub":            func(a, b int) int { return a - b },
+		"csrfToken":      func() string { return s.authMiddleware.CSRFToken(r) },
+	}).ParseFiles(templatePaths...)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+// getSessionPerformerData получить данные о сеансе сотрудника.
+func (s *SectorHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r *http.Request) (int, int, error) {
+	performerId, ok := s.authMiddleware.GetPerformerId(r)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, s.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	performerRole, ok := s.authMiddleware.GetRoleId(r, model.AppAForms)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, s.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	return performerId, performerRole, nil
+}
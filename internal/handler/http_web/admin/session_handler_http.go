@@ -0,0 +1,372 @@
+package admin
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+const (
+	tmplAdminSessionsHTML = "sessions.html"
+)
+
+type SessionHandlerHTML struct {
+	sessionService   service.SessionUseCase
+	loginThrottle    service.LoginThrottleUseCase
+	performerService service.PerformerUseCase
+	roleService      service.RoleUseCase
+	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
+}
+
+func NewSessionHandlerHTML(
+	sessionService service.SessionUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	performerService service.PerformerUseCase,
+	roleService service.RoleUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *SessionHandlerHTML {
+
+	return &SessionHandlerHTML{
+		sessionService:   sessionService,
+		loginThrottle:    loginThrottle,
+		performerService: performerService,
+		roleService:      roleService,
+		logg:             logg,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (s *SessionHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/admin/sessions", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.AllSessionsHTML))
+	mux.HandleFunc("/admin/sessions/list", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.HandleJSONList))
+	mux.HandleFunc("/admin/sessions/revoke", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.HandleJSONRevoke))
+	mux.HandleFunc("/admin/sessions/revoke-performer", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.HandleJSONRevokePerformer))
+	mux.HandleFunc("/admin/sessions/lockouts", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.HandleJSONLockouts))
+	mux.HandleFunc("/admin/sessions/unlock", s.authMiddleware.RequirePermission(model.AppAForms, model.PermSessionsManage, s.HandleJSONUnlock))
+}
+
+// AllSessionsHTML страница действующих сессий.
+func (s *SessionHandlerHTML) AllSessionsHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", s.logg, r)
+
+		return
+	}
+
+	performerId, performerRoleId, err := s.getSessionPerformerData(w, r)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, err.Error(), s.logg, r)
+
+		return
+	}
+
+	sessions, err := s.getSessionViews(r)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+		return
+	}
+
+	performer, err := s.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), s.logg, r)
+
+		return
+	}
+
+	role, err := s.roleService.FindRoleById(r.Context(), performerRoleId)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusNotFound, err.Error(), s.logg, r)
+
+		return
+	}
+
+	data := struct {
+		Title         string
+		CurrentPage   string
+		Sessions      []*model.SessionView
+		Lockouts      []*model.LoginLockout
+		PerformerFIO  string
+		PerformerId   int
+		PerformerRole string
+	}{
+		Title:         "Активные сессии",
+		CurrentPage:   "sessions",
+		Sessions:      sessions,
+		Lockouts:      s.loginThrottle.GetLockouts(),
+		PerformerFIO:  performer.FIO,
+		PerformerId:   performerId,
+		PerformerRole: role.Name,
+	}
+
+	s.renderPages(w, tmplAdminHTML, data, r, tmplAdminSessionsHTML, tmplAdminPerformersHTML, tmplAdminRolesHTML, tmplAdminSectorsHTML, tmplAdminProductsHTML, tmplAdminApiTokensHTML)
+}
+
+// HandleJSONList действующие сессии: сотрудник, роль, время входа и последней активности, адрес и браузер клиента.
+func (s *SessionHandlerHTML) HandleJSONList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	sessions, err := s.getSessionViews(r)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, sessions, r)
+}
+
+// HandleJSONRevoke завершить сессию: ?sessionId=ид. При следующем запросе сотрудник увидит страницу
+// принудительного выхода.
+func (s *SessionHandlerHTML) HandleJSONRevoke(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	sessionId := r.URL.Query().Get("sessionId")
+	if current, ok := s.authMiddleware.GetSessionId(r); ok && current == sessionId {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, "Текущую сессию завершите выходом из системы", r)
+
+		return
+	}
+
+	if err := s.sessionService.RevokeSessionById(r.Context(), sessionId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, model.SessionRevoke{Success: true, Message: "Сессия завершена", Revoked: 1}, r)
+}
+
+// HandleJSONRevokePerformer завершить все сессии сотрудника: ?performerId=N.
+func (s *SessionHandlerHTML) HandleJSONRevokePerformer(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId := convert.ConvStrToInt(r.URL.Query().Get("performerId"))
+	if current, ok := s.authMiddleware.GetPerformerId(r); ok && current == performerId {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, "Нельзя завершить собственные сессии", r)
+
+		return
+	}
+
+	revoked, err := s.sessionService.RevokePerformerSessions(r.Context(), performerId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	response := model.SessionRevoke{
+		Success: true,
+		Message: fmt.Sprintf("Завершено сессий: %d", revoked),
+		Revoked: revoked,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, response, r)
+}
+
+// HandleJSONLockouts действующие блокировки входа после неудачных попыток.
+func (s *SessionHandlerHTML) HandleJSONLockouts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, s.loginThrottle.GetLockouts(), r)
+}
+
+// HandleJSONUnlock снять блокировку входа: ?kind=performer|addr&value=табельный номер или адрес клиента.
+func (s *SessionHandlerHTML) HandleJSONUnlock(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	if kind != model.LoginLockPerformer && kind != model.LoginLockAddr {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, kind, r)
+
+		return
+	}
+
+	if !s.loginThrottle.UnlockLogin(kind, r.URL.Query().Get("value")) {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "Блокировка не найдена", r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json_api.WriteJSON(w, model.LoginUnlock{Success: true, Message: "Блокировка входа снята"}, r)
+}
+
+// getSessionViews действующие сессии с ФИО сотрудника и наименованием роли.
+func (s *SessionHandlerHTML) getSessionViews(r *http.Request) ([]*model.SessionView, error) {
+	sessions, err := s.sessionService.GetActiveSessions(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleService.GetAllRole(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make(map[int]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.Id] = role.Name
+	}
+
+	currentId, _ := s.authMiddleware.GetSessionId(r)
+	fio := make(map[int]string)
+
+	views := make([]*model.SessionView, 0, len(sessions))
+	for _, session := range sessions {
+		if _, ok := fio[session.PerformerId]; !ok {
+			fio[session.PerformerId] = s.findPerformerFIO(r.Context(), session.PerformerId)
+		}
+
+		views = append(views, &model.SessionView{
+			SessionRecord: *session,
+			PerformerFIO:  fio[session.PerformerId],
+			RoleName:      roleNames[session.RoleId],
+			Current:       session.Id == currentId,
+		})
+	}
+
+	return views, nil
+}
+
+// findPerformerFIO ФИО сотрудника, пусто - сотрудник не найден.
+func (s *SessionHandlerHTML) findPerformerFIO(ctx context.Context, performerId int) string {
+	performer, err := s.performerService.FindByIdPerformer(ctx, performerId)
+	if err != nil {
+		return ""
+	}
+
+	return performer.FIO
+}
+
+func (s *SessionHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	s.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	s.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (s *SessionHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+		}).ParseFiles(prefixTmplAdmin + tmpl)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+func (s *SessionHandlerHTML) renderPages(
+	w http.ResponseWriter, tmpl string, data interface{}, r *http.Request, addTemplates ...string) {
+
+	templatePaths := []string{prefixDefaultTmpl + tmpl}
+
+	for _, addTmpl := range addTemplates {
+		templatePaths = append(templatePaths, prefixAdminTmpl+addTmpl)
+	}
+
+	parseTmpl, err := template.New(tmpl).Funcs(template.FuncMap{
+		"formatDateTime": convert.FormatDateTime,
+		"add":            func(a, b int) int { return a + b },
+		"sub":            func(a, b int) int { return a - b },
+		"csrfToken":      func() string { return s.authMiddleware.CSRFToken(r) },
+	}).ParseFiles(templatePaths...)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
+
+// getSessionPerformerData получить данные о сеансе сотрудника.
+func (s *SessionHandlerHTML) getSessionPerformerData(w http.ResponseWriter, r *http.Request) (int, int, error) {
+	performerId, ok := s.authMiddleware.GetPerformerId(r)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, s.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	performerRole, ok := s.authMiddleware.GetRoleId(r, model.AppAForms)
+	if !ok {
+		http_err.SendErrorHTTP(w, http.StatusUnauthorized, msg.H7005, s.logg, r)
+
+		return 0, 0, fmt.Errorf("%s", msg.H7005)
+	}
+
+	return performerId, performerRole, nil
+}
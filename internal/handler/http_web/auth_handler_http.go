@@ -4,6 +4,7 @@ import (
 	"FGW_WEB/internal/config"
 	"FGW_WEB/internal/handler"
 	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/model"
 	"FGW_WEB/internal/service"
 	"FGW_WEB/pkg/common"
 	"FGW_WEB/pkg/common/msg"
@@ -21,13 +22,23 @@ const (
 	tmplAdminHTML      = "admin.html"
 	tmplRedirectHTML   = "redirect.html"
 	tmplAuthHTML       = "auth.html"
+	tmplPasswordHTML   = "password.html"
+	tmplTwoFactorLogin = "two_factor_login.html"
+	tmplTwoFactorHTML  = "two_factor.html"
 	tmplPerformersHTML = "performers.html"
 	tmplRolesHTML      = "roles.html"
+	tmplSectorsHTML    = "sectors.html"
+	tmplProductsHTML   = "products.html"
+	tmplSessionsHTML   = "sessions.html"
+	tmplApiTokensHTML  = "api_tokens.html"
 
 	urlAdmin              = "/admin"
 	urlFGW                = "/fgw"
 	urlAuth               = "/auth"
 	urlLogin              = "/login"
+	urlPassword           = "/password"
+	urlTwoFactor          = "/2fa"
+	urlTwoFactorLogin     = "/auth/2fa"
 	urlLogoutTempRedirect = "/logout-temp-redirect"
 	urlTempRedirect       = "/temp-redirect"
 	pathToDefault         = "/"
@@ -48,6 +59,9 @@ const (
 type AuthHandlerHTML struct {
 	performerService service.PerformerUseCase
 	roleService      service.RoleUseCase
+	loginThrottle    service.LoginThrottleUseCase
+	passwordService  service.PasswordUseCase
+	twoFactorService service.TwoFactorUseCase
 	logg             *common.Logger
 	authMiddleware   *handler.AuthMiddleware
 }
@@ -68,12 +82,18 @@ type RedirectData struct {
 func NewAuthHandlerHTML(
 	performerService service.PerformerUseCase,
 	roleService service.RoleUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	passwordService service.PasswordUseCase,
+	twoFactorService service.TwoFactorUseCase,
 	logg *common.Logger,
 	authMiddleware *handler.AuthMiddleware) *AuthHandlerHTML {
 
 	return &AuthHandlerHTML{
 		performerService: performerService,
 		roleService:      roleService,
+		loginThrottle:    loginThrottle,
+		passwordService:  passwordService,
+		twoFactorService: twoFactorService,
 		logg:             logg,
 		authMiddleware:   authMiddleware,
 	}
@@ -84,13 +104,16 @@ func (a *AuthHandlerHTML) ServerHTTPRouter(mux *http.ServeMux) {
 	mux.HandleFunc("/login", a.LoginPage)
 	mux.HandleFunc("/auth", a.AuthPerformerHTML)
 	mux.HandleFunc("/logout", a.Logout)
+	mux.HandleFunc("/auth/2fa", a.TwoFactorLoginPage)
+	mux.HandleFunc("/password", a.authMiddleware.RequireAuth(a.PasswordPage))
+	mux.HandleFunc("/2fa", a.authMiddleware.RequireAuth(a.TwoFactorPage))
 	mux.HandleFunc("/fgw", a.authMiddleware.RequireAuth(a.StartPage))
-	mux.HandleFunc("/admin", a.authMiddleware.RequireAuth(a.authMiddleware.RequireRole([]int{3}, a.StartPageAdmin)))
+	mux.HandleFunc("/admin", a.authMiddleware.RequirePermission(model.AppAForms, model.PermAdminAccess, a.StartPageAdmin))
 }
 
 func (a *AuthHandlerHTML) StartPageAdmin(w http.ResponseWriter, r *http.Request) {
 	performerId, ok1 := a.authMiddleware.GetPerformerId(r)
-	performerRole, ok2 := a.authMiddleware.GetRoleId(r)
+	performerRole, ok2 := a.authMiddleware.GetRoleId(r, model.AppAForms)
 
 	if !ok1 || !ok2 {
 		a.redirectToLoginWithHistoryClear(w, r)
@@ -123,12 +146,12 @@ func (a *AuthHandlerHTML) StartPageAdmin(w http.ResponseWriter, r *http.Request)
 		PerformerRole: role.Name,
 	}
 
-	a.renderPages(w, tmplAdminHTML, data, r, tmplPerformersHTML, tmplRolesHTML)
+	a.renderPages(w, tmplAdminHTML, data, r, tmplPerformersHTML, tmplRolesHTML, tmplSectorsHTML, tmplProductsHTML, tmplSessionsHTML, tmplApiTokensHTML)
 }
 
 func (a *AuthHandlerHTML) StartPage(w http.ResponseWriter, r *http.Request) {
 	performerId, ok1 := a.authMiddleware.GetPerformerId(r)
-	performerRole, ok2 := a.authMiddleware.GetRoleId(r)
+	performerRole, ok2 := a.authMiddleware.GetRoleId(r, model.AppFGW)
 
 	if !ok1 || !ok2 {
 		a.redirectToLoginWithHistoryClear(w, r)
@@ -187,9 +210,7 @@ func (a *AuthHandlerHTML) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if token, ok := session.Values["session_token"].(string); ok {
-		if mw, ok := interface{}(a.authMiddleware).(interface{ RemoveSessionToken(token string) }); ok {
-			mw.RemoveSessionToken(token)
-		}
+		a.authMiddleware.RemoveSessionToken(token)
 	}
 
 	for key := range session.Values {
@@ -238,8 +259,20 @@ func (a *AuthHandlerHTML) AuthPerformerHTML(w http.ResponseWriter, r *http.Reque
 	}
 
 	performerId := convert.ConvStrToInt(performerIdStr)
+	addr := handler.ClientAddr(r)
+
+	if throttle := a.loginThrottle.CheckLogin(performerId, addr); !throttle.Allowed {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(throttle.Message), http.StatusFound)
+		return
+	}
 
+	// Попытка, которую не удалось проверить, тоже учитывается: иначе при недоступном способе проверки подбор не
+	// ограничен.
 	authResult, err := a.performerService.AuthPerformer(r.Context(), performerId, performerPass)
+	if err != nil || !authResult.Success {
+		a.loginThrottle.LoginFailed(performerId, addr)
+	}
+
 	if err != nil {
 		if authResult != nil && !authResult.Success {
 			http.Redirect(w, r, "/login?error="+url.QueryEscape(authResult.Message), http.StatusFound)
@@ -250,24 +283,156 @@ func (a *AuthHandlerHTML) AuthPerformerHTML(w http.ResponseWriter, r *http.Reque
 	}
 
 	if authResult.Success {
-		err := a.createSecureSession(w, r, performerId, authResult.Performer.IdRoleAForms)
+		state, err := a.twoFactorService.LoginState(r.Context(), &authResult.Performer)
 		if err != nil {
-			a.renderErrorPage(w, http.StatusInternalServerError, "Ошибка создания сессии", r)
+			a.renderErrorPage(w, http.StatusInternalServerError, msg.H7001, r)
+			return
+		}
+
+		// Попытки входа сбрасываются только после второго шага: подбор кода 2FA ограничивается вместе с паролем.
+		if state == model.TwoFactorVerify {
+			if err = a.beginTwoFactorLogin(w, r, performerId); err != nil {
+				a.renderErrorPage(w, http.StatusInternalServerError, "Ошибка создания сессии", r)
+				return
+			}
+
+			http.Redirect(w, r, urlTwoFactorLogin, http.StatusFound)
 			return
 		}
 
-		a.sendLoginSuccessPage(w, r, authResult.Performer.IdRoleAForms)
+		a.loginThrottle.LoginSucceeded(performerId)
+		a.completeLogin(w, r, &authResult.Performer, state == model.TwoFactorEnroll)
 	} else {
 		http.Redirect(w, r, "/login?error="+url.QueryEscape(authResult.Message), http.StatusFound)
 	}
 }
 
+// completeLogin - создать сессию сотрудника после проверки пароля и, если нужно, кода 2FA. twoFactorEnroll -
+// 2FA обязательна, но не подключена.
+func (a *AuthHandlerHTML) completeLogin(w http.ResponseWriter, r *http.Request, performer *model.Performer, twoFactorEnroll bool) {
+	mustChange, err := a.passwordService.PassMustChange(r.Context(), performer.Id)
+	if err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7001, r)
+		return
+	}
+
+	flags := sessionFlags{passMustChange: mustChange, twoFactorEnroll: twoFactorEnroll}
+	if err = a.createSecureSession(w, r, performer, flags); err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, "Ошибка создания сессии", r)
+		return
+	}
+
+	a.sendLoginSuccessPage(w, r)
+}
+
+// PasswordPage - смена пароля сотрудником: GET - форма, POST - смена. Текущий пароль проверяется, как при входе, с
+// защитой от подбора. После смены остальные сессии и токены обновления сотрудника отзываются, текущая сессия
+// продолжается с новым токеном.
+func (a *AuthHandlerHTML) PasswordPage(w http.ResponseWriter, r *http.Request) {
+	performerId, ok := a.authMiddleware.GetPerformerId(r)
+	if !ok {
+		a.redirectToLoginWithHistoryClear(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		a.renderPasswordPage(w, r, http.StatusOK, "")
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			a.renderErrorPage(w, http.StatusBadRequest, msg.H7007, r)
+			return
+		}
+
+		password := r.FormValue("newPassword")
+		if password != r.FormValue("confirmPassword") {
+			a.renderPasswordPage(w, r, http.StatusUnprocessableEntity, "Новый пароль и его повтор не совпадают")
+			return
+		}
+
+		// Захваченная сессия не должна открывать подбор текущего пароля: попытки учитываются вместе со входом.
+		addr := handler.ClientAddr(r)
+		if throttle := a.loginThrottle.CheckLogin(performerId, addr); !throttle.Allowed {
+			a.renderPasswordPage(w, r, http.StatusTooManyRequests, throttle.Message)
+			return
+		}
+
+		current := r.FormValue("currentPassword")
+		authResult, err := a.performerService.AuthPerformer(r.Context(), performerId, current)
+		if err != nil || !authResult.Success {
+			a.loginThrottle.LoginFailed(performerId, addr)
+			a.renderPasswordPage(w, r, http.StatusUnprocessableEntity, msg.E3210)
+			return
+		}
+		a.loginThrottle.LoginSucceeded(performerId)
+
+		if err = a.passwordService.ChangePassword(r.Context(), performerId, current, password); err != nil {
+			a.renderPasswordPage(w, r, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		if err = a.renewSession(w, r, performerId); err != nil {
+			a.renderErrorPage(w, http.StatusInternalServerError, "Ошибка создания сессии", r)
+			return
+		}
+
+		a.renderRedirectPage(w, r, RedirectData{
+			Title:        "Пароль изменен",
+			Message:      "Пароль изменен. Выполняется перенаправление...",
+			TargetURL:    a.startPageURL(r),
+			TempURL:      urlTempRedirect,
+			ClearHistory: true,
+		})
+	default:
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", a.logg, r)
+	}
+}
+
+func (a *AuthHandlerHTML) renderPasswordPage(w http.ResponseWriter, r *http.Request, status int, errorMsg string) {
+	a.setSecureHTMLHeaders(w)
+
+	data := struct {
+		ErrorMessage string
+		MustChange   bool
+		Policy       *config.PasswordPolicyCfg
+	}{
+		ErrorMessage: errorMsg,
+		MustChange:   a.authMiddleware.PassMustChange(r),
+		Policy:       a.passwordService.Policy(),
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+
+	a.renderPage(w, tmplPasswordHTML, data, r)
+}
+
+// renewSession - отозвать все сессии сотрудника после смены пароля и продолжить текущую с новым токеном.
+func (a *AuthHandlerHTML) renewSession(w http.ResponseWriter, r *http.Request, performerId int) error {
+	session, err := config.Store.Get(r, config.GetSessionName())
+	if err != nil {
+		return err
+	}
+
+	if _, err = a.authMiddleware.RevokePerformerSessions(r.Context(), performerId); err != nil {
+		return err
+	}
+
+	delete(session.Values, config.SessionPassMustChangeKey)
+	session.Values["session_token"] = config.GenerateSessionToken()
+	session.Values[config.SessionCSRFKey] = config.GenerateSessionToken()
+
+	if err = a.authMiddleware.RegisterSession(r, session); err != nil {
+		return err
+	}
+
+	return session.Save(r, w)
+}
+
 // НОВЫЙ МЕТОД: safeRedirectBasedOnRole с использованием общего шаблона
 func (a *AuthHandlerHTML) safeRedirectBasedOnRole(w http.ResponseWriter, r *http.Request, session *sessions.Session) {
-	target := urlFGW
-	if role, ok := session.Values[config.SessionRoleKey].(int); ok && role == 3 {
-		target = urlAdmin
-	}
+	target := a.startPageURL(r)
 
 	data := RedirectData{
 		Title:           "Перенаправление",
@@ -285,6 +450,24 @@ func (a *AuthHandlerHTML) safeRedirectBasedOnRole(w http.ResponseWriter, r *http
 	a.renderRedirectPage(w, r, data)
 }
 
+// startPageURL - страница после входа: смена временного пароля, подключение обязательной 2FA, панель
+// администратора или FGW.
+func (a *AuthHandlerHTML) startPageURL(r *http.Request) string {
+	if a.authMiddleware.PassMustChange(r) {
+		return urlPassword
+	}
+
+	if a.authMiddleware.TwoFactorEnroll(r) {
+		return urlTwoFactor
+	}
+
+	if a.authMiddleware.HasPermission(r, model.AppAForms, model.PermAdminAccess) {
+		return urlAdmin
+	}
+
+	return urlFGW
+}
+
 func (a *AuthHandlerHTML) renderRedirectPage(w http.ResponseWriter, r *http.Request, data RedirectData) {
 	if data.Title == "" {
 		data.Title = "Перенаправление"
@@ -310,11 +493,8 @@ func (a *AuthHandlerHTML) renderRedirectPage(w http.ResponseWriter, r *http.Requ
 }
 
 // Обновленный sendLoginSuccessPage
-func (a *AuthHandlerHTML) sendLoginSuccessPage(w http.ResponseWriter, r *http.Request, roleId int) {
-	target := urlFGW
-	if roleId == 3 {
-		target = urlAdmin
-	}
+func (a *AuthHandlerHTML) sendLoginSuccessPage(w http.ResponseWriter, r *http.Request) {
+	target := a.startPageURL(r)
 
 	data := RedirectData{
 		Title:           "Успешный вход",
@@ -363,18 +543,42 @@ func (a *AuthHandlerHTML) setSecureHTMLHeaders(w http.ResponseWriter) {
 	w.Header().Set("X-Frame-Options", "DENY")
 }
 
-func (a *AuthHandlerHTML) createSecureSession(w http.ResponseWriter, r *http.Request, performerId, roleId int) error {
+// sessionFlags - ограничения сессии после входа.
+type sessionFlags struct {
+	passMustChange  bool // passMustChange - вход по временному паролю.
+	twoFactorEnroll bool // twoFactorEnroll - 2FA обязательна для роли, но не подключена.
+}
+
+func (a *AuthHandlerHTML) createSecureSession(
+	w http.ResponseWriter, r *http.Request, performer *model.Performer, flags sessionFlags) error {
 	session, _ := config.Store.Get(r, config.GetSessionName())
 
+	delete(session.Values, config.SessionTwoFactorPendingKey)
+	delete(session.Values, config.SessionTwoFactorPendingAtKey)
+
 	token := config.GenerateSessionToken()
 
 	session.Values[config.SessionAuthPerformer] = true
-	session.Values[config.SessionPerformerKey] = performerId
-	session.Values[config.SessionRoleKey] = roleId
+	session.Values[config.SessionPerformerKey] = performer.Id
+	session.Values[config.SessionRoleKey] = performer.IdRoleAForms
+	session.Values[config.SessionRoleFGWKey] = performer.IdRoleAFGW
 	session.Values["session_token"] = token
+	session.Values[config.SessionCSRFKey] = config.GenerateSessionToken()
 	session.Values["created_at"] = time.Now().Unix()
 	session.Values["last_activity"] = time.Now().Unix()
 
+	if flags.passMustChange {
+		session.Values[config.SessionPassMustChangeKey] = true
+	} else {
+		delete(session.Values, config.SessionPassMustChangeKey)
+	}
+
+	if flags.twoFactorEnroll {
+		session.Values[config.SessionTwoFactorEnrollKey] = true
+	} else {
+		delete(session.Values, config.SessionTwoFactorEnrollKey)
+	}
+
 	session.Options = &sessions.Options{
 		Path:     pathToDefault,
 		MaxAge:   1800,
@@ -383,6 +587,14 @@ func (a *AuthHandlerHTML) createSecureSession(w http.ResponseWriter, r *http.Req
 		SameSite: http.SameSiteStrictMode,
 	}
 
+	if err := a.authMiddleware.CachePermissions(r, session); err != nil {
+		return err
+	}
+
+	if err := a.authMiddleware.RegisterSession(r, session); err != nil {
+		return err
+	}
+
 	a.setSecureHTMLHeaders(w)
 
 	return session.Save(r, w)
@@ -417,6 +629,7 @@ func (a *AuthHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data in
 		template.FuncMap{
 			"add":            func(a, b int) int { return a + b },
 			"sub":            func(a, b int) int { return a - b },
+			"csrfToken":      func() string { return a.authMiddleware.CSRFToken(r) },
 			"formatDateTime": convert.FormatDateTime,
 		}).ParseFiles(templatePath)
 
@@ -445,6 +658,7 @@ func (a *AuthHandlerHTML) renderPages(
 		template.FuncMap{
 			"add":            func(a, b int) int { return a + b },
 			"sub":            func(a, b int) int { return a - b },
+			"csrfToken":      func() string { return a.authMiddleware.CSRFToken(r) },
 			"formatDateTime": convert.FormatDateTime,
 		}).ParseFiles(templatePaths...)
 
@@ -0,0 +1,190 @@
+package http_web
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_api"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"html/template"
+	"net/http"
+)
+
+const (
+	tmplShiftTasksHTML = "shift_tasks.html"
+)
+
+type ShiftTaskHandlerHTML struct {
+	shiftTaskService service.ShiftTaskUseCase
+	sectorService    service.SectorUseCase
+	productService   service.ProductUseCase
+	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
+}
+
+func NewShiftTaskHandlerHTML(
+	shiftTaskService service.ShiftTaskUseCase,
+	sectorService service.SectorUseCase,
+	productService service.ProductUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *ShiftTaskHandlerHTML {
+
+	return &ShiftTaskHandlerHTML{
+		shiftTaskService: shiftTaskService,
+		sectorService:    sectorService,
+		productService:   productService,
+		logg:             logg,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (s *ShiftTaskHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/fgw/shift-tasks", s.authMiddleware.RequireAuth(s.AllShiftTasksHTML))
+	mux.HandleFunc("/fgw/shift-tasks/add", s.authMiddleware.RequirePermission(model.AppFGW, model.PermShiftTasksEdit, s.HandleJSONAdd))
+}
+
+// AllShiftTasksHTML страница план/факт сменно-суточных заданий: ?date=ГГГГ-ММ-ДД&shift=N.
+func (s *ShiftTaskHandlerHTML) AllShiftTasksHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", s.logg, r)
+
+		return
+	}
+
+	taskDate, err := model.ParseShiftTaskDate(r.URL.Query().Get("date"))
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusBadRequest, err.Error(), s.logg, r)
+
+		return
+	}
+
+	shiftNum := 0
+	if shiftStr := r.URL.Query().Get("shift"); shiftStr != "" {
+		shiftNum = convert.ConvStrToInt(shiftStr)
+	}
+
+	tasks, err := s.shiftTaskService.GetShiftTasks(r.Context(), taskDate, shiftNum)
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+		return
+	}
+
+	canEdit := s.authMiddleware.HasPermission(r, model.AppFGW, model.PermShiftTasksEdit)
+
+	var sectors []*model.Sector
+	var products []*model.Product
+	if canEdit {
+		if sectors, err = s.sectorService.GetAllSectors(r.Context()); err != nil {
+			http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+			return
+		}
+
+		if products, err = s.productService.GetActiveProducts(r.Context()); err != nil {
+			http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+			return
+		}
+	}
+
+	data := struct {
+		Title    string
+		TaskDate string
+		ShiftNum int
+		Tasks    []*model.ShiftTask
+		CanEdit  bool
+		Sectors  []*model.Sector
+		Products []*model.Product
+	}{
+		Title:    "Сменно-суточные задания",
+		TaskDate: taskDate,
+		ShiftNum: shiftNum,
+		Tasks:    tasks,
+		CanEdit:  canEdit,
+		Sectors:  sectors,
+		Products: products,
+	}
+
+	s.renderPage(w, tmplShiftTasksHTML, data, r)
+}
+
+// HandleJSONAdd обработчик для JSON запросов от Fetch API, добавляет задание от имени текущего сотрудника.
+func (s *ShiftTaskHandlerHTML) HandleJSONAdd(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := s.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	var task model.ShiftTask
+	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if err := s.shiftTaskService.AddShiftTask(r.Context(), &task, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json_api.WriteJSON(w, model.ShiftTaskUpdate{Success: true, Message: "Задание добавлено"}, r)
+}
+
+func (s *ShiftTaskHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	s.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	s.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (s *ShiftTaskHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+			"csrfToken":      func() string { return s.authMiddleware.CSRFToken(r) },
+		}).ParseFiles(prefixDefaultTmpl + tmpl)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
@@ -0,0 +1,106 @@
+package http_web
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"html/template"
+	"net/http"
+)
+
+const (
+	tmplStorageAreasHTML = "storage_areas.html"
+)
+
+type StorageAreaHandlerHTML struct {
+	catalogService service.CatalogUseCase
+	logg           *common.Logger
+	authMiddleware *handler.AuthMiddleware
+}
+
+func NewStorageAreaHandlerHTML(
+	catalogService service.CatalogUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *StorageAreaHandlerHTML {
+
+	return &StorageAreaHandlerHTML{
+		catalogService: catalogService,
+		logg:           logg,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (s *StorageAreaHandlerHTML) ServeHTTPHTMLRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/fgw/storage-areas", s.authMiddleware.RequireAuth(s.StorageAreaOccupancyHTML))
+}
+
+// StorageAreaOccupancyHTML страница заполненности участков хранения, участок окрашен по уровню заполненности.
+func (s *StorageAreaHandlerHTML) StorageAreaOccupancyHTML(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", s.logg, r)
+
+		return
+	}
+
+	occupancy, err := s.catalogService.GetStorageAreaOccupancy(r.Context())
+	if err != nil {
+		http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), s.logg, r)
+
+		return
+	}
+
+	data := struct {
+		Title        string
+		StorageAreas []*model.StorageAreaOccupancy
+	}{
+		Title:        "Заполненность склада",
+		StorageAreas: occupancy,
+	}
+
+	s.renderPage(w, tmplStorageAreasHTML, data, r)
+}
+
+func (s *StorageAreaHandlerHTML) renderErrorPage(w http.ResponseWriter, statusCode int, msgCode string, r *http.Request) {
+	data := struct {
+		Title      string
+		MsgCode    string
+		StatusCode int
+		Method     string
+		Path       string
+	}{
+		Title:      "Ошибка",
+		MsgCode:    msgCode,
+		StatusCode: statusCode,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+	}
+
+	w.WriteHeader(statusCode)
+	s.logg.LogHttpErr(msgCode, statusCode, r.Method, r.URL.Path)
+	s.renderPage(w, tmplErrorHTML, data, r)
+}
+
+func (s *StorageAreaHandlerHTML) renderPage(w http.ResponseWriter, tmpl string, data interface{}, r *http.Request) {
+	parseTmpl, err := template.New(tmpl).Funcs(
+		template.FuncMap{
+			"formatDateTime": convert.FormatDateTime,
+			"csrfToken":      func() string { return s.authMiddleware.CSRFToken(r) },
+		}).ParseFiles(prefixDefaultTmpl + tmpl)
+	if err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7002+err.Error(), r)
+
+		return
+	}
+
+	if err = parseTmpl.ExecuteTemplate(w, tmpl, data); err != nil {
+		s.renderErrorPage(w, http.StatusInternalServerError, msg.H7003+err.Error(), r)
+
+		return
+	}
+}
@@ -0,0 +1,245 @@
+package http_web
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common/msg"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/sessions"
+)
+
+const twoFactorPendingTTL = 5 * time.Minute // twoFactorPendingTTL - время на ввод кода 2FA после проверки пароля.
+
+// twoFactorPageData - данные страницы настроек 2FA.
+type twoFactorPageData struct {
+	Status        *model.TwoFactorStatus
+	MustEnroll    bool         // MustEnroll - вход без обязательной 2FA, доступно только ее подключение.
+	Confirming    bool         // Confirming - подключение начато, ожидается код из приложения.
+	Secret        string       // Secret - секрет для ручного ввода в приложение.
+	QRCode        template.URL // QRCode - QR-код секрета, data URI.
+	RecoveryCodes []string     // RecoveryCodes - новые коды восстановления, показываются один раз.
+	Message       string
+	ErrorMessage  string
+}
+
+// beginTwoFactorLogin - пароль проверен, сессия хранит только табельный номер до ввода кода 2FA. Прежняя сессия
+// в браузере завершается.
+func (a *AuthHandlerHTML) beginTwoFactorLogin(w http.ResponseWriter, r *http.Request, performerId int) error {
+	session, _ := config.Store.Get(r, config.GetSessionName())
+
+	if token, ok := session.Values["session_token"].(string); ok {
+		a.authMiddleware.RemoveSessionToken(token)
+	}
+
+	for key := range session.Values {
+		delete(session.Values, key)
+	}
+
+	session.Values[config.SessionTwoFactorPendingKey] = performerId
+	session.Values[config.SessionTwoFactorPendingAtKey] = time.Now().Unix()
+	session.Values[config.SessionCSRFKey] = config.GenerateSessionToken()
+
+	session.Options = &sessions.Options{
+		Path:     pathToDefault,
+		MaxAge:   int(twoFactorPendingTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+
+	a.setSecureHTMLHeaders(w)
+
+	return session.Save(r, w)
+}
+
+// TwoFactorLoginPage - второй шаг входа: GET - форма, POST - проверка кода из приложения или кода восстановления.
+// Неверные коды учитываются в ограничении попыток входа вместе с паролями.
+func (a *AuthHandlerHTML) TwoFactorLoginPage(w http.ResponseWriter, r *http.Request) {
+	session, err := config.Store.Get(r, config.GetSessionName())
+	performerId, ok := pendingTwoFactor(session)
+	if err != nil || !ok {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape("Время ввода кода истекло, войдите снова"), http.StatusFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		a.renderTwoFactorLoginPage(w, r, "")
+	case http.MethodPost:
+		if err = r.ParseForm(); err != nil {
+			a.renderErrorPage(w, http.StatusBadRequest, msg.H7007, r)
+			return
+		}
+
+		addr := handler.ClientAddr(r)
+		if throttle := a.loginThrottle.CheckLogin(performerId, addr); !throttle.Allowed {
+			http.Redirect(w, r, "/login?error="+url.QueryEscape(throttle.Message), http.StatusFound)
+			return
+		}
+
+		verified, err := a.twoFactorService.Verify(r.Context(), performerId, r.FormValue("code"))
+		if err != nil {
+			a.renderErrorPage(w, http.StatusInternalServerError, msg.H7001, r)
+			return
+		}
+
+		if !verified {
+			a.loginThrottle.LoginFailed(performerId, addr)
+			a.renderTwoFactorLoginPage(w, r, msg.E3232)
+			return
+		}
+
+		a.loginThrottle.LoginSucceeded(performerId)
+
+		performer, err := a.performerService.FindByIdPerformer(r.Context(), performerId)
+		if err != nil {
+			a.renderErrorPage(w, http.StatusInternalServerError, msg.H7001, r)
+			return
+		}
+
+		a.completeLogin(w, r, performer, false)
+	default:
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", a.logg, r)
+	}
+}
+
+// TwoFactorPage - настройки 2FA сотрудника: GET - состояние, POST - действие action: begin (новый секрет и QR-код),
+// confirm (подключение по первому коду), regenerate (новые коды восстановления), disable (отключение).
+func (a *AuthHandlerHTML) TwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	performer, ok := a.sessionPerformer(r)
+	if !ok {
+		a.redirectToLoginWithHistoryClear(w, r)
+		return
+	}
+
+	var data twoFactorPageData
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			a.renderErrorPage(w, http.StatusBadRequest, msg.H7007, r)
+			return
+		}
+
+		var err error
+		code := r.FormValue("code")
+
+		switch r.FormValue("action") {
+		case "begin":
+			var enrollment *model.TOTPEnrollment
+			if enrollment, err = a.twoFactorService.BeginEnrollment(r.Context(), performer.Id); err == nil {
+				data.Confirming = true
+				data.Secret = enrollment.Secret
+				data.QRCode = template.URL(enrollment.QRCode)
+			}
+		case "confirm":
+			if data.RecoveryCodes, err = a.twoFactorService.ConfirmEnrollment(r.Context(), performer.Id, code); err == nil {
+				data.Message = "Двухфакторная аутентификация подключена"
+				err = a.clearTwoFactorEnroll(w, r)
+			} else {
+				data.Confirming = true
+			}
+		case "regenerate":
+			data.RecoveryCodes, err = a.twoFactorService.RegenerateRecoveryCodes(r.Context(), performer.Id, code)
+		case "disable":
+			if err = a.twoFactorService.Disable(r.Context(), performer, code); err == nil {
+				data.Message = "Двухфакторная аутентификация отключена"
+			}
+		default:
+			a.renderErrorPage(w, http.StatusBadRequest, msg.H7004, r)
+			return
+		}
+
+		if err != nil {
+			data.ErrorMessage = err.Error()
+		}
+	default:
+		http_err.SendErrorHTTP(w, http.StatusMethodNotAllowed, "", a.logg, r)
+		return
+	}
+
+	a.renderTwoFactorPage(w, r, performer, data)
+}
+
+func (a *AuthHandlerHTML) renderTwoFactorLoginPage(w http.ResponseWriter, r *http.Request, errorMsg string) {
+	a.setSecureHTMLHeaders(w)
+
+	data := struct {
+		ErrorMessage string
+	}{
+		ErrorMessage: errorMsg,
+	}
+
+	if errorMsg != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	a.renderPage(w, tmplTwoFactorLogin, data, r)
+}
+
+func (a *AuthHandlerHTML) renderTwoFactorPage(w http.ResponseWriter, r *http.Request, performer *model.Performer, data twoFactorPageData) {
+	status, err := a.twoFactorService.Status(r.Context(), performer)
+	if err != nil {
+		a.renderErrorPage(w, http.StatusInternalServerError, msg.H7001, r)
+		return
+	}
+
+	data.Status = status
+	data.MustEnroll = a.authMiddleware.TwoFactorEnroll(r)
+
+	a.setSecureHTMLHeaders(w)
+
+	if data.ErrorMessage != "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	a.renderPage(w, tmplTwoFactorHTML, data, r)
+}
+
+// clearTwoFactorEnroll - обязательная 2FA подключена, снять ограничение сессии.
+func (a *AuthHandlerHTML) clearTwoFactorEnroll(w http.ResponseWriter, r *http.Request) error {
+	session, err := config.Store.Get(r, config.GetSessionName())
+	if err != nil {
+		return err
+	}
+
+	delete(session.Values, config.SessionTwoFactorEnrollKey)
+
+	return session.Save(r, w)
+}
+
+// sessionPerformer - табельный номер и роли сотрудника из сессии.
+func (a *AuthHandlerHTML) sessionPerformer(r *http.Request) (*model.Performer, bool) {
+	performerId, ok1 := a.authMiddleware.GetPerformerId(r)
+	roleAForms, ok2 := a.authMiddleware.GetRoleId(r, model.AppAForms)
+	roleFGW, ok3 := a.authMiddleware.GetRoleId(r, model.AppFGW)
+
+	if !ok1 || !ok2 || !ok3 {
+		return nil, false
+	}
+
+	return &model.Performer{Id: performerId, IdRoleAForms: roleAForms, IdRoleAFGW: roleFGW}, true
+}
+
+// pendingTwoFactor - табельный номер сотрудника, ожидающего ввода кода 2FA, false - ожидания нет или оно истекло.
+func pendingTwoFactor(session *sessions.Session) (int, bool) {
+	if session == nil {
+		return 0, false
+	}
+
+	performerId, ok1 := session.Values[config.SessionTwoFactorPendingKey].(int)
+	pendingAt, ok2 := session.Values[config.SessionTwoFactorPendingAtKey].(int64)
+
+	if !ok1 || !ok2 || time.Since(time.Unix(pendingAt, 0)) > twoFactorPendingTTL {
+		return 0, false
+	}
+
+	return performerId, true
+}
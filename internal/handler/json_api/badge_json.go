@@ -0,0 +1,63 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common/msg"
+	"net/http"
+	"strconv"
+)
+
+// authBadge вход по бейджу с зарегистрированного устройства: ид и ключ устройства из BADGE_DEVICES передаются в
+// заголовках X-Device-Id и X-Device-Key. Неверное устройство и неизвестный код бейджа учитываются в ограничении
+// попыток входа по адресу клиента. false - ответ с ошибкой уже отправлен.
+func authBadge(
+	w http.ResponseWriter,
+	r *http.Request,
+	badgeCfg *config.BadgeLoginCfg,
+	loginThrottle service.LoginThrottleUseCase,
+	performerService service.PerformerUseCase,
+	twoFactor service.TwoFactorUseCase,
+	barcode string) (*model.Performer, *config.BadgeDevice, bool) {
+
+	addr := handler.ClientAddr(r)
+
+	throttle := loginThrottle.CheckBadgeLogin(addr)
+	if !throttle.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttle.RetryAfter.Seconds()+0.5)))
+		json_err.SendErrorResponse(w, http.StatusTooManyRequests, msg.H7011, throttle.Message, r)
+
+		return nil, nil, false
+	}
+
+	device, ok := badgeCfg.AuthDevice(r.Header.Get(config.DeviceIdHeader), r.Header.Get(config.DeviceKeyHeader))
+	if !ok {
+		loginThrottle.BadgeLoginFailed(addr)
+		json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, r.Header.Get(config.DeviceIdHeader), r)
+
+		return nil, nil, false
+	}
+
+	result, err := performerService.AuthPerformerByBC(r.Context(), barcode)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, msg.E3222, r)
+
+		return nil, nil, false
+	}
+
+	if !result.Success {
+		loginThrottle.BadgeLoginFailed(addr)
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3222, r)
+
+		return nil, nil, false
+	}
+
+	if !checkBadgeTwoFactor(w, r, twoFactor, &result.Performer) {
+		return nil, nil, false
+	}
+
+	return &result.Performer, device, true
+}
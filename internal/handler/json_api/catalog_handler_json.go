@@ -0,0 +1,65 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"net/http"
+)
+
+type CatalogHandlerJSON struct {
+	catalogService service.CatalogUseCase
+	logg           *common.Logger
+	authMiddleware *handler.AuthMiddleware
+}
+
+func NewCatalogHandlerJSON(catalogService service.CatalogUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *CatalogHandlerJSON {
+	return &CatalogHandlerJSON{catalogService: catalogService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (c *CatalogHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/storage-areas", c.authMiddleware.RequireAPI(model.ScopeCatalogsRead, c.AllStorageAreasJSON))
+	mux.HandleFunc("/api/fgw/storage-areas/occupancy", c.authMiddleware.RequireAPI(model.ScopeCatalogsRead, c.StorageAreaOccupancyJSON))
+}
+
+func (c *CatalogHandlerJSON) AllStorageAreasJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	areas, err := c.catalogService.GetStorageAreas(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, &model.StorageAreaList{StorageAreas: areas}, r)
+}
+
+// StorageAreaOccupancyJSON заполненность участков хранения: доля заполнения и уровень для окраски участка.
+func (c *CatalogHandlerJSON) StorageAreaOccupancyJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	occupancy, err := c.catalogService.GetStorageAreaOccupancy(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, &model.StorageAreaOccupancyList{StorageAreas: occupancy}, r)
+}
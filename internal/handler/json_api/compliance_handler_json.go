@@ -0,0 +1,107 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"net/http"
+)
+
+type ComplianceHandlerJSON struct {
+	complianceService service.ComplianceUseCase
+	logg              *common.Logger
+	authMiddleware    *handler.AuthMiddleware
+}
+
+func NewComplianceHandlerJSON(complianceService service.ComplianceUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *ComplianceHandlerJSON {
+	return &ComplianceHandlerJSON{complianceService: complianceService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (c *ComplianceHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/declarations", c.authMiddleware.RequireAPI(model.ScopeDeclarationsRead, c.DeclarationsJSON))
+	mux.HandleFunc("/api/fgw/declarations/expiring", c.authMiddleware.RequireAPI(model.ScopeDeclarationsRead, c.ExpiringDeclarationsJSON))
+	mux.HandleFunc("/api/fgw/products/label-check", c.authMiddleware.RequireAPI(model.ScopeDeclarationsRead, c.LabelPrintCheckJSON))
+}
+
+// DeclarationsJSON декларации продукции: ?productId=N.
+func (c *ComplianceHandlerJSON) DeclarationsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	productId := convert.ConvStrToInt(r.URL.Query().Get("productId"))
+
+	declarations, err := c.complianceService.GetDeclarations(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if declarations == nil {
+		declarations = []*model.Declaration{}
+	}
+
+	WriteJSON(w, &model.DeclarationList{Declarations: declarations}, r)
+}
+
+// ExpiringDeclarationsJSON отчет по декларациям, истекающим в течение N дней: ?days=N, по умолчанию 30.
+func (c *ComplianceHandlerJSON) ExpiringDeclarationsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	days := convert.ConvStrToInt(r.URL.Query().Get("days"))
+
+	declarations, err := c.complianceService.GetExpiringDeclarations(r.Context(), days)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if declarations == nil {
+		declarations = []*model.Declaration{}
+	}
+
+	WriteJSON(w, &model.DeclarationList{Declarations: declarations}, r)
+}
+
+// LabelPrintCheckJSON можно ли печатать этикетку продукции: ?productId=N, 409 - печать запрещена. Та же проверка
+// выполняется при печати п\п на станции (/api/fgw/pack-stations/print).
+func (c *ComplianceHandlerJSON) LabelPrintCheckJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	productId := convert.ConvStrToInt(r.URL.Query().Get("productId"))
+
+	check, err := c.complianceService.CheckLabelPrint(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, err.Error(), r)
+
+		return
+	}
+
+	if !check.Allowed {
+		w.WriteHeader(http.StatusConflict)
+	}
+
+	WriteJSON(w, check, r)
+}
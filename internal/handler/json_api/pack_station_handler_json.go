@@ -0,0 +1,188 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"net/http"
+)
+
+type PackStationHandlerJSON struct {
+	packStationService service.PackStationUseCase
+	logg               *common.Logger
+	authMiddleware     *handler.AuthMiddleware
+}
+
+func NewPackStationHandlerJSON(packStationService service.PackStationUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *PackStationHandlerJSON {
+	return &PackStationHandlerJSON{packStationService: packStationService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (p *PackStationHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/pack-stations", p.authMiddleware.RequireAPI(model.ScopePackStationsRead, p.AllPackStationsJSON))
+	mux.HandleFunc("/api/fgw/pack-stations/session/open", p.authMiddleware.RequireAPI(model.ScopePackStationsWrite, p.OpenSessionJSON))
+	mux.HandleFunc("/api/fgw/pack-stations/session/close", p.authMiddleware.RequireAPI(model.ScopePackStationsWrite, p.CloseSessionJSON))
+	mux.HandleFunc("/api/fgw/pack-stations/stamp", p.authMiddleware.RequireAPI(model.ScopePackStationsWrite, p.PrintStampJSON))
+	mux.HandleFunc("/api/fgw/pack-stations/print", p.authMiddleware.RequireAPI(model.ScopePackStationsWrite, p.PrintPalletJSON))
+}
+
+func (p *PackStationHandlerJSON) AllPackStationsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	stations, err := p.packStationService.GetAllPackStations(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if stations == nil {
+		stations = []*model.PackStation{}
+	}
+
+	WriteJSON(w, &model.PackStationList{PackStations: stations}, r)
+}
+
+// OpenSessionJSON открыть сеанс на станции сканированием бейджа.
+func (p *PackStationHandlerJSON) OpenSessionJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var badge model.PackStationBadge
+	if err := json.NewDecoder(r.Body).Decode(&badge); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if !p.checkStation(w, r, badge.StationId) {
+		return
+	}
+
+	session, err := p.packStationService.OpenSession(r.Context(), &badge)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, session, r)
+}
+
+// CloseSessionJSON закрыть сеанс станции: ?stationId=N.
+func (p *PackStationHandlerJSON) CloseSessionJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	stationId := convert.ConvStrToInt(r.URL.Query().Get("stationId"))
+	if !p.checkStation(w, r, stationId) {
+		return
+	}
+
+	if err := p.packStationService.CloseSession(r.Context(), stationId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, model.PackStationUpdate{Success: true, Message: "Сеанс станции закрыт"}, r)
+}
+
+// PrintStampJSON отметка для печати п\п на станции: ?stationId=N. 409, если сеанс не открыт.
+func (p *PackStationHandlerJSON) PrintStampJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	stationId := convert.ConvStrToInt(r.URL.Query().Get("stationId"))
+	if !p.checkStation(w, r, stationId) {
+		return
+	}
+
+	stamp, err := p.packStationService.GetPrintStamp(r.Context(), stationId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, stamp, r)
+}
+
+// PrintPalletJSON зарегистрировать печать п\п на станции, п\п штампуется отметкой открытого сеанса. 409, если сеанс
+// не открыт.
+func (p *PackStationHandlerJSON) PrintPalletJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var print model.PalletPrint
+	if err := json.NewDecoder(r.Body).Decode(&print); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if !p.checkStation(w, r, print.StationId) {
+		return
+	}
+
+	performerId, _ := p.authMiddleware.GetPerformerId(r)
+	pallet, err := p.packStationService.PrintPallet(r.Context(), &print, performerId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusConflict, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	WriteJSON(w, pallet, r)
+}
+
+// checkStation станция должна существовать, иначе ответ 404.
+func (p *PackStationHandlerJSON) checkStation(w http.ResponseWriter, r *http.Request, stationId int) bool {
+	exists, err := p.packStationService.ExistPackStation(r.Context(), stationId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return false
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,86 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"fmt"
+	"net/http"
+)
+
+type PalletHandlerJSON struct {
+	palletService  service.PalletUseCase
+	logg           *common.Logger
+	authMiddleware *handler.AuthMiddleware
+}
+
+func NewPalletHandlerJSON(palletService service.PalletUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *PalletHandlerJSON {
+	return &PalletHandlerJSON{palletService: palletService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (p *PalletHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/pallets/ship", p.authMiddleware.RequireAPI(model.ScopePalletsWrite, p.ShipPalletJSON))
+	mux.HandleFunc("/api/fgw/pallets/place", p.authMiddleware.RequireAPI(model.ScopePalletsWrite, p.PlacePalletJSON))
+}
+
+// ShipPalletJSON отгрузить п\п со склада: ?id=N. 404, если п\п не найден или уже отгружен.
+func (p *PalletHandlerJSON) ShipPalletJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	id := convert.ConvStrToInt(r.URL.Query().Get("id"))
+	performerId, _ := p.authMiddleware.GetPerformerId(r)
+
+	if err := p.palletService.ShipPallet(r.Context(), id, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, model.PalletUpdate{Success: true, Message: "П\\п отгружен"}, r)
+}
+
+// PlacePalletJSON разместить п\п на участке хранения: ?id=N&storageAreaId=N. 404, если п\п не найден или уже отгружен,
+// 422, если участка нет, площадка закрыта или заполнена.
+func (p *PalletHandlerJSON) PlacePalletJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	id := convert.ConvStrToInt(r.URL.Query().Get("id"))
+	storageAreaId := convert.ConvStrToInt(r.URL.Query().Get("storageAreaId"))
+
+	result, err := p.palletService.PlacePallet(r.Context(), id, storageAreaId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if result == nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, fmt.Sprintf("п\\п %d не найден или уже отгружен", id), r)
+
+		return
+	}
+
+	if !result.Success {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, result.Message, r)
+
+		return
+	}
+
+	WriteJSON(w, result, r)
+}
@@ -0,0 +1,84 @@
+package json_api
+
+import "FGW_WEB/internal/model"
+
+// Ответы с данными сотрудника. Пароль (хеш) и код доступа (штрих-код бейджа) в ответы не попадают, вместо них -
+// признаки наличия.
+
+// PerformerListItem сотрудник в списке.
+type PerformerListItem struct {
+	Id           int    `json:"id"`           // Id - табельный номер.
+	FIO          string `json:"fio"`          // FIO - ФИО сотрудника.
+	HasBadge     bool   `json:"hasBadge"`     // HasBadge - задан код доступа.
+	HasPassword  bool   `json:"hasPassword"`  // HasPassword - задан пароль.
+	Archive      bool   `json:"archive"`      // Archive - флаг архивного сотрудника.
+	IdRoleAForms int    `json:"idRoleAForms"` // IdRoleAForms - id роли.
+	IdRoleAFGW   int    `json:"idRoleAFGW"`   // IdRoleAFGW - id роли.
+}
+
+// PerformerDetail карточка сотрудника.
+type PerformerDetail struct {
+	PerformerListItem
+	AuditRec model.Audit `json:"auditRec"` // AuditRec - аудит для отслеживания изменений данных.
+}
+
+// PerformerSelf сотрудник текущей сессии.
+type PerformerSelf struct {
+	Id           int    `json:"id"`
+	FIO          string `json:"fio"`
+	IdRoleAForms int    `json:"idRoleAForms"`
+	IdRoleAFGW   int    `json:"idRoleAFGW"`
+}
+
+type PerformerListResponse struct {
+	Performers []*PerformerListItem `json:"performers"`
+}
+
+type AuthPerformerResponse struct {
+	Success   bool           `json:"success"`
+	Performer *PerformerSelf `json:"performer,omitempty"`
+	Message   string         `json:"message"`
+}
+
+func NewPerformerListItem(performer *model.Performer) *PerformerListItem {
+	return &PerformerListItem{
+		Id:           performer.Id,
+		FIO:          performer.FIO,
+		HasBadge:     performer.BC != "",
+		HasPassword:  performer.Pass != "",
+		Archive:      performer.Archive,
+		IdRoleAForms: performer.IdRoleAForms,
+		IdRoleAFGW:   performer.IdRoleAFGW,
+	}
+}
+
+func NewPerformerList(performers []*model.Performer) []*PerformerListItem {
+	items := make([]*PerformerListItem, 0, len(performers))
+	for _, performer := range performers {
+		items = append(items, NewPerformerListItem(performer))
+	}
+
+	return items
+}
+
+func NewPerformerDetail(performer *model.Performer) *PerformerDetail {
+	return &PerformerDetail{PerformerListItem: *NewPerformerListItem(performer), AuditRec: performer.AuditRec}
+}
+
+func NewPerformerSelf(performer *model.Performer) *PerformerSelf {
+	return &PerformerSelf{
+		Id:           performer.Id,
+		FIO:          performer.FIO,
+		IdRoleAForms: performer.IdRoleAForms,
+		IdRoleAFGW:   performer.IdRoleAFGW,
+	}
+}
+
+func NewAuthPerformerResponse(auth *model.AuthPerformer) *AuthPerformerResponse {
+	response := &AuthPerformerResponse{Success: auth.Success, Message: auth.Message}
+	if auth.Success {
+		response.Performer = NewPerformerSelf(&auth.Performer)
+	}
+
+	return response
+}
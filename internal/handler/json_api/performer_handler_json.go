@@ -1,6 +1,7 @@
 package json_api
 
 import (
+	"FGW_WEB/internal/handler"
 	"FGW_WEB/internal/handler/json_err"
 	"FGW_WEB/internal/model"
 	"FGW_WEB/internal/service"
@@ -9,21 +10,42 @@ import (
 	"FGW_WEB/pkg/convert"
 	"encoding/json"
 	"net/http"
+	"strconv"
 )
 
 type PerformerHandlerJSON struct {
 	performerService service.PerformerUseCase
+	loginThrottle    service.LoginThrottleUseCase
+	passwordService  service.PasswordUseCase
+	twoFactorService service.TwoFactorUseCase
 	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
 }
 
-func NewPerformerHandlerJSON(performerService service.PerformerUseCase, logg *common.Logger) *PerformerHandlerJSON {
-	return &PerformerHandlerJSON{performerService: performerService, logg: logg}
+func NewPerformerHandlerJSON(
+	performerService service.PerformerUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	passwordService service.PasswordUseCase,
+	twoFactorService service.TwoFactorUseCase,
+	logg *common.Logger,
+	authMiddleware *handler.AuthMiddleware) *PerformerHandlerJSON {
+
+	return &PerformerHandlerJSON{
+		performerService: performerService,
+		loginThrottle:    loginThrottle,
+		passwordService:  passwordService,
+		twoFactorService: twoFactorService,
+		logg:             logg,
+		authMiddleware:   authMiddleware,
+	}
 }
 
 func (p *PerformerHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
 	mux.HandleFunc("/api/fgw/login", p.AuthPerformerJSON)
-	mux.HandleFunc("/api/fgw/performers", p.AllPerformersJSON)
-	mux.HandleFunc("/api/fgw/performers/upd", p.UpdPerformersJSON)
+	mux.HandleFunc("/api/fgw/performers", p.authMiddleware.RequireAPI(model.ScopePerformersRead, p.AllPerformersJSON))
+	mux.HandleFunc("/api/fgw/performers/upd", p.authMiddleware.RequireAPI(model.ScopePerformersWrite, p.UpdPerformersJSON))
+	mux.HandleFunc("/api/fgw/performers/detail", p.authMiddleware.RequireAPI(model.ScopePerformersRead, p.PerformerDetailJSON))
+	mux.HandleFunc("/api/fgw/performers/me", p.authMiddleware.RequireAPI(model.ScopePerformersRead, p.PerformerSelfJSON))
 }
 
 func (p *PerformerHandlerJSON) AllPerformersJSON(w http.ResponseWriter, r *http.Request) {
@@ -44,16 +66,63 @@ func (p *PerformerHandlerJSON) AllPerformersJSON(w http.ResponseWriter, r *http.
 
 	if len(performers) == 0 {
 		w.WriteHeader(http.StatusNoContent)
-		if err = json.NewEncoder(w).Encode(&model.PerformerList{Performers: []*model.Performer{}}); err != nil {
+		if err = json.NewEncoder(w).Encode(&PerformerListResponse{Performers: []*PerformerListItem{}}); err != nil {
 			json_err.SendErrorResponse(w, http.StatusNoContent, msg.H7009, err.Error(), r)
 
 			return
 		}
 	}
 
-	data := model.PerformerList{Performers: performers}
+	WriteJSON(w, &PerformerListResponse{Performers: NewPerformerList(performers)}, r)
+}
+
+// PerformerDetailJSON карточка сотрудника: ?performerId=N.
+func (p *PerformerHandlerJSON) PerformerDetailJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId := convert.ConvStrToInt(r.URL.Query().Get("performerId"))
+
+	performer, err := p.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil || performer == nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return
+	}
 
-	WriteJSON(w, &data, r)
+	WriteJSON(w, NewPerformerDetail(performer), r)
+}
+
+// PerformerSelfJSON сотрудник текущей сессии или сотрудник, которому выдан API-токен.
+func (p *PerformerHandlerJSON) PerformerSelfJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	performer, err := p.performerService.FindByIdPerformer(r.Context(), performerId)
+	if err != nil || performer == nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return
+	}
+
+	WriteJSON(w, NewPerformerSelf(performer), r)
 }
 
 func (p *PerformerHandlerJSON) AuthPerformerJSON(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +137,7 @@ func (p *PerformerHandlerJSON) AuthPerformerJSON(w http.ResponseWriter, r *http.
 	var req struct {
 		Id       int    `json:"id"`
 		Password string `json:"password"`
+		Otp      string `json:"otp"` // Otp - код 2FA, если она подключена.
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -76,14 +146,51 @@ func (p *PerformerHandlerJSON) AuthPerformerJSON(w http.ResponseWriter, r *http.
 		return
 	}
 
+	addr := handler.ClientAddr(r)
+
+	throttle := p.loginThrottle.CheckLogin(req.Id, addr)
+	if !throttle.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttle.RetryAfter.Seconds()+0.5)))
+		json_err.SendErrorResponse(w, http.StatusTooManyRequests, msg.H7011, throttle.Message, r)
+
+		return
+	}
+
+	// Ошибка проверки пароля учитывается как неудачная попытка, текст ошибки клиенту не передается.
 	result, err := p.performerService.AuthPerformer(r.Context(), req.Id, req.Password)
 	if err != nil {
-		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, err.Error(), r)
+		p.loginThrottle.LoginFailed(req.Id, addr)
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3210, r)
 
 		return
 	}
 
-	WriteJSON(w, result, r)
+	if !result.Success {
+		p.loginThrottle.LoginFailed(req.Id, addr)
+	}
+
+	if result.Success {
+		if !checkTwoFactor(w, r, p.twoFactorService, p.loginThrottle, &result.Performer, req.Otp, addr) {
+			return
+		}
+
+		p.loginThrottle.LoginSucceeded(req.Id)
+
+		mustChange, err := p.passwordService.PassMustChange(r.Context(), req.Id)
+		if err != nil {
+			json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+			return
+		}
+
+		if mustChange {
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, msg.E3231, r)
+
+			return
+		}
+	}
+
+	WriteJSON(w, NewAuthPerformerResponse(result), r)
 }
 
 func (p *PerformerHandlerJSON) UpdPerformersJSON(w http.ResponseWriter, r *http.Request) {
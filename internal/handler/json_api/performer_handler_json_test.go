@@ -0,0 +1,417 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPassHash  = "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z3ZoGBf1vpgGdN6ZbfEXXVQy"
+	testBadge     = "2000000010011"
+	testDeviceKey = "0123456789abcdef"
+
+	testPassUnverified = "ldap-down" // testPassUnverified - пароль, который не удалось проверить: каталог недоступен.
+)
+
+// testDeviceHeaders заголовки зарегистрированного ТСД для входа по бейджу.
+var testDeviceHeaders = map[string]string{config.DeviceIdHeader: "tsd-01", config.DeviceKeyHeader: testDeviceKey}
+
+// fakePerformerService сервис сотрудников для проверки ответов, неиспользуемые методы не реализованы.
+type fakePerformerService struct {
+	service.PerformerUseCase
+	performer *model.Performer
+}
+
+func (f *fakePerformerService) GetAllPerformers(_ context.Context) ([]*model.Performer, error) {
+	return []*model.Performer{f.performer}, nil
+}
+
+func (f *fakePerformerService) FindByIdPerformer(_ context.Context, id int) (*model.Performer, error) {
+	if id != f.performer.Id {
+		return nil, sql.ErrNoRows
+	}
+
+	return f.performer, nil
+}
+
+func (f *fakePerformerService) AuthPerformer(_ context.Context, id int, password string) (*model.AuthPerformer, error) {
+	if password == testPassUnverified {
+		return &model.AuthPerformer{Success: false, Message: msg.E3210}, errors.New("ldap: dial tcp 10.0.0.10:636: i/o timeout")
+	}
+
+	if id != f.performer.Id || password != "1001" {
+		return &model.AuthPerformer{Success: false, Message: "Неверный пароль"}, nil
+	}
+
+	return &model.AuthPerformer{Success: true, Performer: *f.performer, Message: "Успешный вход"}, nil
+}
+
+func (f *fakePerformerService) AuthPerformerByBC(_ context.Context, bc string) (*model.AuthPerformer, error) {
+	if bc != f.performer.BC {
+		return &model.AuthPerformer{Success: false, Message: msg.E3222}, nil
+	}
+
+	return &model.AuthPerformer{Success: true, Performer: *f.performer, Message: "Успешный вход"}, nil
+}
+
+// fakePasswordService сервис паролей: временный пароль у сотрудников из mustChange, новый пароль принимается любой,
+// кроме текущего.
+type fakePasswordService struct {
+	service.PasswordUseCase
+	mustChange map[int]bool
+}
+
+func (f *fakePasswordService) ChangePassword(_ context.Context, id int, current, password string) error {
+	if current == password {
+		return errors.New("пароль совпадает с текущим")
+	}
+
+	delete(f.mustChange, id)
+
+	return nil
+}
+
+func (f *fakePasswordService) PassMustChange(_ context.Context, id int) (bool, error) {
+	return f.mustChange[id], nil
+}
+
+// fakePermissionRepo репозиторий прав: роль 5 заводит сменные задания, у остальных ролей прав нет.
+type fakePermissionRepo struct {
+	repository.PermissionRepository
+}
+
+func (f *fakePermissionRepo) CodesByRole(_ context.Context, roleId int) ([]string, error) {
+	if roleId == 5 {
+		return []string{model.PermShiftTasksEdit}, nil
+	}
+
+	return nil, nil
+}
+
+func (f *fakePermissionRepo) Version(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+// fakeApiTokenRepo репозиторий API-токенов в памяти.
+type fakeApiTokenRepo struct {
+	tokens []*model.ApiToken
+}
+
+func (f *fakeApiTokenRepo) Add(_ context.Context, token *model.ApiToken) (int, error) {
+	f.tokens = append(f.tokens, token)
+
+	return len(f.tokens), nil
+}
+
+func (f *fakeApiTokenRepo) FindByHash(_ context.Context, tokenHash string) (*model.ApiToken, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash == tokenHash && token.RevokedAt == nil {
+			return token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeApiTokenRepo) All(_ context.Context) ([]*model.ApiToken, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeApiTokenRepo) Revoke(_ context.Context, id int) error {
+	now := time.Now()
+	f.tokens[id-1].RevokedAt = &now
+
+	return nil
+}
+
+func (f *fakeApiTokenRepo) Touch(_ context.Context, _ int, _ time.Time) error {
+	return nil
+}
+
+// fakePerformerRepo репозиторий сотрудников для выдачи токенов, неиспользуемые методы не реализованы.
+type fakePerformerRepo struct {
+	repository.PerformerRepository
+}
+
+func (f *fakePerformerRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return id == 1001, nil
+}
+
+func (f *fakePerformerRepo) FindById(_ context.Context, id int) (*model.Performer, error) {
+	if id != 1001 {
+		return nil, sql.ErrNoRows
+	}
+
+	return newTestPerformerService().performer, nil
+}
+
+// newTestBadgeCfg вход по бейджу с ТСД tsd-01.
+func newTestBadgeCfg() *config.BadgeLoginCfg {
+	return &config.BadgeLoginCfg{
+		DeviceTypes: map[string]bool{"tsd": true},
+		Devices:     map[string]*config.BadgeDevice{"tsd-01": {Id: "tsd-01", Type: "tsd", Key: []byte(testDeviceKey)}},
+		MaxAge:      900,
+	}
+}
+
+func newTestLoginThrottle() service.LoginThrottleUseCase {
+	return service.NewLoginThrottleService(&config.LoginThrottleCfg{
+		MaxFailures: 3, MaxFailuresAddr: 10, BackoffBase: time.Millisecond, BackoffMax: time.Millisecond, Lockout: time.Minute,
+	}, &common.Logger{})
+}
+
+// setTestDevice запрос с зарегистрированного ТСД.
+func setTestDevice(req *http.Request) {
+	for name, value := range testDeviceHeaders {
+		req.Header.Set(name, value)
+	}
+}
+
+func newTestPerformerService() *fakePerformerService {
+	return &fakePerformerService{performer: &model.Performer{
+		Id: 1001, FIO: "Иванов И.И.", BC: testBadge, Pass: testPassHash, IdRoleAForms: 4, IdRoleAFGW: 5,
+	}}
+}
+
+// testMuxOptions сервисы маршрутизатора сотрудников, незаданные сервисы подменяются заглушками по умолчанию.
+type testMuxOptions struct {
+	passwords service.PasswordUseCase  // passwords - по умолчанию временных паролей нет.
+	twoFactor service.TwoFactorUseCase // twoFactor - по умолчанию 2FA не подключена.
+}
+
+// newTestPerformerMux маршрутизатор обработчиков сотрудников, входа и токенов под защитой CSRF.
+func newTestPerformerMux(t *testing.T, opts testMuxOptions) http.Handler {
+	t.Helper()
+
+	if opts.passwords == nil {
+		opts.passwords = &fakePasswordService{mustChange: map[int]bool{}}
+	}
+	if opts.twoFactor == nil {
+		opts.twoFactor = &fakeTwoFactorService{}
+	}
+
+	// Хранилище сессий подменяется на время теста, сессии и токены обновления регистрируются в одном реестре в памяти.
+	store := sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef"))
+	prevStore := config.Store
+	config.Store = store
+	t.Cleanup(func() { config.Store = prevStore })
+
+	sessionService := service.NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+	permissionService := service.NewPermissionService(&fakePermissionRepo{}, nil, &common.Logger{})
+	apiTokens := service.NewApiTokenService(&fakeApiTokenRepo{}, &fakePerformerRepo{}, &common.Logger{})
+	accessTokens := service.NewAccessTokenService(&config.JWTCfg{
+		Keys:       map[string][]byte{"test": []byte("0123456789abcdef0123456789abcdef")},
+		ActiveKid:  "test",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}, &fakePerformerRepo{}, sessionService, &common.Logger{})
+	authMiddleware := handler.NewAuthMiddleware(store, sessionService, permissionService, apiTokens, accessTokens, &common.Logger{})
+
+	performerService := newTestPerformerService()
+	badgeCfg := newTestBadgeCfg()
+
+	mux := http.NewServeMux()
+	loginThrottle := newTestLoginThrottle()
+
+	NewPerformerHandlerJSON(performerService, loginThrottle, opts.passwords, opts.twoFactor, &common.Logger{}, authMiddleware).ServeHTTPJSONRouter(mux)
+	NewAuthHandlerJSON(performerService, loginThrottle, opts.twoFactor, badgeCfg, authMiddleware, &common.Logger{}).ServeHTTPJSONRouter(mux)
+	NewTokenHandlerJSON(performerService, accessTokens, loginThrottle, opts.passwords, opts.twoFactor, badgeCfg, &common.Logger{}).ServeHTTPJSONRouter(mux)
+
+	return authMiddleware.ProtectCSRF(mux)
+}
+
+// badgeCookies cookie сессии сотрудника после входа по бейджу.
+func badgeCookies(t *testing.T, mux http.Handler) []*http.Cookie {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/badge", strings.NewReader(`{"barcode":"`+testBadge+`"}`))
+	setTestDevice(req)
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	cookies := rec.Result().Cookies()
+	require.NotEmpty(t, cookies)
+
+	return cookies
+}
+
+func TestPerformerResponses_NoSecrets(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+	cookies := badgeCookies(t, mux)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		header map[string]string
+		auth   bool
+	}{
+		{name: "список", method: http.MethodGet, url: "/api/fgw/performers", auth: true},
+		{name: "карточка", method: http.MethodGet, url: "/api/fgw/performers/detail?performerId=1001", auth: true},
+		{name: "текущий сотрудник", method: http.MethodGet, url: "/api/fgw/performers/me", auth: true},
+		{name: "вход по паролю", method: http.MethodPost, url: "/api/fgw/login", body: `{"id":1001,"password":"1001"}`},
+		{
+			name: "вход по бейджу", method: http.MethodPost, url: "/api/auth/badge",
+			body: `{"barcode":"` + testBadge + `"}`, header: testDeviceHeaders,
+		},
+		{
+			name: "токен по паролю", method: http.MethodPost, url: "/api/auth/token",
+			body: `{"grant_type":"password","id":1001,"password":"1001"}`, header: testDeviceHeaders,
+		},
+		{
+			name: "токен по бейджу", method: http.MethodPost, url: "/api/auth/token",
+			body: `{"grant_type":"badge","barcode":"` + testBadge + `"}`, header: testDeviceHeaders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			if tt.auth {
+				for _, cookie := range cookies {
+					req.AddCookie(cookie)
+				}
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			body := rec.Body.String()
+			assert.Contains(t, body, "1001")
+			assert.NotContains(t, body, testPassHash)
+			assert.NotContains(t, body, testBadge)
+			assert.NotContains(t, body, `"password"`)
+			assert.NotContains(t, body, `"bc"`)
+		})
+	}
+}
+
+func TestBadgeLogin_DeviceAndThrottle(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+
+	badge := func(barcode string, header map[string]string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/badge", strings.NewReader(`{"barcode":"`+barcode+`"}`))
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		mux.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, badge(testBadge, map[string]string{config.DeviceTypeHeader: "tsd"}), "тип устройства из заголовка не учитывается")
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, http.StatusForbidden, badge(testBadge, map[string]string{config.DeviceIdHeader: "tsd-01", config.DeviceKeyHeader: "wrong"}), "неверный ключ устройства")
+
+	// Подбор кодов бейджей: после MaxFailuresAddr неудачных попыток адрес блокируется.
+	for i := 0; i < 8; i++ {
+		time.Sleep(2 * time.Millisecond)
+		require.Equal(t, http.StatusUnauthorized, badge("200000001000"+strconv.Itoa(i), testDeviceHeaders))
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, http.StatusTooManyRequests, badge(testBadge, testDeviceHeaders), "адрес заблокирован")
+}
+
+func TestPerformerSelfJSON_Unauthorized(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/fgw/performers/me", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthPerformerJSON_Lockout(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+
+	login := func(password string) *httptest.ResponseRecorder {
+		time.Sleep(2 * time.Millisecond)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/fgw/login", strings.NewReader(`{"id":1001,"password":"`+password+`"}`))
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, login("wrong").Code, "неверный пароль до блокировки")
+	}
+
+	rec := login("1001")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "верный пароль во время блокировки")
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestAuthPerformerJSON_ProviderError(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+
+	login := func(password string) *httptest.ResponseRecorder {
+		time.Sleep(2 * time.Millisecond)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/fgw/login", strings.NewReader(`{"id":1001,"password":"`+password+`"}`))
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	for i := 0; i < 3; i++ {
+		rec := login(testPassUnverified)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "ldap", "текст ошибки проверки не передается клиенту")
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, login("1001").Code, "непроверенные попытки учитываются")
+}
+
+func TestPassMustChange_JSON(t *testing.T) {
+	passwords := &fakePasswordService{mustChange: map[int]bool{1001: true}}
+	mux := newTestPerformerMux(t, testMuxOptions{passwords: passwords})
+
+	call := func(url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, url, strings.NewReader(body)))
+
+		return rec
+	}
+
+	assert.Equal(t, http.StatusForbidden, call("/api/fgw/login", `{"id":1001,"password":"1001"}`).Code, "временный пароль")
+	assert.Equal(t, http.StatusForbidden, call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001"}`).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/password", `{"id":1001,"password":"wrong","new_password":"Winter#2026"}`).Code)
+	time.Sleep(2 * time.Millisecond) // пауза после неверного пароля
+	assert.Equal(t, http.StatusUnprocessableEntity, call("/api/auth/password", `{"id":1001,"password":"1001","new_password":"1001"}`).Code)
+
+	rec := call("/api/auth/password", `{"id":1001,"password":"1001","new_password":"Winter#2026"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, passwords.mustChange[1001])
+
+	assert.Equal(t, http.StatusOK, call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001"}`).Code, "пароль сменен")
+}
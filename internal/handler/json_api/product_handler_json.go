@@ -0,0 +1,237 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"encoding/json"
+	"net/http"
+)
+
+type ProductHandlerJSON struct {
+	productService service.ProductUseCase
+	logg           *common.Logger
+	authMiddleware *handler.AuthMiddleware
+}
+
+func NewProductHandlerJSON(productService service.ProductUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *ProductHandlerJSON {
+	return &ProductHandlerJSON{productService: productService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (p *ProductHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/products", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.AllProductsJSON))
+	mux.HandleFunc("/api/fgw/products/upd", p.authMiddleware.RequireAPI(model.ScopeProductsWrite, p.UpdProductJSON))
+	mux.HandleFunc("/api/fgw/products/line-check", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.ProductLineCheckJSON))
+	mux.HandleFunc("/api/fgw/products/history", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.ProductHistoryJSON))
+	mux.HandleFunc("/api/fgw/products/history/diff", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.ProductHistoryDiffJSON))
+	mux.HandleFunc("/api/fgw/products/catalogs", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.ProductCatalogsJSON))
+	mux.HandleFunc("/api/fgw/products/catalogs/unmatched", p.authMiddleware.RequireAPI(model.ScopeProductsRead, p.ProductCatalogUnmatchedJSON))
+}
+
+// AllProductsJSON действующая продукция с отбором по справочникам: ?colorId=N&designId=N, 0 или пусто - без отбора.
+// ?archive=1 - архивная продукция.
+func (p *ProductHandlerJSON) AllProductsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	filter := model.ProductFilter{
+		ColorId:  convert.ConvStrToInt(r.URL.Query().Get("colorId")),
+		DesignId: convert.ConvStrToInt(r.URL.Query().Get("designId")),
+	}
+
+	list, err := p.productService.GetProductsByArchive(r.Context(), filter)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	products := list.Active
+	if r.URL.Query().Get("archive") == "1" {
+		products = list.Archived
+	}
+
+	WriteJSON(w, &model.ProductList{Products: products}, r)
+}
+
+// UpdProductJSON частичное изменение продукции: ?productId=N, в теле только изменяемые поля, остальные поля
+// остаются как в БД. Автор изменения - сотрудник сессии или токена, а не auditRec из тела запроса.
+func (p *ProductHandlerJSON) UpdProductJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPatch {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	performerId, ok := p.authMiddleware.GetPerformerId(r)
+	if !ok {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+		return
+	}
+
+	productId := convert.ConvStrToInt(r.URL.Query().Get("productId"))
+
+	exists, err := p.productService.ExistProduct(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if !exists {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, "", r)
+
+		return
+	}
+
+	product, err := p.productService.FindProductById(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	// Поля из тела запроса накладываются на текущую запись, отсутствующие в теле поля не обнуляются.
+	if err = json.NewDecoder(r.Body).Decode(product); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if err = p.productService.UpdProduct(r.Context(), productId, product, performerId); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	response := model.ProductUpdate{
+		Success: true,
+		Message: "Продукция успешно обновлена",
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, response, r)
+}
+
+// ProductLineCheckJSON отчет о продукции, у которой машинная линия не принадлежит печи.
+func (p *ProductHandlerJSON) ProductLineCheckJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	issues, err := p.productService.CheckProductLines(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, &model.ProductLineIssueList{Issues: issues}, r)
+}
+
+// ProductHistoryJSON версии продукции с изменениями полей: ?productId=N.
+func (p *ProductHandlerJSON) ProductHistoryJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	productId := convert.ConvStrToInt(r.URL.Query().Get("productId"))
+
+	versions, err := p.productService.GetProductHistory(r.Context(), productId)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if versions == nil {
+		versions = []*model.ProductVersion{}
+	}
+
+	WriteJSON(w, &model.ProductVersionList{Versions: versions}, r)
+}
+
+// ProductHistoryDiffJSON изменения полей между версиями: ?productId=N&from=N&to=N.
+func (p *ProductHandlerJSON) ProductHistoryDiffJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	query := r.URL.Query()
+	productId := convert.ConvStrToInt(query.Get("productId"))
+	from := convert.ConvStrToInt(query.Get("from"))
+	to := convert.ConvStrToInt(query.Get("to"))
+
+	diff, err := p.productService.DiffProductVersions(r.Context(), productId, from, to)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusNotFound, msg.H7008, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, diff, r)
+}
+
+// ProductCatalogsJSON справочники цветов и конструкторских наименований для отбора продукции.
+func (p *ProductHandlerJSON) ProductCatalogsJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	catalogs, err := p.productService.GetProductCatalogs(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, catalogs, r)
+}
+
+// ProductCatalogUnmatchedJSON отчет о текстовых цветах и наименованиях продукции без записи в справочниках.
+func (p *ProductHandlerJSON) ProductCatalogUnmatchedJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	unmatched, err := p.productService.GetProductCatalogUnmatched(r.Context())
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	WriteJSON(w, &model.ProductCatalogUnmatchedList{Unmatched: unmatched}, r)
+}
@@ -1,6 +1,7 @@
 package json_api
 
 import (
+	"FGW_WEB/internal/handler"
 	"FGW_WEB/internal/handler/json_err"
 	"FGW_WEB/internal/model"
 	"FGW_WEB/internal/service"
@@ -12,18 +13,19 @@ import (
 )
 
 type RoleHandlerJSON struct {
-	roleService service.RoleUseCase
-	logg        *common.Logger
+	roleService    service.RoleUseCase
+	logg           *common.Logger
+	authMiddleware *handler.AuthMiddleware
 }
 
-func NewRoleHandlerJSON(roleService service.RoleUseCase, logger *common.Logger) *RoleHandlerJSON {
-	return &RoleHandlerJSON{roleService: roleService, logg: logger}
+func NewRoleHandlerJSON(roleService service.RoleUseCase, logger *common.Logger, authMiddleware *handler.AuthMiddleware) *RoleHandlerJSON {
+	return &RoleHandlerJSON{roleService: roleService, logg: logger, authMiddleware: authMiddleware}
 }
 
 func (r *RoleHandlerJSON) ServerHTTPJSONRouter(mux *http.ServeMux) {
-	mux.HandleFunc("/api/fgw/roles", r.AllRoleJSON)
-	mux.HandleFunc("/api/fgw/roles/add", r.AddRoleJSON)
-	mux.HandleFunc("/api/fgw/roles/upd", r.UpdRoleJSON)
+	mux.HandleFunc("/api/fgw/roles", r.authMiddleware.RequireAPI(model.ScopeRolesRead, r.AllRoleJSON))
+	mux.HandleFunc("/api/fgw/roles/add", r.authMiddleware.RequireAPI(model.ScopeRolesWrite, r.AddRoleJSON))
+	mux.HandleFunc("/api/fgw/roles/upd", r.authMiddleware.RequireAPI(model.ScopeRolesWrite, r.UpdRoleJSON))
 }
 
 func (r *RoleHandlerJSON) AllRoleJSON(w http.ResponseWriter, req *http.Request) {
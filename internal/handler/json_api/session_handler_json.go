@@ -2,24 +2,132 @@ package json_api
 
 import (
 	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
 	"FGW_WEB/internal/service"
 	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
 	"encoding/json"
 	"net/http"
 	"time"
+
+	"github.com/gorilla/sessions"
 )
 
 type AuthHandlerJSON struct {
 	performerService service.PerformerUseCase
+	loginThrottle    service.LoginThrottleUseCase
+	twoFactorService service.TwoFactorUseCase
+	badgeCfg         *config.BadgeLoginCfg
+	authMiddleware   *handler.AuthMiddleware
 	logg             *common.Logger
 }
 
-func NewAuthHandlerJSON(performerService service.PerformerUseCase, logg *common.Logger) *AuthHandlerJSON {
-	return &AuthHandlerJSON{performerService: performerService, logg: logg}
+func NewAuthHandlerJSON(
+	performerService service.PerformerUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	twoFactorService service.TwoFactorUseCase,
+	badgeCfg *config.BadgeLoginCfg,
+	authMiddleware *handler.AuthMiddleware,
+	logg *common.Logger) *AuthHandlerJSON {
+
+	return &AuthHandlerJSON{
+		performerService: performerService,
+		loginThrottle:    loginThrottle,
+		twoFactorService: twoFactorService,
+		badgeCfg:         badgeCfg,
+		authMiddleware:   authMiddleware,
+		logg:             logg,
+	}
 }
 
 func (a *AuthHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
 	mux.HandleFunc("/api/session-check", a.SessionCheckHandler)
+	mux.HandleFunc("/api/auth/badge", a.BadgeLoginHandler)
+}
+
+// BadgeLoginHandler вход по штрих-коду бейджа для ТСД и терминалов цеха. Устройство должно быть в BADGE_DEVICES
+// (см. authBadge), а его тип разрешен в BADGE_LOGIN_DEVICES, сессия живет BADGE_SESSION_MAX_AGE секунд.
+// Сотрудникам с 2FA вход по бейджу запрещен.
+func (a *AuthHandlerJSON) BadgeLoginHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var req struct {
+		Barcode string `json:"barcode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	performer, device, ok := authBadge(w, r, a.badgeCfg, a.loginThrottle, a.performerService, a.twoFactorService, req.Barcode)
+	if !ok {
+		return
+	}
+
+	if err := a.createBadgeSession(w, r, performer, device.Type); err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success":     true,
+		"message":     "Успешный вход",
+		"performerId": performer.Id,
+		"fio":         performer.FIO,
+		"roleId":      performer.IdRoleAForms,
+		"roleFGWId":   performer.IdRoleAFGW,
+		"maxAge":      a.badgeCfg.MaxAge,
+		"csrfToken":   a.authMiddleware.CSRFToken(r),
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, response, r)
+}
+
+// createBadgeSession сессия входа по бейджу с коротким временем жизни.
+func (a *AuthHandlerJSON) createBadgeSession(w http.ResponseWriter, r *http.Request, performer *model.Performer, deviceType string) error {
+	session, _ := config.Store.Get(r, config.GetSessionName())
+
+	now := time.Now().Unix()
+
+	session.Values[config.SessionAuthPerformer] = true
+	session.Values[config.SessionPerformerKey] = performer.Id
+	session.Values[config.SessionRoleKey] = performer.IdRoleAForms
+	session.Values[config.SessionRoleFGWKey] = performer.IdRoleAFGW
+	session.Values[config.SessionDeviceKey] = deviceType
+	session.Values["session_token"] = config.GenerateSessionToken()
+	session.Values[config.SessionCSRFKey] = config.GenerateSessionToken()
+	session.Values["created_at"] = now
+	session.Values["last_activity"] = now
+	session.Values["max_age"] = a.badgeCfg.MaxAge
+
+	session.Options = &sessions.Options{
+		Path:     "/",
+		MaxAge:   a.badgeCfg.MaxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+
+	if err := a.authMiddleware.CachePermissions(r, session); err != nil {
+		return err
+	}
+
+	if err := a.authMiddleware.RegisterSession(r, session); err != nil {
+		return err
+	}
+
+	return session.Save(r, w)
 }
 
 func (a *AuthHandlerJSON) SessionCheckHandler(w http.ResponseWriter, r *http.Request) {
@@ -49,8 +157,11 @@ func (a *AuthHandlerJSON) SessionCheckHandler(w http.ResponseWriter, r *http.Req
 	if createdAt, ok := session.Values["created_at"].(int64); ok {
 		createTime := time.Unix(createdAt, 0)
 
-		// 4 часа максимальное время (как в middleware).
+		// 4 часа максимальное время (как в middleware), у сессий входа по бейджу своё время жизни.
 		maxAge := 4 * time.Hour
+		if customMaxAge, ok := session.Values["max_age"].(int); ok {
+			maxAge = time.Duration(customMaxAge) * time.Second
+		}
 
 		if time.Since(createTime) > maxAge {
 			w.Header().Set("Session-Status", "expired")
@@ -64,6 +175,13 @@ func (a *AuthHandlerJSON) SessionCheckHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Проверяем, что сессия не отозвана.
+	if !a.authMiddleware.IsSessionActive(r, session) {
+		w.Header().Set("Session-Status", "revoked")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
 	// Обновляем время последней активности.
 	session.Values["last_activity"] = time.Now().Unix()
 	if err = session.Save(r, w); err != nil {
@@ -78,14 +196,18 @@ func (a *AuthHandlerJSON) SessionCheckHandler(w http.ResponseWriter, r *http.Req
 	if r.Method == http.MethodGet {
 		performerId, _ := session.Values[config.SessionPerformerKey].(int)
 		roleId, _ := session.Values[config.SessionRoleKey].(int)
+		roleFGWId, _ := session.Values[config.SessionRoleFGWKey].(int)
 		createdAt, _ := session.Values["created_at"].(int64)
+		csrfToken, _ := session.Values[config.SessionCSRFKey].(string)
 
 		response := map[string]interface{}{
 			"status":      "active",
 			"performerId": performerId,
 			"roleId":      roleId,
+			"roleFGWId":   roleFGWId,
 			"createdAt":   time.Unix(createdAt, 0).Format("02.01.2006 15:04:05"),
 			"sessionAge":  time.Since(time.Unix(createdAt, 0)).String(),
+			"csrfToken":   csrfToken,
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,52 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"net/http"
+)
+
+type ShiftTaskHandlerJSON struct {
+	shiftTaskService service.ShiftTaskUseCase
+	logg             *common.Logger
+	authMiddleware   *handler.AuthMiddleware
+}
+
+func NewShiftTaskHandlerJSON(shiftTaskService service.ShiftTaskUseCase, logg *common.Logger, authMiddleware *handler.AuthMiddleware) *ShiftTaskHandlerJSON {
+	return &ShiftTaskHandlerJSON{shiftTaskService: shiftTaskService, logg: logg, authMiddleware: authMiddleware}
+}
+
+func (s *ShiftTaskHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/fgw/shift-tasks", s.authMiddleware.RequireAPI(model.ScopeShiftTasksRead, s.AllShiftTasksJSON))
+}
+
+// AllShiftTasksJSON задания на дату и смену: ?date=ГГГГ-ММ-ДД&shift=N, по умолчанию текущий день и все смены.
+func (s *ShiftTaskHandlerJSON) AllShiftTasksJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	shiftNum := convert.ConvStrToInt(r.URL.Query().Get("shift"))
+
+	tasks, err := s.shiftTaskService.GetShiftTasks(r.Context(), r.URL.Query().Get("date"), shiftNum)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if tasks == nil {
+		tasks = []*model.ShiftTask{}
+	}
+
+	WriteJSON(w, &model.ShiftTaskList{ShiftTasks: tasks}, r)
+}
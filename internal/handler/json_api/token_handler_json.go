@@ -0,0 +1,249 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/handler"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type TokenHandlerJSON struct {
+	performerService service.PerformerUseCase
+	accessTokens     service.AccessTokenUseCase
+	loginThrottle    service.LoginThrottleUseCase
+	passwordService  service.PasswordUseCase
+	twoFactorService service.TwoFactorUseCase
+	badgeCfg         *config.BadgeLoginCfg
+	logg             *common.Logger
+}
+
+func NewTokenHandlerJSON(
+	performerService service.PerformerUseCase,
+	accessTokens service.AccessTokenUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	passwordService service.PasswordUseCase,
+	twoFactorService service.TwoFactorUseCase,
+	badgeCfg *config.BadgeLoginCfg,
+	logg *common.Logger) *TokenHandlerJSON {
+
+	return &TokenHandlerJSON{
+		performerService: performerService,
+		accessTokens:     accessTokens,
+		loginThrottle:    loginThrottle,
+		passwordService:  passwordService,
+		twoFactorService: twoFactorService,
+		badgeCfg:         badgeCfg,
+		logg:             logg,
+	}
+}
+
+func (t *TokenHandlerJSON) ServeHTTPJSONRouter(mux *http.ServeMux) {
+	mux.HandleFunc("/api/auth/token", t.TokenJSON)
+	mux.HandleFunc("/api/auth/token/revoke", t.RevokeTokenJSON)
+	mux.HandleFunc("/api/auth/password", t.ChangePasswordJSON)
+}
+
+// TokenJSON выдача JWT токена доступа и токена обновления для ТСД: grant_type password (id, password и otp -
+// код 2FA, если она подключена), badge (barcode, устройство из BADGE_DEVICES в заголовках X-Device-Id и X-Device-Key) или refresh_token
+// (refresh_token). Токен доступа передается в заголовке Authorization: Bearer.
+func (t *TokenHandlerJSON) TokenJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var req model.TokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	client := &model.TokenClient{
+		DeviceType: r.Header.Get(config.DeviceTypeHeader),
+		RemoteAddr: handler.ClientAddr(r),
+		UserAgent:  r.UserAgent(),
+	}
+
+	var performer *model.Performer
+
+	switch req.GrantType {
+	case model.GrantPassword:
+		throttle := t.loginThrottle.CheckLogin(req.Id, client.RemoteAddr)
+		if !throttle.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(throttle.RetryAfter.Seconds()+0.5)))
+			json_err.SendErrorResponse(w, http.StatusTooManyRequests, msg.H7011, throttle.Message, r)
+
+			return
+		}
+
+		result, err := t.performerService.AuthPerformer(r.Context(), req.Id, req.Password)
+		if err != nil || !result.Success {
+			t.loginThrottle.LoginFailed(req.Id, client.RemoteAddr)
+			json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3210, r)
+
+			return
+		}
+
+		if !checkTwoFactor(w, r, t.twoFactorService, t.loginThrottle, &result.Performer, req.Otp, client.RemoteAddr) {
+			return
+		}
+
+		t.loginThrottle.LoginSucceeded(req.Id)
+
+		// Временный пароль сначала меняется через /api/auth/password.
+		mustChange, err := t.passwordService.PassMustChange(r.Context(), req.Id)
+		if err != nil {
+			json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+			return
+		}
+
+		if mustChange {
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, msg.E3231, r)
+
+			return
+		}
+
+		performer = &result.Performer
+	case model.GrantBadge:
+		badgePerformer, device, ok := authBadge(w, r, t.badgeCfg, t.loginThrottle, t.performerService, t.twoFactorService, req.Barcode)
+		if !ok {
+			return
+		}
+
+		// Тип устройства берется из реестра устройств, а не из заголовка клиента.
+		client.DeviceType = device.Type
+		performer = badgePerformer
+	case model.GrantRefreshToken:
+		// Сотрудник и тип устройства берутся из токена обновления.
+	default:
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, "Неизвестный grant_type: "+req.GrantType, r)
+
+		return
+	}
+
+	var tokens *model.TokenResponse
+	var err error
+
+	if performer != nil {
+		tokens, err = t.accessTokens.IssueTokens(r.Context(), performer, client)
+	} else {
+		tokens, err = t.accessTokens.RefreshTokens(r.Context(), req.RefreshToken, client)
+	}
+
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return
+	}
+
+	if tokens == nil {
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "Токен обновления недействителен", r)
+
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, tokens, r)
+}
+
+// RevokeTokenJSON выход с устройства: отзыв токена обновления, токен доступа действует до своего окончания.
+func (t *TokenHandlerJSON) RevokeTokenJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var req model.TokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	if err := t.accessTokens.RevokeRefreshToken(r.Context(), req.RefreshToken); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "Токен обновления отозван",
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, response, r)
+}
+
+// ChangePasswordJSON смена пароля с ТСД по табельному номеру, текущему паролю и коду 2FA, если она подключена.
+// Текущий пароль проверяется, как при входе, с защитой от подбора; после смены временного пароля можно получить
+// токены по grant_type password.
+func (t *TokenHandlerJSON) ChangePasswordJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodPost {
+		json_err.SendErrorResponse(w, http.StatusMethodNotAllowed, msg.H7000, "", r)
+
+		return
+	}
+
+	var req model.PasswordChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json_err.SendErrorResponse(w, http.StatusBadRequest, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	addr := handler.ClientAddr(r)
+
+	throttle := t.loginThrottle.CheckLogin(req.Id, addr)
+	if !throttle.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttle.RetryAfter.Seconds()+0.5)))
+		json_err.SendErrorResponse(w, http.StatusTooManyRequests, msg.H7011, throttle.Message, r)
+
+		return
+	}
+
+	result, err := t.performerService.AuthPerformer(r.Context(), req.Id, req.Password)
+	if err != nil || !result.Success {
+		t.loginThrottle.LoginFailed(req.Id, addr)
+		json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3210, r)
+
+		return
+	}
+
+	if !checkTwoFactor(w, r, t.twoFactorService, t.loginThrottle, &result.Performer, req.Otp, addr) {
+		return
+	}
+
+	t.loginThrottle.LoginSucceeded(req.Id)
+
+	if err = t.passwordService.ChangePassword(r.Context(), req.Id, req.Password, req.NewPassword); err != nil {
+		json_err.SendErrorResponse(w, http.StatusUnprocessableEntity, msg.H7004, err.Error(), r)
+
+		return
+	}
+
+	response := map[string]interface{}{
+		"success": true,
+		"message": "Пароль изменен",
+	}
+
+	w.WriteHeader(http.StatusOK)
+	WriteJSON(w, response, r)
+}
@@ -0,0 +1,78 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenJSON(t *testing.T) {
+	mux := newTestPerformerMux(t, testMuxOptions{})
+
+	call := func(url, body, bearer string, header map[string]string) *httptest.ResponseRecorder {
+		method := http.MethodGet
+		if body != "" {
+			method = http.MethodPost
+		}
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	decode := func(rec *httptest.ResponseRecorder) *model.TokenResponse {
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var tokens model.TokenResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&tokens))
+		require.NotEmpty(t, tokens.AccessToken)
+		require.NotEmpty(t, tokens.RefreshToken)
+		assert.Equal(t, "Bearer", tokens.TokenType)
+		assert.Equal(t, 1001, tokens.PerformerId)
+		assert.Equal(t, 5, tokens.RoleFGWId)
+
+		return &tokens
+	}
+
+	tsd := testDeviceHeaders
+
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"wrong"}`, "", nil).Code)
+	time.Sleep(2 * time.Millisecond) // пауза после неверного пароля
+	assert.Equal(t, http.StatusForbidden, call("/api/auth/token", `{"grant_type":"badge","barcode":"`+testBadge+`"}`, "", nil).Code, "вход по бейджу с незарегистрированного устройства")
+	assert.Equal(t, http.StatusBadRequest, call("/api/auth/token", `{"grant_type":"client_credentials"}`, "", nil).Code)
+	time.Sleep(2 * time.Millisecond) // пауза после попытки с незарегистрированного устройства
+
+	decode(call("/api/auth/token", `{"grant_type":"badge","barcode":"`+testBadge+`"}`, "", tsd))
+	time.Sleep(2 * time.Millisecond) // пауза после неверного пароля
+	tokens := decode(call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001"}`, "", tsd))
+
+	rec := call("/api/fgw/performers/me", "", tokens.AccessToken, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "1001")
+
+	assert.Equal(t, http.StatusUnauthorized, call("/api/fgw/performers/me", "", tokens.AccessToken+"x", nil).Code, "подпись не сходится")
+	assert.Equal(t, http.StatusUnauthorized, call("/api/fgw/performers/me", "", tokens.RefreshToken, nil).Code, "токен обновления не дает доступа")
+
+	refreshed := decode(call("/api/auth/token", `{"grant_type":"refresh_token","refresh_token":"`+tokens.RefreshToken+`"}`, "", nil))
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/token", `{"grant_type":"refresh_token","refresh_token":"`+tokens.RefreshToken+`"}`, "", nil).Code, "токен обновления одноразовый")
+
+	require.Equal(t, http.StatusOK, call("/api/auth/token/revoke", `{"refresh_token":"`+refreshed.RefreshToken+`"}`, "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/token", `{"grant_type":"refresh_token","refresh_token":"`+refreshed.RefreshToken+`"}`, "", nil).Code, "отозванный токен обновления")
+}
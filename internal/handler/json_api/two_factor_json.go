@@ -0,0 +1,81 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common/msg"
+	"net/http"
+)
+
+// checkTwoFactor второй шаг входа с устройства после проверки пароля: код 2FA передается в поле otp. Неверный код
+// учитывается в ограничении попыток входа. Если 2FA обязательна, но не подключена, вход запрещен до подключения
+// в веб-интерфейсе. false - ответ с ошибкой уже отправлен.
+func checkTwoFactor(
+	w http.ResponseWriter,
+	r *http.Request,
+	twoFactor service.TwoFactorUseCase,
+	loginThrottle service.LoginThrottleUseCase,
+	performer *model.Performer,
+	otp, addr string) bool {
+
+	state, err := twoFactor.LoginState(r.Context(), performer)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return false
+	}
+
+	switch state {
+	case model.TwoFactorEnroll:
+		json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, msg.E3234, r)
+
+		return false
+	case model.TwoFactorVerify:
+		if otp == "" {
+			json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3233, r)
+
+			return false
+		}
+
+		verified, err := twoFactor.Verify(r.Context(), performer.Id, otp)
+		if err != nil {
+			json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+			return false
+		}
+
+		if !verified {
+			loginThrottle.LoginFailed(performer.Id, addr)
+			json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, msg.E3232, r)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkBadgeTwoFactor вход по бейджу без пароля и кода: сотрудникам с подключенной или обязательной 2FA он
+// запрещен. false - ответ с ошибкой уже отправлен.
+func checkBadgeTwoFactor(w http.ResponseWriter, r *http.Request, twoFactor service.TwoFactorUseCase, performer *model.Performer) bool {
+	state, err := twoFactor.LoginState(r.Context(), performer)
+	if err != nil {
+		json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+		return false
+	}
+
+	switch state {
+	case model.TwoFactorVerify:
+		json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, msg.E3233, r)
+
+		return false
+	case model.TwoFactorEnroll:
+		json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, msg.E3234, r)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,68 @@
+package json_api
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTwoFactorService сервис 2FA: состояние входа сотрудника из states, верный код - из codes.
+type fakeTwoFactorService struct {
+	service.TwoFactorUseCase
+	states map[int]int
+	codes  map[int]string
+}
+
+func (f *fakeTwoFactorService) LoginState(_ context.Context, performer *model.Performer) (int, error) {
+	return f.states[performer.Id], nil
+}
+
+func (f *fakeTwoFactorService) Verify(_ context.Context, performerId int, code string) (bool, error) {
+	return code != "" && code == f.codes[performerId], nil
+}
+
+func TestTwoFactor_JSON(t *testing.T) {
+	twoFactor := &fakeTwoFactorService{states: map[int]int{1001: model.TwoFactorVerify}, codes: map[int]string{1001: "123456"}}
+	mux := newTestPerformerMux(t, testMuxOptions{twoFactor: twoFactor})
+
+	call := func(url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		setTestDevice(req)
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := call("/api/fgw/login", `{"id":1001,"password":"1001"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "E3233", "нужен код")
+
+	rec = call("/api/fgw/login", `{"id":1001,"password":"1001","otp":"000000"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "E3232", "неверный код")
+
+	time.Sleep(2 * time.Millisecond) // пауза после неверного кода
+	assert.Equal(t, http.StatusOK, call("/api/fgw/login", `{"id":1001,"password":"1001","otp":"123456"}`).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001"}`).Code)
+	assert.Equal(t, http.StatusOK, call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001","otp":"123456"}`).Code)
+
+	assert.Equal(t, http.StatusForbidden, call("/api/auth/badge", `{"barcode":"`+testBadge+`"}`).Code, "вход по бейджу без кода")
+	assert.Equal(t, http.StatusForbidden, call("/api/auth/token", `{"grant_type":"badge","barcode":"`+testBadge+`"}`).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, call("/api/auth/password", `{"id":1001,"password":"1001","new_password":"Winter#2026"}`).Code)
+	assert.Equal(t, http.StatusOK, call("/api/auth/password", `{"id":1001,"password":"1001","new_password":"Winter#2026","otp":"123456"}`).Code)
+
+	twoFactor.states[1001] = model.TwoFactorEnroll
+	rec = call("/api/auth/token", `{"grant_type":"password","id":1001,"password":"1001","otp":"123456"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "2FA обязательна, но не подключена")
+	assert.Contains(t, rec.Body.String(), "E3234")
+}
@@ -3,10 +3,18 @@ package handler
 import (
 	"FGW_WEB/internal/config"
 	"FGW_WEB/internal/handler/http_err"
+	"FGW_WEB/internal/handler/json_err"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/service"
 	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"crypto/subtle"
 	"fmt"
 	"html/template"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/sessions"
@@ -17,22 +25,66 @@ const (
 	prefixTmplPerformers = "web/html/"
 	tmplForceLogoutHTML  = "force_logout.html"
 	maxLifeSession       = 4 * time.Hour
+	prefixAPI            = "/api/"
+	pathPassword         = "/password"
+	pathTwoFactor        = "/2fa"
+	bearerPrefix         = "Bearer "
 )
 
+// apiTokenCtxKey ключ контекста запроса с API-токеном, по которому он прошел.
+type apiTokenCtxKey struct{}
+
+// accessClaimsCtxKey ключ контекста запроса с утверждениями JWT, по которому он прошел.
+type accessClaimsCtxKey struct{}
+
+// csrfExemptPaths входы в систему, отзыв токена обновления и смена пароля с устройства: сессии и CSRF-токена у
+// них нет.
+var csrfExemptPaths = map[string]bool{
+	"/auth":                  true,
+	"/api/fgw/login":         true,
+	"/api/auth/badge":        true,
+	"/api/auth/token":        true,
+	"/api/auth/token/revoke": true,
+	"/api/auth/password":     true,
+}
+
 type AuthMiddleware struct {
-	store        *sessions.CookieStore
-	sessName     string
-	performerKey string
-	roleKey      string
-	logg         *common.Logger
+	store          *sessions.CookieStore
+	sessName       string
+	performerKey   string
+	roleKeys       map[string]string // roleKeys - ключ сессии с ролью сотрудника по приложению.
+	permissionKeys map[string]string // permissionKeys - ключ сессии с правами роли по приложению.
+	registry       service.SessionUseCase
+	permissions    service.PermissionUseCase
+	apiTokens      service.ApiTokenUseCase
+	accessTokens   service.AccessTokenUseCase
+	logg           *common.Logger
 }
 
-func NewAuthMiddleware(store *sessions.CookieStore, logg *common.Logger) *AuthMiddleware {
+func NewAuthMiddleware(
+	store *sessions.CookieStore,
+	registry service.SessionUseCase,
+	permissions service.PermissionUseCase,
+	apiTokens service.ApiTokenUseCase,
+	accessTokens service.AccessTokenUseCase,
+	logg *common.Logger) *AuthMiddleware {
+
 	return &AuthMiddleware{
 		store:        store,
 		sessName:     config.GetSessionName(),
 		performerKey: config.SessionPerformerKey,
-		roleKey:      config.SessionRoleKey,
+		roleKeys: map[string]string{
+			model.AppAForms: config.SessionRoleKey,
+			model.AppFGW:    config.SessionRoleFGWKey,
+		},
+		permissionKeys: map[string]string{
+			model.AppAForms: config.SessionPermissionsKey,
+			model.AppFGW:    config.SessionPermissionsFGWKey,
+		},
+		registry:     registry,
+		permissions:  permissions,
+		apiTokens:    apiTokens,
+		accessTokens: accessTokens,
 		logg:         logg,
 	}
 }
@@ -71,6 +123,44 @@ func (m *AuthMiddleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Сессии, созданные до разделения ролей по приложениям, хранят только роль AForms.
+		for _, roleKey := range m.roleKeys {
+			if _, ok := session.Values[roleKey].(int); !ok {
+				m.forceLogoutAndRedirect(w, r, "     Роль не определена")
+
+				return
+			}
+		}
+
+		// Проверяем, что сессия есть в реестре и не отозвана.
+		if !m.IsSessionActive(r, session) {
+			m.forceLogoutAndRedirect(w, r, "     Сессия отозвана")
+
+			return
+		}
+
+		// До смены временного пароля или подключения обязательной 2FA доступна только соответствующая страница.
+		if path, reason := m.restrictedPath(session); path != "" && r.URL.Path != path {
+			if r.Method == http.MethodGet {
+				http.Redirect(w, r, path, http.StatusFound)
+
+				return
+			}
+
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, reason, r)
+
+			return
+		}
+
+		// Перечитываем права роли, если их нет в сессии или права ролей изменились.
+		if !m.isPermissionsCached(r, session) {
+			if err = m.CachePermissions(r, session); err != nil {
+				http_err.SendErrorHTTP(w, http.StatusInternalServerError, err.Error(), m.logg, r)
+
+				return
+			}
+		}
+
 		// Обновляем активность сессии.
 		m.updateSessionActivity(session, w, r)
 
@@ -83,37 +173,288 @@ func (m *AuthMiddleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// RequireRole - middleware для проверки ролей.
-func (m *AuthMiddleware) RequireRole(requireRoles []int, next http.HandlerFunc) http.HandlerFunc {
-	allowedRoles := make(map[int]bool)
-	for _, role := range requireRoles {
-		allowedRoles[role] = true
-	}
-
+// RequirePermission - middleware для проверки права доступа по правам роли сотрудника в приложении app,
+// закешированным в сессии. Включает проверку RequireAuth, отдельно оборачивать в RequireAuth не нужно.
+func (m *AuthMiddleware) RequirePermission(app, permission string, next http.HandlerFunc) http.HandlerFunc {
 	return m.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
-		session, err := m.store.Get(r, m.sessName)
-		if err != nil {
-			http.Redirect(w, r, pathToDefault, http.StatusFound)
+		if !m.HasPermission(r, app, permission) {
+			http_err.SendErrorHTTP(w, http.StatusForbidden, "     Доступ запрещен: недостаточно прав.", m.logg, r)
 			return
 		}
 
-		performerRole, ok := session.Values[m.roleKey].(int)
-		if !ok {
-			m.forceLogoutAndRedirect(w, r, "     Роль не определена")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAPI - middleware для JSON API: запрос проходит по токену из заголовка Authorization: Bearer - JWT
+// токену доступа или API-токену с областью доступа scope, или по сессии сотрудника. Области на запись, кроме того,
+// проверяются по правам роли сотрудника (hasScopePermission). Если заголовок передан, сессия не проверяется. Ошибки возвращаются в JSON, без перенаправления на страницу входа.
+func (m *AuthMiddleware) RequireAPI(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			// Токен доступа выдается вместо сессии: области доступа на запись проверяются по правам ролей из токена.
+			if claims, ok := m.accessTokens.VerifyAccessToken(r.Context(), token); ok {
+				r = r.WithContext(context.WithValue(r.Context(), accessClaimsCtxKey{}, claims))
+				if !m.hasScopePermission(r, scope) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+					json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, "Недостаточно прав для "+scope, r)
+
+					return
+				}
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			apiToken, err := m.apiTokens.AuthenticateApiToken(r.Context(), token)
+			if err != nil {
+				json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+				return
+			}
+
+			if apiToken == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "Токен недействителен", r)
+
+				return
+			}
+
+			if !apiToken.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, "Нет области доступа "+scope, r)
+
+				return
+			}
+
+			// Область доступа на запись действует, пока она есть у текущей роли сотрудника, которому выдан токен.
+			r = r.WithContext(context.WithValue(r.Context(), apiTokenCtxKey{}, apiToken))
+			if !m.hasScopePermission(r, scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, "Недостаточно прав для "+scope, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
 			return
 		}
 
-		if !allowedRoles[performerRole] {
-			http_err.SendErrorHTTP(w, http.StatusForbidden, "     Доступ запрещен: недостаточно прав.", m.logg, r)
+		session, err := m.getSecureSession(r)
+		if err != nil || session == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+			return
+		}
+
+		if auth, ok := session.Values[config.SessionAuthPerformer].(bool); !ok || !auth ||
+			m.isSessionExpired(session) || !m.IsSessionActive(r, session) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			json_err.SendErrorResponse(w, http.StatusUnauthorized, msg.H7005, "", r)
+
+			return
+		}
+
+		if path, reason := m.restrictedPath(session); path != "" {
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, reason, r)
+
+			return
+		}
+
+		if !m.isPermissionsCached(r, session) {
+			if err = m.CachePermissions(r, session); err != nil {
+				json_err.SendErrorResponse(w, http.StatusInternalServerError, msg.H7001, err.Error(), r)
+
+				return
+			}
+		}
+
+		if !m.hasScopePermission(r, scope) {
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7010, "Недостаточно прав для "+scope, r)
+
 			return
 		}
 
 		next.ServeHTTP(w, r)
-	})
+	}
+}
+
+// hasScopePermission - у роли сотрудника есть право, нужное для области доступа scope (model.ScopePermissions).
+func (m *AuthMiddleware) hasScopePermission(r *http.Request, scope string) bool {
+	perm, ok := model.ScopePermissions[scope]
+	if !ok {
+		return true
+	}
+
+	return m.HasPermission(r, perm.App, perm.Code)
+}
+
+// ApiToken - API-токен, по которому прошел запрос, false - запрос по сессии.
+func (m *AuthMiddleware) ApiToken(r *http.Request) (*model.ApiToken, bool) {
+	apiToken, ok := r.Context().Value(apiTokenCtxKey{}).(*model.ApiToken)
+
+	return apiToken, ok
+}
+
+// AccessClaims - утверждения JWT токена доступа, по которому прошел запрос, false - запрос не по JWT.
+func (m *AuthMiddleware) AccessClaims(r *http.Request) (*model.AccessClaims, bool) {
+	claims, ok := r.Context().Value(accessClaimsCtxKey{}).(*model.AccessClaims)
+
+	return claims, ok
+}
+
+// isTokenRequest - запрос прошел по токену доступа или API-токену: права берутся по ролям из токена, а не из сессии.
+func (m *AuthMiddleware) isTokenRequest(r *http.Request) bool {
+	if _, ok := m.AccessClaims(r); ok {
+		return true
+	}
+
+	_, ok := m.ApiToken(r)
+
+	return ok
+}
+
+// bearerToken - токен из заголовка Authorization: Bearer.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
+
+// CachePermissions - сохранение в сессии прав ролей сотрудника во всех приложениях, вызывается при входе до
+// сохранения сессии.
+func (m *AuthMiddleware) CachePermissions(r *http.Request, session *sessions.Session) error {
+	version, err := m.permissions.PermissionsVersion(r.Context())
+	if err != nil {
+		return err
+	}
+
+	for app, roleKey := range m.roleKeys {
+		roleId, _ := session.Values[roleKey].(int)
+
+		codes, err := m.permissions.GetRolePermissionCodes(r.Context(), roleId)
+		if err != nil {
+			return err
+		}
+
+		session.Values[m.permissionKeys[app]] = strings.Join(codes, ",")
+	}
+	session.Values[config.SessionPermissionsVerKey] = version
+
+	return nil
 }
 
-// GetPerformerId - получение ID пользователя.
+// isPermissionsCached - права ролей есть в сессии и не устарели. Если версию прав не прочитать, права перечитываются.
+func (m *AuthMiddleware) isPermissionsCached(r *http.Request, session *sessions.Session) bool {
+	for _, permissionKey := range m.permissionKeys {
+		if _, ok := session.Values[permissionKey].(string); !ok {
+			return false
+		}
+	}
+
+	version, _ := session.Values[config.SessionPermissionsVerKey].(int64)
+	current, err := m.permissions.PermissionsVersion(r.Context())
+
+	return err == nil && version == current
+}
+
+// PassMustChange - вход выполнен по временному паролю и его нужно сменить.
+func (m *AuthMiddleware) PassMustChange(r *http.Request) bool {
+	session, err := m.store.Get(r, m.sessName)
+	if err != nil {
+		return false
+	}
+
+	return m.isPassMustChange(session)
+}
+
+// isPassMustChange - в сессии отмечен вход по временному паролю.
+func (m *AuthMiddleware) isPassMustChange(session *sessions.Session) bool {
+	mustChange, _ := session.Values[config.SessionPassMustChangeKey].(bool)
+
+	return mustChange
+}
+
+// TwoFactorEnroll - 2FA обязательна для роли сотрудника, но не подключена.
+func (m *AuthMiddleware) TwoFactorEnroll(r *http.Request) bool {
+	session, err := m.store.Get(r, m.sessName)
+	if err != nil {
+		return false
+	}
+
+	return m.isTwoFactorEnroll(session)
+}
+
+// isTwoFactorEnroll - в сессии отмечен вход без обязательной 2FA.
+func (m *AuthMiddleware) isTwoFactorEnroll(session *sessions.Session) bool {
+	enroll, _ := session.Values[config.SessionTwoFactorEnrollKey].(bool)
+
+	return enroll
+}
+
+// restrictedPath - единственная страница, доступная сессии, и причина ограничения: сначала смена временного пароля,
+// затем подключение обязательной 2FA. Пусто - ограничений нет.
+func (m *AuthMiddleware) restrictedPath(session *sessions.Session) (string, string) {
+	switch {
+	case m.isPassMustChange(session):
+		return pathPassword, msg.E3231
+	case m.isTwoFactorEnroll(session):
+		return pathTwoFactor, msg.E3234
+	default:
+		return "", ""
+	}
+}
+
+// HasPermission - есть ли право доступа у роли сотрудника в приложении app. Для сессии права берутся из кеша в
+// сессии, для токена доступа - по роли из токена, для API-токена - по текущей роли сотрудника, которому он выдан.
+func (m *AuthMiddleware) HasPermission(r *http.Request, app, permission string) bool {
+	var codes []string
+	if m.isTokenRequest(r) {
+		roleId, _ := m.GetRoleId(r, app)
+		performerId, _ := m.GetPerformerId(r)
+
+		var err error
+		if codes, err = m.permissions.GetRolePermissionCodes(r.Context(), roleId); err != nil {
+			m.logg.LogE(msg.E3209, fmt.Errorf("%s: права роли %d сотрудника %d: %w", msg.E3209, roleId, performerId, err))
+
+			return false
+		}
+	} else {
+		session, err := m.store.Get(r, m.sessName)
+		if err != nil {
+			return false
+		}
+
+		cached, _ := session.Values[m.permissionKeys[app]].(string)
+		codes = strings.Split(cached, ",")
+	}
+
+	for _, code := range codes {
+		if code == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetPerformerId - получение ID пользователя: сотрудника сессии, токена доступа или сотрудника, которому выдан
+// API-токен.
 func (m *AuthMiddleware) GetPerformerId(r *http.Request) (int, bool) {
+	if claims, ok := m.AccessClaims(r); ok {
+		return claims.PerformerId, true
+	}
+
+	if apiToken, ok := m.ApiToken(r); ok {
+		return apiToken.PerformerId, true
+	}
+
 	session, err := m.store.Get(r, m.sessName)
 	if err != nil {
 		return 0, false
@@ -123,17 +464,166 @@ func (m *AuthMiddleware) GetPerformerId(r *http.Request) (int, bool) {
 	return performerId, ok
 }
 
-// GetRoleId - получение ID роли.
-func (m *AuthMiddleware) GetRoleId(r *http.Request) (int, bool) {
+// GetRoleId - получение ID роли сотрудника в приложении app.
+func (m *AuthMiddleware) GetRoleId(r *http.Request, app string) (int, bool) {
+	if claims, ok := m.AccessClaims(r); ok {
+		if app == model.AppFGW {
+			return claims.RoleFGWId, true
+		}
+
+		return claims.RoleId, true
+	}
+
+	if apiToken, ok := m.ApiToken(r); ok {
+		if app == model.AppFGW {
+			return apiToken.RoleFGWId, true
+		}
+
+		return apiToken.RoleId, true
+	}
+
 	session, err := m.store.Get(r, m.sessName)
 	if err != nil {
 		return 0, false
 	}
 
-	performerRole, ok := session.Values[m.roleKey].(int)
+	performerRole, ok := session.Values[m.roleKeys[app]].(int)
 	return performerRole, ok
 }
 
+// ClientAddr - адрес клиента без порта.
+func ClientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// RegisterSession - регистрация сессии в реестре по её токену, вызывается при входе до сохранения сессии.
+func (m *AuthMiddleware) RegisterSession(r *http.Request, session *sessions.Session) error {
+	token, _ := session.Values["session_token"].(string)
+	performerId, _ := session.Values[m.performerKey].(int)
+	roleId, _ := session.Values[m.roleKeys[model.AppAForms]].(int)
+	deviceType, _ := session.Values[config.SessionDeviceKey].(string)
+
+	createdAt := time.Now()
+	if created, ok := session.Values["created_at"].(int64); ok {
+		createdAt = time.Unix(created, 0)
+	}
+
+	maxAge := maxLifeSession
+	if customMaxAge, ok := session.Values["max_age"].(int); ok {
+		maxAge = time.Duration(customMaxAge) * time.Second
+	}
+
+	return m.registry.RegisterSession(r.Context(), token, &model.SessionRecord{
+		PerformerId: performerId,
+		RoleId:      roleId,
+		DeviceType:  deviceType,
+		RemoteAddr:  ClientAddr(r),
+		UserAgent:   r.UserAgent(),
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(maxAge),
+	})
+}
+
+// IsSessionActive - проверка сессии по реестру: токен зарегистрирован, не отозван и не истек.
+func (m *AuthMiddleware) IsSessionActive(r *http.Request, session *sessions.Session) bool {
+	token, ok := session.Values["session_token"].(string)
+	if !ok {
+		return false
+	}
+
+	active, err := m.registry.CheckSession(r.Context(), token)
+	if err != nil {
+		return false
+	}
+
+	return active
+}
+
+// ProtectCSRF - middleware проверки CSRF-токена для всех изменяющих запросов (кроме GET, HEAD, OPTIONS). Токен
+// передается в заголовке X-CSRF-Token или в поле формы csrf_token и сверяется с токеном сессии.
+func (m *AuthMiddleware) ProtectCSRF(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if csrfExemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		// Запросы к API с токеном в заголовке не используют куки сессии, подделать их со стороннего сайта нельзя.
+		if _, ok := bearerToken(r); ok && strings.HasPrefix(r.URL.Path, prefixAPI) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		token := r.Header.Get(config.CSRFHeader)
+		if token == "" {
+			token = r.PostFormValue(config.CSRFFormField)
+		}
+
+		expected := m.CSRFToken(r)
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			m.logg.LogW(fmt.Sprintf("%s%s %s", msg.H7012, r.Method, r.URL.Path))
+			json_err.SendErrorResponse(w, http.StatusForbidden, msg.H7012, "", r)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CSRFToken - получение CSRF-токена сессии, пусто - нет сессии.
+func (m *AuthMiddleware) CSRFToken(r *http.Request) string {
+	session, err := m.store.Get(r, m.sessName)
+	if err != nil {
+		return ""
+	}
+
+	token, _ := session.Values[config.SessionCSRFKey].(string)
+
+	return token
+}
+
+// GetSessionId - получение ид текущей сессии в реестре.
+func (m *AuthMiddleware) GetSessionId(r *http.Request) (string, bool) {
+	session, err := m.store.Get(r, m.sessName)
+	if err != nil {
+		return "", false
+	}
+
+	token, ok := session.Values["session_token"].(string)
+	if !ok {
+		return "", false
+	}
+
+	return model.SessionTokenId(token), true
+}
+
+// RemoveSessionToken - отзыв сессии по токену, вызывается при выходе.
+func (m *AuthMiddleware) RemoveSessionToken(token string) {
+	if _, err := m.registry.RevokeSession(context.Background(), token); err != nil {
+		m.logg.LogE(msg.E3216, err)
+	}
+}
+
+// RevokePerformerSessions - отзыв всех сессий сотрудника, возвращает кол-во отозванных.
+func (m *AuthMiddleware) RevokePerformerSessions(ctx context.Context, performerId int) (int, error) {
+	return m.registry.RevokePerformerSessions(ctx, performerId)
+}
+
 // getSecureSession - безопасное получение сессии с валидацией.
 func (m *AuthMiddleware) getSecureSession(r *http.Request) (*sessions.Session, error) {
 	session, err := m.store.Get(r, m.sessName)
@@ -170,6 +660,11 @@ func (m *AuthMiddleware) updateSessionActivity(session *sessions.Session, w http
 	now := time.Now()
 	session.Values["last_activity"] = now.Unix()
 
+	// Сессии, созданные до появления CSRF-токена, получают его при первом запросе.
+	if _, ok := session.Values[config.SessionCSRFKey].(string); !ok {
+		session.Values[config.SessionCSRFKey] = config.GenerateSessionToken()
+	}
+
 	// Устанавливаем куку с коротким временем жизни для браузера.
 	if cookie, err := r.Cookie("activity_check"); err != nil || cookie.Value != "active" {
 		http.SetCookie(w, &http.Cookie{
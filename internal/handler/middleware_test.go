@@ -0,0 +1,368 @@
+package handler
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/internal/service"
+	"FGW_WEB/pkg/common"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePermissionRepo репозиторий прав: роль 5 заводит сменные задания и отгружает п\п, у остальных ролей прав нет.
+type fakePermissionRepo struct {
+	repository.PermissionRepository
+}
+
+func (f *fakePermissionRepo) CodesByRole(_ context.Context, roleId int) ([]string, error) {
+	if roleId == 5 {
+		return []string{model.PermShiftTasksEdit, model.PermPalletsMove}, nil
+	}
+
+	return nil, nil
+}
+
+func (f *fakePermissionRepo) Version(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+// fakeApiTokenRepo репозиторий API-токенов в памяти, как ХП svTB_ApiTokenByHash отдает токен с текущими ролями
+// сотрудника из performers, сотрудника нет в performers - он в архиве.
+type fakeApiTokenRepo struct {
+	tokens     []*model.ApiToken
+	performers map[int]*model.Performer
+}
+
+func (f *fakeApiTokenRepo) Add(_ context.Context, token *model.ApiToken) (int, error) {
+	f.tokens = append(f.tokens, token)
+
+	return len(f.tokens), nil
+}
+
+func (f *fakeApiTokenRepo) FindByHash(_ context.Context, tokenHash string) (*model.ApiToken, error) {
+	for _, token := range f.tokens {
+		performer, ok := f.performers[token.PerformerId]
+		if token.TokenHash == tokenHash && token.RevokedAt == nil && ok {
+			found := *token
+			found.RoleId, found.RoleFGWId = performer.IdRoleAForms, performer.IdRoleAFGW
+
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeApiTokenRepo) All(_ context.Context) ([]*model.ApiToken, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeApiTokenRepo) Revoke(_ context.Context, id int) error {
+	now := time.Now()
+	f.tokens[id-1].RevokedAt = &now
+
+	return nil
+}
+
+func (f *fakeApiTokenRepo) Touch(_ context.Context, _ int, _ time.Time) error {
+	return nil
+}
+
+// fakePerformerRepo репозиторий сотрудников для выдачи токенов, неиспользуемые методы не реализованы.
+type fakePerformerRepo struct {
+	repository.PerformerRepository
+}
+
+func (f *fakePerformerRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return id == 1001, nil
+}
+
+func (f *fakePerformerRepo) FindById(_ context.Context, id int) (*model.Performer, error) {
+	if id != 1001 {
+		return nil, sql.ErrNoRows
+	}
+
+	return newTestPerformer(), nil
+}
+
+// newTestPerformer оператор: роль 4 в AForms, роль 5 в FGW.
+func newTestPerformer() *model.Performer {
+	return &model.Performer{Id: 1001, FIO: "Иванов И.И.", IdRoleAForms: 4, IdRoleAFGW: 5}
+}
+
+// testMiddleware middleware с реестром сессий в памяти и сервисы токенов, проверяемые им.
+type testMiddleware struct {
+	*AuthMiddleware
+	apiTokenRepo *fakeApiTokenRepo
+	apiTokens    service.ApiTokenUseCase
+	accessTokens service.AccessTokenUseCase
+}
+
+func newTestMiddleware(t *testing.T) *testMiddleware {
+	t.Helper()
+
+	store := sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef"))
+	sessionService := service.NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+	permissionService := service.NewPermissionService(&fakePermissionRepo{}, nil, &common.Logger{})
+	apiTokenRepo := &fakeApiTokenRepo{performers: map[int]*model.Performer{1001: newTestPerformer()}}
+	apiTokens := service.NewApiTokenService(apiTokenRepo, &fakePerformerRepo{}, &common.Logger{})
+
+	accessTokens := service.NewAccessTokenService(&config.JWTCfg{
+		Keys:       map[string][]byte{"test": []byte("0123456789abcdef0123456789abcdef")},
+		ActiveKid:  "test",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}, &fakePerformerRepo{}, sessionService, &common.Logger{})
+
+	return &testMiddleware{
+		AuthMiddleware: NewAuthMiddleware(store, sessionService, permissionService, apiTokens, accessTokens, &common.Logger{}),
+		apiTokenRepo:   apiTokenRepo,
+		apiTokens:      apiTokens,
+		accessTokens:   accessTokens,
+	}
+}
+
+// newTestSession cookie и CSRF-токен сессии сотрудника, сессия собирается и регистрируется как при входе.
+func (m *testMiddleware) newTestSession(t *testing.T, performer *model.Performer) ([]*http.Cookie, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	session, err := m.store.Get(req, m.sessName)
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+	csrfToken := config.GenerateSessionToken()
+
+	session.Values[config.SessionAuthPerformer] = true
+	session.Values[config.SessionPerformerKey] = performer.Id
+	session.Values[config.SessionRoleKey] = performer.IdRoleAForms
+	session.Values[config.SessionRoleFGWKey] = performer.IdRoleAFGW
+	session.Values["session_token"] = config.GenerateSessionToken()
+	session.Values[config.SessionCSRFKey] = csrfToken
+	session.Values["created_at"] = now
+	session.Values["last_activity"] = now
+
+	require.NoError(t, m.CachePermissions(req, session))
+	require.NoError(t, m.RegisterSession(req, session))
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, session.Save(req, rec))
+
+	return rec.Result().Cookies(), csrfToken
+}
+
+// okHandler отвечает 200 и ид сотрудника запроса.
+func (m *testMiddleware) okHandler(w http.ResponseWriter, r *http.Request) {
+	performerId, _ := m.GetPerformerId(r)
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(strconv.Itoa(performerId)))
+}
+
+func TestProtectCSRF(t *testing.T) {
+	m := newTestMiddleware(t)
+	cookies, csrfToken := m.newTestSession(t, newTestPerformer())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/fgw/performers/upd", m.RequireAPI(model.ScopePerformersRead, m.okHandler))
+	protected := m.ProtectCSRF(mux)
+
+	update := func(token string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/fgw/performers/upd", strings.NewReader(`{}`))
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		if token != "" {
+			req.Header.Set(config.CSRFHeader, token)
+		}
+		protected.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, update(""), "без токена")
+	assert.Equal(t, http.StatusForbidden, update("wrong"), "чужой токен")
+	assert.Equal(t, http.StatusOK, update(csrfToken))
+}
+
+func TestRequirePermission_PerApp(t *testing.T) {
+	m := newTestMiddleware(t)
+	cookies, _ := m.newTestSession(t, newTestPerformer())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/test/fgw", m.RequirePermission(model.AppFGW, model.PermShiftTasksEdit, m.okHandler))
+	mux.HandleFunc("/test/aforms", m.RequirePermission(model.AppAForms, model.PermShiftTasksEdit, m.okHandler))
+
+	get := func(url string, cookies []*http.Cookie) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		mux.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("/test/fgw", cookies), "право роли FGW")
+	assert.Equal(t, http.StatusForbidden, get("/test/aforms", cookies), "у роли AForms права нет")
+	assert.NotEqual(t, http.StatusOK, get("/test/fgw", nil), "без сессии")
+}
+
+func TestRequireAPI_SessionPermissions(t *testing.T) {
+	m := newTestMiddleware(t)
+
+	// Оператор (роль 5 в обоих приложениях): читать может, менять сотрудников и роли - нет.
+	performer := newTestPerformer()
+	performer.IdRoleAForms = 5
+	cookies, _ := m.newTestSession(t, performer)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/fgw/performers", m.RequireAPI(model.ScopePerformersRead, m.okHandler))
+	mux.HandleFunc("/api/fgw/performers/upd", m.RequireAPI(model.ScopePerformersWrite, m.okHandler))
+	mux.HandleFunc("/api/fgw/roles/upd", m.RequireAPI(model.ScopeRolesWrite, m.okHandler))
+
+	call := func(method, url string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(`{"idRoleAForms":3}`))
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		mux.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, "/api/fgw/performers"), "область на чтение")
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, "/api/fgw/performers/upd?performerId=1001"))
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, "/api/fgw/roles/upd"))
+}
+
+func TestRequireAPI_AccessTokenPermissions(t *testing.T) {
+	m := newTestMiddleware(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/fgw/performers", m.RequireAPI(model.ScopePerformersRead, m.okHandler))
+	mux.HandleFunc("/api/fgw/performers/upd", m.RequireAPI(model.ScopePerformersWrite, m.okHandler))
+
+	tokens, err := m.accessTokens.IssueTokens(context.Background(), newTestPerformer(), &model.TokenClient{DeviceType: "tsd"})
+	require.NoError(t, err)
+
+	call := func(method, url string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		mux.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, "/api/fgw/performers"), "область на чтение")
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, "/api/fgw/performers/upd"), "у ролей из токена нет права")
+
+	require.NoError(t, m.accessTokens.RevokeRefreshToken(context.Background(), tokens.RefreshToken))
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/api/fgw/performers"), "сессия токена отозвана")
+}
+
+func TestRequireAPI_Bearer(t *testing.T) {
+	m := newTestMiddleware(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/fgw/performers", m.RequireAPI(model.ScopePerformersRead, m.okHandler))
+	mux.HandleFunc("/api/fgw/performers/upd", m.RequireAPI(model.ScopePerformersWrite, m.okHandler))
+	protected := m.ProtectCSRF(mux)
+
+	issue := func(scopes ...string) string {
+		created, err := m.apiTokens.CreateApiToken(context.Background(), &model.ApiTokenCreate{
+			Name: "интеграция", PerformerId: 1001, Scopes: scopes,
+		}, 1)
+		require.NoError(t, err)
+
+		return created.Token
+	}
+	readToken := issue(model.ScopePerformersRead)
+	revokedToken := issue(model.ScopePerformersRead)
+	require.NoError(t, m.apiTokens.RevokeApiToken(context.Background(), 2))
+
+	call := func(method, url, token string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(`{}`))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		protected.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		token  string
+		status int
+	}{
+		{name: "без токена и сессии", method: http.MethodGet, url: "/api/fgw/performers", status: http.StatusUnauthorized},
+		{name: "неизвестный токен", method: http.MethodGet, url: "/api/fgw/performers", token: "fgw_unknown", status: http.StatusUnauthorized},
+		{name: "отозванный токен", method: http.MethodGet, url: "/api/fgw/performers", token: revokedToken, status: http.StatusUnauthorized},
+		{name: "список", method: http.MethodGet, url: "/api/fgw/performers", token: readToken, status: http.StatusOK},
+		{name: "нет области доступа", method: http.MethodPut, url: "/api/fgw/performers/upd", token: readToken, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := call(tt.method, tt.url, tt.token)
+
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.status != http.StatusOK {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"), "ответ токена, а не CSRF-проверки")
+			}
+		})
+	}
+
+	assert.Equal(t, "1001", call(http.MethodGet, "/api/fgw/performers", readToken).Body.String(), "сотрудник токена")
+}
+
+func TestRequireAPI_ApiTokenPermissions(t *testing.T) {
+	m := newTestMiddleware(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/fgw/pallets/place", m.RequireAPI(model.ScopePalletsWrite, m.okHandler))
+
+	created, err := m.apiTokens.CreateApiToken(context.Background(), &model.ApiTokenCreate{
+		Name: "ТСД склада", PerformerId: 1001, Scopes: []string{model.ScopePalletsWrite},
+	}, 1)
+	require.NoError(t, err)
+
+	call := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/fgw/pallets/place", strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer "+created.Token)
+		mux.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, call().Code, "у роли 5 в FGW есть право отгрузки")
+
+	// Бессрочный токен переживает смену роли, но право проверяется по текущей роли сотрудника.
+	m.apiTokenRepo.performers[1001].IdRoleAFGW = 4
+	rec := call()
+	assert.Equal(t, http.StatusForbidden, rec.Code, "роль без права отгрузки")
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "insufficient_scope")
+
+	delete(m.apiTokenRepo.performers, 1001)
+	assert.Equal(t, http.StatusUnauthorized, call().Code, "сотрудник в архиве")
+}
@@ -0,0 +1,53 @@
+package model
+
+const (
+	TokenTypeAccess  = "access"  // TokenTypeAccess - токен доступа к JSON API.
+	TokenTypeRefresh = "refresh" // TokenTypeRefresh - токен обновления, меняется на новую пару токенов.
+
+	GrantPassword     = "password"      // GrantPassword - вход по табельному номеру и паролю.
+	GrantBadge        = "badge"         // GrantBadge - вход по штрих-коду бейджа.
+	GrantRefreshToken = "refresh_token" // GrantRefreshToken - обмен токена обновления.
+)
+
+// AccessClaims утверждения JWT токена доступа или обновления.
+type AccessClaims struct {
+	Issuer      string `json:"iss"`           // Issuer - издатель.
+	PerformerId int    `json:"pid"`           // PerformerId - табельный номер.
+	RoleId      int    `json:"role"`          // RoleId - роль сотрудника в AForms.
+	RoleFGWId   int    `json:"role_fgw"`      // RoleFGWId - роль сотрудника в FGW.
+	DeviceType  string `json:"dev,omitempty"` // DeviceType - тип устройства входа.
+	SessionId   string `json:"sid,omitempty"` // SessionId - ид токена обновления в реестре сессий (токен доступа).
+	TokenType   string `json:"typ"`           // TokenType - access или refresh.
+	Id          string `json:"jti"`           // Id - уникальный ид токена.
+	IssuedAt    int64  `json:"iat"`           // IssuedAt - время выдачи, unix.
+	ExpiresAt   int64  `json:"exp"`           // ExpiresAt - окончание действия, unix.
+}
+
+// TokenClient клиент, которому выдаются токены, для реестра сессий.
+type TokenClient struct {
+	DeviceType string
+	RemoteAddr string
+	UserAgent  string
+}
+
+// TokenRequest запрос токенов: grant_type password (id, password, otp), badge (barcode) или refresh_token.
+type TokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	Id           int    `json:"id"`
+	Password     string `json:"password"`
+	Otp          string `json:"otp"` // Otp - код 2FA, если она подключена.
+	Barcode      string `json:"barcode"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse выданная пара токенов.
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`         // TokenType - всегда Bearer.
+	ExpiresIn        int    `json:"expires_in"`         // ExpiresIn - время жизни токена доступа, сек.
+	RefreshToken     string `json:"refresh_token"`      // RefreshToken - одноразовый, при обмене выдается новый.
+	RefreshExpiresIn int    `json:"refresh_expires_in"` // RefreshExpiresIn - время жизни токена обновления, сек.
+	PerformerId      int    `json:"performerId"`
+	RoleId           int    `json:"roleId"`
+	RoleFGWId        int    `json:"roleFGWId"`
+}
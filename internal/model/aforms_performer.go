@@ -0,0 +1,37 @@
+package model
+
+// Результат добавления или обновления сотрудника AForms.
+const (
+	UpsertSkipped = 0 // UpsertSkipped - данные не изменились.
+	UpsertCreated = 1 // UpsertCreated - сотрудник добавлен.
+	UpsertUpdated = 2 // UpsertUpdated - сотрудник обновлен.
+)
+
+// AFormsPerformer сотрудник AForms (svTB_Performer), данные получаем от Галактики.
+type AFormsPerformer struct {
+	Id         int    `json:"id"`         // Id - ИД.
+	SectorId   int    `json:"sectorId"`   // SectorId - ид печки (svTB_Sector), 0 - не привязан.
+	SectorName string `json:"sectorName"` // SectorName - наименование привязанной печки.
+	Folder     int    `json:"folder"`     // Folder - папка.
+	Name       string `json:"name"`       // Name - ФИО.
+	Barcode    string `json:"barcode"`    // Barcode - штрих-код.
+	EditDate   string `json:"editDate"`   // EditDate - дата редактирования.
+	EditUser   int    `json:"editUser"`   // EditUser - ИД пользователя редактирования.
+	Tabnum     int    `json:"tabnum"`     // Tabnum - табельный номер.
+}
+
+// ImportReport итог импорта сотрудников из выгрузки Галактики.
+type ImportReport struct {
+	Created int      `json:"created"` // Created - кол-во добавленных строк.
+	Updated int      `json:"updated"` // Updated - кол-во обновленных строк.
+	Skipped int      `json:"skipped"` // Skipped - кол-во пропущенных строк.
+	Errors  []string `json:"errors"`  // Errors - причины пропуска строк.
+}
+
+// Skip отмечает строку выгрузки как пропущенную.
+func (r *ImportReport) Skip(reason string) {
+	r.Skipped++
+	if reason != "" {
+		r.Errors = append(r.Errors, reason)
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	ScopePerformersRead    = "performers:read"     // ScopePerformersRead - список и карточки сотрудников.
+	ScopePerformersWrite   = "performers:write"    // ScopePerformersWrite - изменение ролей сотрудников.
+	ScopeRolesRead         = "roles:read"          // ScopeRolesRead - список ролей.
+	ScopeRolesWrite        = "roles:write"         // ScopeRolesWrite - добавление и изменение ролей.
+	ScopeProductsRead      = "products:read"       // ScopeProductsRead - продукция, история, справочники, проверка линии.
+	ScopeProductsWrite     = "products:write"      // ScopeProductsWrite - изменение продукции.
+	ScopeCatalogsRead      = "catalogs:read"       // ScopeCatalogsRead - справочники: складские зоны.
+	ScopeDeclarationsRead  = "declarations:read"   // ScopeDeclarationsRead - декларации, проверка печати этикетки.
+	ScopePackStationsRead  = "pack_stations:read"  // ScopePackStationsRead - станции упаковки.
+	ScopePackStationsWrite = "pack_stations:write" // ScopePackStationsWrite - сеансы станций и печать штампа.
+	ScopeShiftTasksRead    = "shift_tasks:read"    // ScopeShiftTasksRead - сменно-суточные задания.
+	ScopePalletsWrite      = "pallets:write"       // ScopePalletsWrite - отгрузка и размещение п\п.
+)
+
+// ApiTokenScopes области доступа API-токенов в порядке вывода.
+var ApiTokenScopes = []string{
+	ScopePerformersRead, ScopePerformersWrite,
+	ScopeRolesRead, ScopeRolesWrite,
+	ScopeProductsRead, ScopeProductsWrite,
+	ScopeCatalogsRead,
+	ScopeDeclarationsRead,
+	ScopePackStationsRead, ScopePackStationsWrite,
+	ScopeShiftTasksRead,
+	ScopePalletsWrite,
+}
+
+// ApiToken API-токен сотрудника. Сам токен показывается один раз при выдаче, хранится только его хеш.
+type ApiToken struct {
+	Id          int        `json:"id"`          // Id - ид токена.
+	Name        string     `json:"name"`        // Name - назначение токена.
+	PerformerId int        `json:"performerId"` // PerformerId - табельный номер сотрудника, от имени которого работает токен.
+	Scopes      []string   `json:"scopes"`      // Scopes - области доступа.
+	TokenHash   string     `json:"-"`           // TokenHash - SHA-256 токена (hex).
+	CreatedAt   time.Time  `json:"createdAt"`   // CreatedAt - дата выдачи.
+	CreatedBy   int        `json:"createdBy"`   // CreatedBy - табельный номер администратора.
+	ExpiresAt   *time.Time `json:"expiresAt"`   // ExpiresAt - окончание действия, nil - бессрочный.
+	LastUsedAt  *time.Time `json:"lastUsedAt"`  // LastUsedAt - время последнего запроса.
+	RevokedAt   *time.Time `json:"revokedAt"`   // RevokedAt - время отзыва, nil - действует.
+	RoleId      int        `json:"-"`           // RoleId - текущая роль сотрудника в AForms, только у токена запроса.
+	RoleFGWId   int        `json:"-"`           // RoleFGWId - текущая роль сотрудника в FGW, только у токена запроса.
+}
+
+// HasScope есть ли у токена область доступа.
+func (t *ApiToken) HasScope(scope string) bool {
+	for _, tokenScope := range t.Scopes {
+		if tokenScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApiTokenHash хеш API-токена для хранения и поиска в БД.
+func ApiTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// ApiTokenCreate запрос на выдачу API-токена.
+type ApiTokenCreate struct {
+	Name        string   `json:"name"`
+	PerformerId int      `json:"performerId"`
+	Scopes      []string `json:"scopes"`
+	ExpiresDays int      `json:"expiresDays"` // ExpiresDays - срок действия в днях, 0 - бессрочный.
+}
+
+// ApiTokenCreated выданный API-токен вместе с самим токеном.
+type ApiTokenCreated struct {
+	Success  bool      `json:"success"`
+	Message  string    `json:"message"`
+	Token    string    `json:"token"` // Token - токен для заголовка Authorization: Bearer.
+	ApiToken *ApiToken `json:"apiToken"`
+}
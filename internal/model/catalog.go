@@ -0,0 +1,136 @@
+package model
+
+import "math"
+
+// Коды справочников svCatalogs (kodcat).
+const (
+	KodcatDesign      = 0  // KodcatDesign - конструкторские наименования продукции.
+	KodcatColor       = 3  // KodcatColor - цвета продукции.
+	KodcatPrinter     = 4  // KodcatPrinter - принтеры.
+	KodcatPackArea    = 9  // KodcatPackArea - участки упаковки.
+	KodcatStorageArea = 10 // KodcatStorageArea - участки хранения.
+)
+
+// Catalog запись справочника svCatalogs.
+type Catalog struct {
+	Id        int     `json:"id"`        // Id - ид записи.
+	ParId     int     `json:"parId"`     // ParId - ид родительской записи.
+	Kodcat    int     `json:"kodcat"`    // Kodcat - код справочника.
+	Kod       int     `json:"kod"`       // Kod - пользовательский код записи.
+	Name      string  `json:"name"`      // Name - наименование.
+	Comm      string  `json:"comm"`      // Comm - комментарий.
+	DopInt1   int     `json:"dopInt1"`   // DopInt1 - дополнительное поле.
+	DopInt2   int     `json:"dopInt2"`   // DopInt2 - дополнительное поле.
+	DopFloat1 float64 `json:"dopFloat1"` // DopFloat1 - дополнительное поле.
+	DopFloat2 float64 `json:"dopFloat2"` // DopFloat2 - дополнительное поле.
+	DopBit1   bool    `json:"dopBit1"`   // DopBit1 - дополнительное поле.
+	DopBit2   bool    `json:"dopBit2"`   // DopBit2 - дополнительное поле.
+	Archive   bool    `json:"archive"`   // Archive - архивная запись.
+}
+
+// StorageArea участок хранения (kodcat = 10).
+type StorageArea struct {
+	Id             int     `json:"id"`             // Id - ид записи справочника.
+	Kod            int     `json:"kod"`            // Kod - код участка.
+	Name           string  `json:"name"`           // Name - наименование участка.
+	Capacity       float64 `json:"capacity"`       // Capacity - вместимость, площадь, объём (dop_float_2).
+	UsablePercent  float64 `json:"usablePercent"`  // UsablePercent - возможный процент использования (dop_float_1).
+	UsableCapacity float64 `json:"usableCapacity"` // UsableCapacity - вместимость с учетом процента использования.
+	Closed         bool    `json:"closed"`         // Closed - площадка закрытая (dop_bit_1).
+	Railway        bool    `json:"railway"`        // Railway - наличие ЖД путей (dop_bit_2).
+}
+
+type StorageAreaList struct {
+	StorageAreas []*StorageArea `json:"storageAreas"`
+}
+
+// NewStorageArea собирает участок хранения из записи справочника.
+func NewStorageArea(c *Catalog) *StorageArea {
+	usablePercent := c.DopFloat1
+	if usablePercent <= 0 || usablePercent > 100 {
+		usablePercent = 100
+	}
+
+	return &StorageArea{
+		Id:             c.Id,
+		Kod:            c.Kod,
+		Name:           c.Name,
+		Capacity:       c.DopFloat2,
+		UsablePercent:  usablePercent,
+		UsableCapacity: c.DopFloat2 * usablePercent / 100,
+		Closed:         c.DopBit1,
+		Railway:        c.DopBit2,
+	}
+}
+
+// Уровень заполненности участка хранения, по нему участок окрашивается на карте склада.
+const (
+	OccupancyUnknown = "unknown" // OccupancyUnknown - вместимость участка не задана.
+	OccupancyLow     = "low"     // OccupancyLow - место есть.
+	OccupancyMedium  = "medium"  // OccupancyMedium - заполнен на OccupancyMediumFrom и больше.
+	OccupancyHigh    = "high"    // OccupancyHigh - заполнен на OccupancyHighFrom и больше.
+
+	OccupancyMediumFrom = 0.7 // OccupancyMediumFrom - доля заполнения, с которой участок желтый.
+	OccupancyHighFrom   = 0.9 // OccupancyHighFrom - доля заполнения, с которой участок красный.
+)
+
+// StorageAreaOccupancy заполненность участка хранения. Вместимость участка считается в местах под п\п.
+type StorageAreaOccupancy struct {
+	*StorageArea
+	Pallets   int     `json:"pallets"`   // Pallets - п\п на складе, размещенные на участке.
+	FreeSpace float64 `json:"freeSpace"` // FreeSpace - свободные места с учетом процента использования.
+	Fill      float64 `json:"fill"`      // Fill - доля заполнения вместимости с учетом процента использования.
+	Level     string  `json:"level"`     // Level - уровень заполненности Occupancy*.
+}
+
+type StorageAreaOccupancyList struct {
+	StorageAreas []*StorageAreaOccupancy `json:"storageAreas"`
+}
+
+// NewStorageAreaOccupancy заполненность участка по кол-ву размещенных на нем п\п.
+func NewStorageAreaOccupancy(area *StorageArea, pallets int) *StorageAreaOccupancy {
+	occupancy := &StorageAreaOccupancy{StorageArea: area, Pallets: pallets, Level: OccupancyUnknown}
+	if area.UsableCapacity <= 0 {
+		return occupancy
+	}
+
+	occupancy.Fill = float64(pallets) / area.UsableCapacity
+	occupancy.FreeSpace = math.Max(area.UsableCapacity-float64(pallets), 0)
+
+	switch {
+	case occupancy.Fill >= OccupancyHighFrom:
+		occupancy.Level = OccupancyHigh
+	case occupancy.Fill >= OccupancyMediumFrom:
+		occupancy.Level = OccupancyMedium
+	default:
+		occupancy.Level = OccupancyLow
+	}
+
+	return occupancy
+}
+
+// Percent процент заполнения для страницы.
+func (o *StorageAreaOccupancy) Percent() int {
+	return int(math.Round(o.Fill * 100))
+}
+
+// HasSpace можно ли разместить на участке еще один п\п: площадка не закрыта и есть свободное место. Участок без
+// вместимости не ограничивается.
+func (o *StorageAreaOccupancy) HasSpace() bool {
+	if o.Closed {
+		return false
+	}
+
+	return o.UsableCapacity <= 0 || o.FreeSpace >= 1
+}
+
+// ContainsCatalogId есть ли запись с ид в списке справочника.
+func ContainsCatalogId(catalogs []*Catalog, id int) bool {
+	for _, catalog := range catalogs {
+		if catalog.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	declNumberMaxLen = 50 // declNumberMaxLen - размер поля DeclNumber.
+	declDateLayout   = "2006-01-02"
+
+	DeclExpiringDefaultDays = 30 // DeclExpiringDefaultDays - горизонт отчета по умолчанию.
+
+	declTypePrefix = "декл" // declTypePrefix - PrType декларируемой продукции: "декларированная", "декларируемая".
+)
+
+// Состояние действующей декларации продукции в отчете.
+const (
+	DeclStatusMissing  = "missing"  // DeclStatusMissing - декларации нет.
+	DeclStatusExpired  = "expired"  // DeclStatusExpired - декларация истекла.
+	DeclStatusExpiring = "expiring" // DeclStatusExpiring - декларация истекает в течение горизонта отчета.
+)
+
+// Declaration декларация о соответствии продукции (svTB_ProductionDecl).
+type Declaration struct {
+	Id             int    `json:"id"`             // Id - ид декларации, 0 - декларации нет.
+	ProductId      int    `json:"productId"`      // ProductId - ид продукции.
+	ProductArticle string `json:"productArticle"` // ProductArticle - артикул продукции.
+	ProductName    string `json:"productName"`    // ProductName - наименование продукции.
+	Number         string `json:"number"`         // Number - регистрационный номер декларации.
+	ValidFrom      string `json:"validFrom"`      // ValidFrom - дата начала действия (ГГГГ-ММ-ДД).
+	ValidTo        string `json:"validTo"`        // ValidTo - дата окончания действия (ГГГГ-ММ-ДД).
+	DaysLeft       int    `json:"daysLeft"`       // DaysLeft - дней до окончания действия.
+	Status         string `json:"status"`         // Status - состояние в отчете.
+	AuditRec       Audit  `json:"auditRec"`
+}
+
+type DeclarationList struct {
+	Declarations []*Declaration `json:"declarations"`
+}
+
+// LabelPrintCheck результат проверки возможности печати этикетки продукции.
+type LabelPrintCheck struct {
+	ProductId int    `json:"productId"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type DeclarationUpdate struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// SetStatus состояние декларации в отчете по сроку действия.
+func (d *Declaration) SetStatus() {
+	switch {
+	case d.Id == 0:
+		d.Status = DeclStatusMissing
+	case d.DaysLeft < 0:
+		d.Status = DeclStatusExpired
+	default:
+		d.Status = DeclStatusExpiring
+	}
+}
+
+// ValidOn действует ли декларация на дату.
+func (d *Declaration) ValidOn(date time.Time) bool {
+	from, err := time.Parse(declDateLayout, d.ValidFrom)
+	if err != nil {
+		return false
+	}
+
+	to, err := time.Parse(declDateLayout, d.ValidTo)
+	if err != nil {
+		return false
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	return !day.Before(from) && !day.After(to)
+}
+
+func ValidateDeclaration(data *Declaration) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось добавить декларацию, данных нет")
+	}
+
+	data.Number = strings.TrimSpace(data.Number)
+	if data.Number == "" || utf8.RuneCountInString(data.Number) > declNumberMaxLen {
+		return fmt.Errorf("ошибка: номер декларации должен быть от 1 до %d символов", declNumberMaxLen)
+	}
+
+	from, err := time.Parse(declDateLayout, data.ValidFrom)
+	if err != nil {
+		return fmt.Errorf("ошибка: невалидная дата начала действия %q", data.ValidFrom)
+	}
+
+	to, err := time.Parse(declDateLayout, data.ValidTo)
+	if err != nil {
+		return fmt.Errorf("ошибка: невалидная дата окончания действия %q", data.ValidTo)
+	}
+
+	if to.Before(from) {
+		return fmt.Errorf("ошибка: дата окончания действия раньше даты начала")
+	}
+
+	return nil
+}
+
+// Declared декларируемая продукция: PrDecl или PrType "декларированная".
+func (p *Product) Declared() bool {
+	return p.Decl || strings.HasPrefix(strings.ToLower(strings.TrimSpace(p.Type)), declTypePrefix)
+}
+
+// ActiveDeclaration декларация продукции на дату: из начавших действовать - с последней датой окончания, nil - нет ни
+// одной. Декларация с будущей датой начала не учитывается. То же правило в svTB_ProductionDeclExpiring.
+func ActiveDeclaration(declarations []*Declaration, date time.Time) *Declaration {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	var active *Declaration
+	for _, declaration := range declarations {
+		from, err := time.Parse(declDateLayout, declaration.ValidFrom)
+		if err != nil || from.After(day) {
+			continue
+		}
+
+		if active == nil || declaration.ValidTo > active.ValidTo {
+			active = declaration
+		}
+	}
+
+	return active
+}
+
+// CheckLabelPrint можно ли печатать этикетку продукции на дату: архивная продукция не печатается, декларируемая -
+// только если декларация на дату (ActiveDeclaration) не истекла.
+func CheckLabelPrint(product *Product, declarations []*Declaration, date time.Time) *LabelPrintCheck {
+	check := &LabelPrintCheck{ProductId: product.Id}
+
+	if product.Archive {
+		check.Reason = "продукция в архиве"
+
+		return check
+	}
+
+	if product.Declared() {
+		if active := ActiveDeclaration(declarations, date); active == nil || !active.ValidOn(date) {
+			check.Reason = "нет действующей декларации о соответствии"
+
+			return check
+		}
+	}
+
+	check.Allowed = true
+
+	return check
+}
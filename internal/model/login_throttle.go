@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	LoginLockPerformer = "performer" // LoginLockPerformer - блокировка по табельному номеру.
+	LoginLockAddr      = "addr"      // LoginLockAddr - блокировка по адресу клиента.
+)
+
+// LoginThrottle результат проверки, можно ли сейчас пробовать войти.
+type LoginThrottle struct {
+	Allowed    bool          `json:"allowed"`
+	Locked     bool          `json:"locked"`     // Locked - учетная запись или адрес заблокированы.
+	RetryAfter time.Duration `json:"retryAfter"` // RetryAfter - через сколько можно повторить попытку.
+	Message    string        `json:"message"`
+}
+
+// LoginUnlock результат снятия блокировки входа.
+type LoginUnlock struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// LoginLockout заблокированный вход.
+type LoginLockout struct {
+	Kind        string    `json:"kind"`        // Kind - performer или addr.
+	Value       string    `json:"value"`       // Value - табельный номер или адрес клиента.
+	Failures    int       `json:"failures"`    // Failures - неудачных попыток подряд.
+	LockedUntil time.Time `json:"lockedUntil"` // LockedUntil - время окончания блокировки.
+}
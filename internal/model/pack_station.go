@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	packStationNameMaxLen = 100 // packStationNameMaxLen - размер поля StName.
+	packBarcodeMaxLen     = 20  // packBarcodeMaxLen - размер поля PfBarcode.
+)
+
+type PackStationList struct {
+	PackStations []*PackStation `json:"packStations"`
+}
+
+// PackStation станция упаковки (svTB_PackStation).
+type PackStation struct {
+	Id           int    `json:"id"`           // Id - ид станции.
+	Name         string `json:"name"`         // Name - наименование станции.
+	PackAreaId   int    `json:"packAreaId"`   // PackAreaId - участок упаковки, svCatalogs kodcat=9.
+	PackAreaName string `json:"packAreaName"` // PackAreaName - наименование участка упаковки.
+	Repack       bool   `json:"repack"`       // Repack - участок переупаковки (dop_bit_1).
+	PrinterId    int    `json:"printerId"`    // PrinterId - принтер, svCatalogs kodcat=4.
+	PrinterName  string `json:"printerName"`  // PrinterName - наименование принтера.
+	SectorId     int    `json:"sectorId"`     // SectorId - ид печки.
+	SectorName   string `json:"sectorName"`   // SectorName - наименование печки.
+	Archive      bool   `json:"archive"`      // Archive - архивная станция.
+	AuditRec     Audit  `json:"auditRec"`
+}
+
+// PackStationSession открытый сеанс на станции упаковки. Отметка, которой штампуются п\п, напечатанные в сеансе.
+type PackStationSession struct {
+	Id              int    `json:"id"`              // Id - ид сеанса.
+	StationId       int    `json:"stationId"`       // StationId - ид станции.
+	StationName     string `json:"stationName"`     // StationName - наименование станции.
+	SectorId        int    `json:"sectorId"`        // SectorId - ид печки.
+	SectorName      string `json:"sectorName"`      // SectorName - наименование печки.
+	PrinterName     string `json:"printerName"`     // PrinterName - принтер станции.
+	PerformerId     int    `json:"performerId"`     // PerformerId - ид сотрудника AForms (idPerformer).
+	PerformerTabnum int    `json:"performerTabnum"` // PerformerTabnum - табельный номер сотрудника.
+	PerformerName   string `json:"performerName"`   // PerformerName - ФИО сотрудника.
+	OpenedAt        string `json:"openedAt"`        // OpenedAt - дата открытия сеанса.
+}
+
+// PackStationBadge сканирование бейджа на станции упаковки.
+type PackStationBadge struct {
+	StationId int    `json:"stationId"` // StationId - ид станции.
+	Barcode   string `json:"barcode"`   // Barcode - штрих-код бейджа.
+}
+
+type PackStationUpdate struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func ValidatePackStation(data *PackStation) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось сохранить станцию, данных нет")
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" || utf8.RuneCountInString(data.Name) > packStationNameMaxLen {
+		return fmt.Errorf("ошибка: наименование станции должно быть от 1 до %d символов", packStationNameMaxLen)
+	}
+
+	if data.PackAreaId <= 0 || data.PrinterId <= 0 || data.SectorId <= 0 {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	return nil
+}
+
+func ValidatePackStationBadge(data *PackStationBadge) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось открыть сеанс, данных нет")
+	}
+
+	data.Barcode = strings.TrimSpace(data.Barcode)
+	if data.StationId <= 0 || data.Barcode == "" || len(data.Barcode) > packBarcodeMaxLen {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import "fmt"
+
+// Pallet напечатанный п\п (svTB_Pallet) с отметкой станции, печки и сотрудника сеанса, в котором он напечатан.
+type Pallet struct {
+	Id              int    `json:"id"`              // Id - ид п\п.
+	SessionId       int    `json:"sessionId"`       // SessionId - сеанс станции, в котором напечатан п\п.
+	StationId       int    `json:"stationId"`       // StationId - ид станции.
+	StationName     string `json:"stationName"`     // StationName - наименование станции.
+	SectorId        int    `json:"sectorId"`        // SectorId - ид печки.
+	SectorName      string `json:"sectorName"`      // SectorName - наименование печки.
+	PerformerId     int    `json:"performerId"`     // PerformerId - ид сотрудника сеанса.
+	PerformerTabnum int    `json:"performerTabnum"` // PerformerTabnum - табельный номер сотрудника.
+	PerformerName   string `json:"performerName"`   // PerformerName - ФИО сотрудника.
+	ProductId       int    `json:"productId"`       // ProductId - ид продукции.
+	ProductArticle  string `json:"productArticle"`  // ProductArticle - артикул продукции.
+	ProductName     string `json:"productName"`     // ProductName - наименование продукции.
+	Qty             int    `json:"qty"`             // Qty - штук на п\п.
+	PrintedAt       string `json:"printedAt"`       // PrintedAt - дата печати.
+	ShiftTaskId     int    `json:"shiftTaskId"`     // ShiftTaskId - задание, в факт которого засчитан п\п, 0 - без задания.
+}
+
+// PalletPrint запрос печати п\п на станции упаковки.
+type PalletPrint struct {
+	StationId   int `json:"stationId"`   // StationId - ид станции.
+	ProductId   int `json:"productId"`   // ProductId - ид продукции.
+	ShiftTaskId int `json:"shiftTaskId"` // ShiftTaskId - сменно-суточное задание печи сеанса, 0 - печать без задания.
+}
+
+type PalletUpdate struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func ValidatePalletPrint(data *PalletPrint) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось напечатать п\\п, данных нет")
+	}
+
+	if data.StationId <= 0 || data.ProductId <= 0 || data.ShiftTaskId < 0 {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	return nil
+}
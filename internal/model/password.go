@@ -0,0 +1,19 @@
+package model
+
+// PasswordChangeRequest смена пароля с ТСД по табельному номеру и текущему, в т.ч. временному, паролю и коду 2FA.
+type PasswordChangeRequest struct {
+	Id          int    `json:"id"`           // Id - табельный номер.
+	Password    string `json:"password"`     // Password - текущий пароль.
+	NewPassword string `json:"new_password"` // NewPassword - новый пароль.
+	Otp         string `json:"otp"`          // Otp - код 2FA, если она подключена.
+}
+
+// PasswordReset итог сброса пароля администратором. Временный пароль показывается один раз и должен быть сменен
+// сотрудником при следующем входе.
+type PasswordReset struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message"`
+	PerformerId  int    `json:"performerId"`  // PerformerId - табельный номер сотрудника.
+	TempPassword string `json:"tempPassword"` // TempPassword - временный пароль.
+	Revoked      int    `json:"revoked"`      // Revoked - отозвано сессий сотрудника.
+}
@@ -2,6 +2,8 @@ package model
 
 import "fmt"
 
+const PerformerBCMaxLen = 13 // PerformerBCMaxLen - размер поля bc.
+
 type PerformerList struct {
 	Performers []*Performer
 	Roles      []*Role
@@ -10,14 +12,26 @@ type PerformerList struct {
 type Performer struct {
 	Id           int    `json:"id"`           // Id - табельный номер.
 	FIO          string `json:"fio"`          // FIO - ФИО сотрудника.
-	BC           string `json:"bc"`           // BC - код доступа сотрудника.
-	Pass         string `json:"password"`     // Pass - пароль сотрудника.
+	BC           string `json:"-"`            // BC - код доступа сотрудника, в JSON не выводится.
+	Pass         string `json:"-"`            // Pass - хеш пароля сотрудника, в JSON не выводится.
 	Archive      bool   `json:"archive"`      // Archive - флаг архивного сотрудника.
 	IdRoleAForms int    `json:"idRoleAForms"` // IdRoleAForms - id роли.
 	IdRoleAFGW   int    `json:"idRoleAFGW"`   // IdRoleAFGW - id роли.
 	AuditRec     Audit  `json:"auditRec"`     // AuditRec - аудит для отслеживания изменений данных.
 }
 
+// PerformerPass сотрудник с незахешированным паролем для перевода на хеш.
+type PerformerPass struct {
+	Id   int    // Id - табельный номер.
+	Pass string // Pass - пароль в открытом виде.
+}
+
+// PerformerHashReport итог перевода паролей на хеш.
+type PerformerHashReport struct {
+	Hashed int      // Hashed - захешировано паролей.
+	Errors []string // Errors - ошибки по сотрудникам.
+}
+
 type AuthPerformer struct {
 	Success   bool      `json:"success"`
 	Performer Performer `json:"performer"`
@@ -0,0 +1,57 @@
+package model
+
+const (
+	AppAForms = "aforms" // AppAForms - AForms и панель администратора, роль сотрудника IdRoleAForms.
+	AppFGW    = "fgw"    // AppFGW - складское приложение FGW, роль сотрудника IdRoleAFGW.
+)
+
+const (
+	PermAdminAccess      = "admin.access"              // PermAdminAccess - вход в панель администратора.
+	PermPerformersEdit   = "performers.edit"           // PermPerformersEdit - сотрудники: изменение ролей, импорт.
+	PermPasswordReset    = "performers.password_reset" // PermPasswordReset - сотрудники: сброс пароля.
+	PermRolesEdit        = "roles.edit"                // PermRolesEdit - роли и права ролей.
+	PermSectorsEdit      = "sectors.edit"              // PermSectorsEdit - печи: закрепление сотрудников.
+	PermProductsEdit     = "products.edit"             // PermProductsEdit - продукция: архив, история, справочники.
+	PermDeclarationsEdit = "declarations.edit"         // PermDeclarationsEdit - декларации о соответствии.
+	PermPackStationsEdit = "pack_stations.edit"        // PermPackStationsEdit - станции упаковки.
+	PermSessionsManage   = "sessions.manage"           // PermSessionsManage - активные сессии и блокировки входа.
+	PermShiftTasksEdit   = "shift_tasks.edit"          // PermShiftTasksEdit - сменно-суточные задания: добавление.
+	PermApiTokensManage  = "api_tokens.manage"         // PermApiTokensManage - API-токены: выдача и отзыв.
+
+	PermPackStationsOperate = "pack_stations.operate" // PermPackStationsOperate - работа на станции упаковки: сеанс, печать.
+	PermPalletsMove         = "pallets.move"          // PermPalletsMove - п\п: отгрузка и размещение на участке.
+)
+
+// ScopePermission право роли сотрудника в приложении, которое нужно для области доступа JSON API.
+type ScopePermission struct {
+	App  string // App - приложение, по роли в котором проверяется право.
+	Code string // Code - код права.
+}
+
+// ScopePermissions права для областей доступа на запись. Запросы по сессии и токену доступа проходят, только если
+// у роли сотрудника есть право; области на чтение открыты любому вошедшему сотруднику.
+var ScopePermissions = map[string]ScopePermission{
+	ScopePerformersWrite:   {App: AppAForms, Code: PermPerformersEdit},
+	ScopeRolesWrite:        {App: AppAForms, Code: PermRolesEdit},
+	ScopeProductsWrite:     {App: AppAForms, Code: PermProductsEdit},
+	ScopePackStationsWrite: {App: AppFGW, Code: PermPackStationsOperate},
+	ScopePalletsWrite:      {App: AppFGW, Code: PermPalletsMove},
+}
+
+// Permission право доступа.
+type Permission struct {
+	Code string `json:"code"`
+	Desc string `json:"desc"`
+}
+
+// RolePermission право, выданное роли.
+type RolePermission struct {
+	RoleId int    `json:"roleId"`
+	Code   string `json:"code"`
+}
+
+// RolePermissionsUpdate права роли для замены.
+type RolePermissionsUpdate struct {
+	RoleId int      `json:"roleId"`
+	Codes  []string `json:"codes"`
+}
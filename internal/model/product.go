@@ -0,0 +1,141 @@
+package model
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	productArticleMaxLen = 5  // productArticleMaxLen - размер поля PrArticle.
+	productBarCodeMaxLen = 13 // productBarCodeMaxLen - размер поля PrBarCode.
+)
+
+// Результат svTB_ProductionArchiveById.
+const (
+	ArchiveDone             = 0 // ArchiveDone - признак архива изменен.
+	ArchiveBlockedShiftTask = 1 // ArchiveBlockedShiftTask - есть невыполненные сменно-суточные задания.
+	ArchiveBlockedStock     = 2 // ArchiveBlockedStock - есть остаток на складе: напечатанные и не отгруженные п\п.
+)
+
+type ProductList struct {
+	Products []*Product `json:"products"`
+}
+
+// Product продукция (svTB_Production).
+type Product struct {
+	Id           int     `json:"id"`           // Id - ид продукции.
+	Name         string  `json:"name"`         // Name - наименование варианта упаковки продукции для упаковщика.
+	ShortName    string  `json:"shortName"`    // ShortName - короткое наименование продукции для этикетки.
+	PackName     string  `json:"packName"`     // PackName - вариант упаковки.
+	Type         string  `json:"type"`         // Type - декларированная или нет.
+	Article      string  `json:"article"`      // Article - артикул варианта упаковки.
+	Color        string  `json:"color"`        // Color - цвет продукции.
+	BarCode      string  `json:"barCode"`      // BarCode - бар-код.
+	Count        int     `json:"count"`        // Count - количество продукции в ряду.
+	Rows         int     `json:"rows"`         // Rows - количество рядов.
+	Weight       float64 `json:"weight"`       // Weight - вес п\п (кг).
+	HWD          string  `json:"hwd"`          // HWD - габариты (мм).
+	Info         string  `json:"info"`         // Info - информация о продукции\комментарий.
+	Status       bool    `json:"status"`       // Status - статус продукции.
+	EditDate     string  `json:"editDate"`     // EditDate - дата и время изменения записи.
+	EditUser     int     `json:"editUser"`     // EditUser - роль сотрудника.
+	Part         int     `json:"part"`         // Part - номер текущей партии.
+	PartLastDate string  `json:"partLastDate"` // PartLastDate - дата выпуска партии.
+	PartAutoInc  int     `json:"partAutoInc"`  // PartAutoInc - нумерация партии и даты.
+	Archive      bool    `json:"archive"`      // Archive - архивная запись или нет.
+	PerGodn      int     `json:"perGodn"`      // PerGodn - срок годности в месяцах.
+	SAP          string  `json:"sap"`          // SAP - сап-код.
+	ProdType     bool    `json:"prodType"`     // ProdType - тип продукции пищевая\не пищевая.
+	Umbrella     bool    `json:"umbrella"`     // Umbrella - беречь от влаги.
+	Sun          bool    `json:"sun"`          // Sun - беречь от солнца.
+	Decl         bool    `json:"decl"`         // Decl - декларирования или нет.
+	Party        bool    `json:"party"`        // Party - партионная или нет.
+	GL           int     `json:"gl"`           // GL - петля Мёбиуса.
+	VP           int     `json:"vp"`           // VP - ванная печь.
+	ML           int     `json:"ml"`           // ML - машинная линия на печи.
+	ColorId      int     `json:"colorId"`      // ColorId - ид записи справочника цветов, 0 - не привязан.
+	DesignId     int     `json:"designId"`     // DesignId - ид записи справочника конструкторских наименований, 0 - не привязан.
+	AuditRec     Audit   `json:"auditRec"`     // AuditRec - аудит для отслеживания изменений данных.
+}
+
+// ProductArchiveList продукция админки: действующая и архивная отдельно.
+type ProductArchiveList struct {
+	Active   []*Product `json:"active"`
+	Archived []*Product `json:"archived"`
+}
+
+type ProductUpdate struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ProductLineIssue продукция, у которой машинная линия не принадлежит печи.
+type ProductLineIssue struct {
+	ProductId int    `json:"productId"` // ProductId - ид продукции.
+	Article   string `json:"article"`   // Article - артикул.
+	Name      string `json:"name"`      // Name - наименование продукции.
+	VP        int    `json:"vp"`        // VP - ванная печь.
+	ML        int    `json:"ml"`        // ML - машинная линия.
+	Reason    string `json:"reason"`    // Reason - причина несоответствия.
+}
+
+type ProductLineIssueList struct {
+	Issues []*ProductLineIssue `json:"issues"`
+}
+
+func ValidateUpdateDataProduct(data *Product) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось обновить данные, данных нет")
+	}
+
+	if data.Name == "" || data.Article == "" {
+		return fmt.Errorf("ошибка: не валидное поле")
+	}
+
+	if utf8.RuneCountInString(data.Article) > productArticleMaxLen || len(data.BarCode) > productBarCodeMaxLen {
+		return fmt.Errorf("ошибка: превышена длина поля")
+	}
+
+	if data.AuditRec.UpdatedBy <= 0 {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	if data.Count < 0 || data.Rows < 0 || data.Weight < 0 || data.PerGodn < 0 || data.VP < 0 || data.ML < 0 {
+		return fmt.Errorf("ошибка: число не может быть отрицательным")
+	}
+
+	return nil
+}
+
+// CheckProductLine проверяет, что машинная линия продукции принадлежит её печи.
+// furnaceLines - линии печей из svTB_Sector по номеру печи. Продукция без печи или линии не проверяется.
+func CheckProductLine(product *Product, furnaceLines map[int][]int) *ProductLineIssue {
+	if product == nil || product.VP == 0 || product.ML == 0 {
+		return nil
+	}
+
+	issue := &ProductLineIssue{
+		ProductId: product.Id,
+		Article:   product.Article,
+		Name:      product.Name,
+		VP:        product.VP,
+		ML:        product.ML,
+	}
+
+	lines, ok := furnaceLines[product.VP]
+	if !ok {
+		issue.Reason = fmt.Sprintf("печь %d не найдена среди печек", product.VP)
+
+		return issue
+	}
+
+	for _, line := range lines {
+		if line == product.ML {
+			return nil
+		}
+	}
+
+	issue.Reason = fmt.Sprintf("линия %d не принадлежит печи %d", product.ML, product.VP)
+
+	return issue
+}
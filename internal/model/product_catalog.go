@@ -0,0 +1,44 @@
+package model
+
+// ProductFilter отбор продукции по записям справочников, 0 - без отбора.
+type ProductFilter struct {
+	ColorId  int `json:"colorId"`  // ColorId - ид записи справочника цветов.
+	DesignId int `json:"designId"` // DesignId - ид записи справочника конструкторских наименований.
+}
+
+// Match подходит ли продукция под отбор.
+func (f ProductFilter) Match(product *Product) bool {
+	if f.ColorId != 0 && product.ColorId != f.ColorId {
+		return false
+	}
+
+	if f.DesignId != 0 && product.DesignId != f.DesignId {
+		return false
+	}
+
+	return true
+}
+
+// ProductCatalogs справочники для привязки и отбора продукции.
+type ProductCatalogs struct {
+	Colors  []*Catalog `json:"colors"`  // Colors - цвета (kodcat = 3).
+	Designs []*Catalog `json:"designs"` // Designs - конструкторские наименования (kodcat = 0).
+}
+
+// ProductCatalogUnmatched текстовое значение продукции, для которого нет записи в справочнике.
+type ProductCatalogUnmatched struct {
+	Field    string `json:"field"`    // Field - color (PrColor) или design (PrShortName).
+	Value    string `json:"value"`    // Value - текстовое значение.
+	Products int    `json:"products"` // Products - кол-во продукции с этим значением.
+}
+
+type ProductCatalogUnmatchedList struct {
+	Unmatched []*ProductCatalogUnmatched `json:"unmatched"`
+}
+
+// ProductCatalogMapReport итог сопоставления текстовых значений продукции со справочниками.
+type ProductCatalogMapReport struct {
+	Colors    int                        `json:"colors"`    // Colors - продукция, привязанная к цвету.
+	Designs   int                        `json:"designs"`   // Designs - продукция, привязанная к конструкторскому наименованию.
+	Unmatched []*ProductCatalogUnmatched `json:"unmatched"` // Unmatched - несопоставленные значения.
+}
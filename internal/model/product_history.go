@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// productDiffSkip поля продукции, которые не сравниваются между версиями: ид и служебные поля изменения.
+var productDiffSkip = map[string]bool{
+	"Id":       true,
+	"EditDate": true,
+	"EditUser": true,
+	"AuditRec": true,
+}
+
+// ProductVersion версия продукции (svTB_ProductionHistory).
+type ProductVersion struct {
+	ProductId int                 `json:"productId"`         // ProductId - ид продукции.
+	Version   int                 `json:"version"`           // Version - номер версии.
+	Snapshot  *Product            `json:"snapshot"`          // Snapshot - снимок продукции.
+	CreatedAt string              `json:"createdAt"`         // CreatedAt - дата создания версии.
+	CreatedBy int                 `json:"createdBy"`         // CreatedBy - табельный номер сотрудника.
+	Changes   []*ProductFieldDiff `json:"changes,omitempty"` // Changes - изменения относительно предыдущей версии.
+}
+
+type ProductVersionList struct {
+	Versions []*ProductVersion `json:"versions"`
+}
+
+// ProductFieldDiff изменение поля продукции между версиями.
+type ProductFieldDiff struct {
+	Field string      `json:"field"` // Field - поле продукции (имя в JSON).
+	Old   interface{} `json:"old"`   // Old - значение в ранней версии.
+	New   interface{} `json:"new"`   // New - значение в поздней версии.
+}
+
+type ProductDiff struct {
+	ProductId int                 `json:"productId"`
+	From      int                 `json:"from"`
+	To        int                 `json:"to"`
+	Changes   []*ProductFieldDiff `json:"changes"`
+}
+
+// DiffProducts изменения полей продукции между версиями from и to.
+func DiffProducts(from, to *Product) []*ProductFieldDiff {
+	changes := make([]*ProductFieldDiff, 0)
+	if from == nil || to == nil {
+		return changes
+	}
+
+	oldValue := reflect.ValueOf(*from)
+	newValue := reflect.ValueOf(*to)
+	productType := oldValue.Type()
+
+	for i := 0; i < productType.NumField(); i++ {
+		field := productType.Field(i)
+		if productDiffSkip[field.Name] {
+			continue
+		}
+
+		oldField := oldValue.Field(i).Interface()
+		newField := newValue.Field(i).Interface()
+		if reflect.DeepEqual(oldField, newField) {
+			continue
+		}
+
+		changes = append(changes, &ProductFieldDiff{
+			Field: jsonFieldName(field),
+			Old:   oldField,
+			New:   newField,
+		})
+	}
+
+	return changes
+}
+
+// FindProductVersion найти версию по номеру.
+func FindProductVersion(versions []*ProductVersion, version int) (*ProductVersion, error) {
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ошибка: версия %d не найдена", version)
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	SapExportDateLayout = "2006-01-02"
+	sapFileDateLayout   = "20060102"
+	sapSenderPort       = "FGW_WEB"
+)
+
+// SapKind вид выгрузки в SAP: имя файла, тип IDoc и сегмент строки.
+type SapKind struct {
+	Name      string // Name - вид в имени файла.
+	DocType   string // DocType - тип IDoc.
+	DocPrefix string // DocPrefix - префикс номера документа IDoc.
+	Segment   string // Segment - сегмент строки IDoc.
+}
+
+// Виды выгрузки в SAP.
+var (
+	SapKindReceipts  = SapKind{Name: "receipts", DocType: "ZFGW_RECEIPTS", DocPrefix: "FGW", Segment: "E1FGWRCPT"}   // SapKindReceipts - приход: п\п, напечатанные за день.
+	SapKindShipments = SapKind{Name: "shipments", DocType: "ZFGW_SHIPMENTS", DocPrefix: "FGS", Segment: "E1FGWSHIP"} // SapKindShipments - отгрузка: п\п, отгруженные за день.
+	SapKindStock     = SapKind{Name: "stock", DocType: "ZFGW_STOCK", DocPrefix: "FGB", Segment: "E1FGWSTCK"}         // SapKindStock - остаток: п\п на складе на конец дня.
+)
+
+// SapRow строка выгрузки в SAP за день по коду SAP: приход, отгрузка или остаток.
+type SapRow struct {
+	Date    string // Date - дата (ГГГГ-ММ-ДД).
+	SapCode string // SapCode - код материала в SAP (PrSAP).
+	Article string // Article - артикул продукции.
+	Name    string // Name - наименование продукции.
+	Pallets int    // Pallets - кол-во п\п.
+	Qty     int    // Qty - кол-во, шт.
+}
+
+// SapExportReport итог выгрузки за день.
+type SapExportReport struct {
+	Date  string         // Date - дата выгрузки.
+	Rows  map[string]int // Rows - кол-во строк по виду выгрузки.
+	Files []string       // Files - записанные файлы.
+}
+
+// SapIDoc выгрузка за день в структуре IDoc, корневой элемент - тип IDoc вида выгрузки.
+type SapIDoc struct {
+	XMLName xml.Name
+	IDoc    SapIDocBody `xml:"IDOC"`
+}
+
+type SapIDocBody struct {
+	Begin    string           `xml:"BEGIN,attr"`
+	Control  SapControlRecord `xml:"EDI_DC40"`
+	Segments []SapItem        `xml:",any"`
+}
+
+// SapControlRecord управляющая запись IDoc.
+type SapControlRecord struct {
+	Segment string `xml:"SEGMENT,attr"`
+	DocNum  string `xml:"DOCNUM"` // DocNum - номер документа, одинаковый при повторной выгрузке дня.
+	IDocTyp string `xml:"IDOCTYP"`
+	SndPor  string `xml:"SNDPOR"`
+	CreDat  string `xml:"CREDAT"` // CreDat - дата выгрузки (ГГГГММДД).
+}
+
+// SapItem строка IDoc, элемент - сегмент вида выгрузки.
+type SapItem struct {
+	XMLName xml.Name
+	Segment string `xml:"SEGMENT,attr"`
+	Matnr   string `xml:"MATNR"` // Matnr - код материала (PrSAP).
+	Budat   string `xml:"BUDAT"` // Budat - дата проводки (ГГГГММДД).
+	Menge   int    `xml:"MENGE"` // Menge - кол-во, шт.
+	Meins   string `xml:"MEINS"` // Meins - единица измерения.
+	Paletts int    `xml:"ANZPAL"`
+	Maktx   string `xml:"MAKTX"` // Maktx - наименование.
+}
+
+// SapFileName имя файла выгрузки за дату: одно и то же для повторной выгрузки, файл перезаписывается.
+func SapFileName(kind string, date time.Time, format string) string {
+	return fmt.Sprintf("FGW_%s_%s.%s", strings.ToUpper(kind), date.Format(sapFileDateLayout), format)
+}
+
+// ParseSapExportRange разбор периода выгрузки, пустые даты - вчерашний день.
+func ParseSapExportRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	yesterday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	start, end := yesterday, yesterday
+	var err error
+
+	if from != "" {
+		if start, err = time.Parse(SapExportDateLayout, from); err != nil {
+			return start, end, fmt.Errorf("ошибка: невалидная дата начала периода %q", from)
+		}
+		end = start
+	}
+
+	if to != "" {
+		if end, err = time.Parse(SapExportDateLayout, to); err != nil {
+			return start, end, fmt.Errorf("ошибка: невалидная дата окончания периода %q", to)
+		}
+	}
+
+	if end.Before(start) {
+		return start, end, fmt.Errorf("ошибка: дата окончания периода раньше даты начала")
+	}
+
+	return start, end, nil
+}
+
+// NewSapIDoc выгрузка вида kind за дату в структуре IDoc.
+func NewSapIDoc(kind SapKind, date time.Time, rows []*SapRow) *SapIDoc {
+	day := date.Format(sapFileDateLayout)
+
+	doc := &SapIDoc{XMLName: xml.Name{Local: kind.DocType}, IDoc: SapIDocBody{
+		Begin: "1",
+		Control: SapControlRecord{
+			Segment: "1",
+			DocNum:  kind.DocPrefix + day,
+			IDocTyp: kind.DocType,
+			SndPor:  sapSenderPort,
+			CreDat:  day,
+		},
+	}}
+
+	for _, row := range rows {
+		doc.IDoc.Segments = append(doc.IDoc.Segments, SapItem{
+			XMLName: xml.Name{Local: kind.Segment},
+			Segment: "1",
+			Matnr:   row.SapCode,
+			Budat:   day,
+			Menge:   row.Qty,
+			Meins:   "ST",
+			Paletts: row.Pallets,
+			Maktx:   row.Name,
+		})
+	}
+
+	return doc
+}
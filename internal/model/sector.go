@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sectorFurnaceRe номер ванной печи в наименовании печки, например "ВП №3 (Лин:31,32)".
+var sectorFurnaceRe = regexp.MustCompile(`ВП\s*№\s*(\d+)`)
+
+type SectorList struct {
+	Sectors []*Sector `json:"sectors"`
+}
+
+// Sector печь (svTB_Sector).
+type Sector struct {
+	Id         int    `json:"id"`         // Id - ид печки.
+	Name       string `json:"name"`       // Name - наименование печки.
+	Lines      string `json:"lines"`      // Lines - список линий печки (SecVPML).
+	TicketSize string `json:"ticketSize"` // TicketSize - размер этикетки.
+	EditDate   string `json:"editDate"`   // EditDate - дата редактирования печки.
+	EditUser   int    `json:"editUser"`   // EditUser - право на редактирование печки.
+}
+
+// FurnaceNumber номер ванной печи из наименования печки.
+func (s *Sector) FurnaceNumber() (int, bool) {
+	match := sectorFurnaceRe.FindStringSubmatch(s.Name)
+	if match == nil {
+		return 0, false
+	}
+
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// LineNumbers номера машинных линий печки из SecVPML.
+func (s *Sector) LineNumbers() []int {
+	var lines []int
+	for _, field := range strings.Split(s.Lines, ",") {
+		line, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || line == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// FurnaceLines линии печей по номеру ванной печи.
+func FurnaceLines(sectors []*Sector) map[int][]int {
+	furnaceLines := make(map[int][]int)
+	for _, sector := range sectors {
+		number, ok := sector.FurnaceNumber()
+		if !ok {
+			continue
+		}
+		furnaceLines[number] = append(furnaceLines[number], sector.LineNumbers()...)
+	}
+
+	return furnaceLines
+}
+
+// SectorAssign назначение сотрудников AForms на печь.
+type SectorAssign struct {
+	PerformerIds []int `json:"performerIds"` // PerformerIds - ид сотрудников AForms (idPerformer).
+	SectorId     int   `json:"sectorId"`     // SectorId - ид печки.
+}
+
+func ValidateSectorAssign(data *SectorAssign) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось назначить печь, данных нет")
+	}
+
+	if len(data.PerformerIds) == 0 {
+		return fmt.Errorf("ошибка: не выбраны сотрудники")
+	}
+
+	if data.SectorId <= 0 {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	for _, id := range data.PerformerIds {
+		if id <= 0 {
+			return fmt.Errorf("ошибка: невалидное поле")
+		}
+	}
+
+	return nil
+}
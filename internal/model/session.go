@@ -0,0 +1,42 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// SessionRecord сессия сотрудника в реестре сессий. Токен сессии в реестре не хранится, только его хеш (Id).
+type SessionRecord struct {
+	Id           string    `json:"id"`           // Id - SHA-256 токена сессии (hex).
+	PerformerId  int       `json:"performerId"`  // PerformerId - табельный номер.
+	RoleId       int       `json:"roleId"`       // RoleId - роль сессии.
+	DeviceType   string    `json:"deviceType"`   // DeviceType - тип устройства входа по бейджу, пусто - браузер.
+	RemoteAddr   string    `json:"remoteAddr"`   // RemoteAddr - адрес клиента при входе.
+	UserAgent    string    `json:"userAgent"`    // UserAgent - браузер клиента при входе.
+	CreatedAt    time.Time `json:"createdAt"`    // CreatedAt - время входа.
+	ExpiresAt    time.Time `json:"expiresAt"`    // ExpiresAt - время окончания действия.
+	LastActivity time.Time `json:"lastActivity"` // LastActivity - время последнего запроса.
+}
+
+// SessionTokenId ид сессии в реестре по токену сессии.
+func SessionTokenId(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// SessionView действующая сессия для администратора.
+type SessionView struct {
+	SessionRecord
+	PerformerFIO string `json:"performerFio"` // PerformerFIO - ФИО сотрудника.
+	RoleName     string `json:"roleName"`     // RoleName - наименование роли.
+	Current      bool   `json:"current"`      // Current - сессия текущего администратора.
+}
+
+// SessionRevoke результат завершения сессий.
+type SessionRevoke struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Revoked int    `json:"revoked"` // Revoked - кол-во завершенных сессий.
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ShiftTaskMinShift   = 1 // ShiftTaskMinShift - первая смена.
+	ShiftTaskMaxShift   = 2 // ShiftTaskMaxShift - вторая смена (12-часовой график).
+	shiftTaskDateLayout = "2006-01-02"
+)
+
+type ShiftTaskList struct {
+	ShiftTasks []*ShiftTask `json:"shiftTasks"`
+}
+
+// ShiftTask сменно-суточное задание на машинную линию печи (svTB_ShiftTask).
+type ShiftTask struct {
+	Id             int    `json:"id"`             // Id - ид задания.
+	TaskDate       string `json:"taskDate"`       // TaskDate - дата смены (ГГГГ-ММ-ДД).
+	ShiftNum       int    `json:"shiftNum"`       // ShiftNum - номер смены.
+	SectorId       int    `json:"sectorId"`       // SectorId - ид печки.
+	SectorName     string `json:"sectorName"`     // SectorName - наименование печки.
+	LineNum        int    `json:"lineNum"`        // LineNum - машинная линия на печи.
+	ProductId      int    `json:"productId"`      // ProductId - ид продукции.
+	ProductArticle string `json:"productArticle"` // ProductArticle - артикул продукции.
+	ProductName    string `json:"productName"`    // ProductName - наименование продукции.
+	PlanQty        int    `json:"planQty"`        // PlanQty - план, кол-во п\п.
+	FactQty        int    `json:"factQty"`        // FactQty - факт, кол-во напечатанных п\п.
+	AuditRec       Audit  `json:"auditRec"`
+}
+
+// Percent процент выполнения задания.
+func (s *ShiftTask) Percent() int {
+	if s.PlanQty <= 0 {
+		return 0
+	}
+
+	return s.FactQty * 100 / s.PlanQty
+}
+
+type ShiftTaskUpdate struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func ValidateShiftTask(data *ShiftTask) error {
+	if data == nil {
+		return fmt.Errorf("ошибка: не удалось добавить задание, данных нет")
+	}
+
+	if _, err := time.Parse(shiftTaskDateLayout, data.TaskDate); err != nil {
+		return fmt.Errorf("ошибка: невалидная дата смены %q", data.TaskDate)
+	}
+
+	if data.ShiftNum < ShiftTaskMinShift || data.ShiftNum > ShiftTaskMaxShift {
+		return fmt.Errorf("ошибка: невалидный номер смены %d", data.ShiftNum)
+	}
+
+	if data.SectorId <= 0 || data.LineNum <= 0 || data.ProductId <= 0 {
+		return fmt.Errorf("ошибка: невалидное поле")
+	}
+
+	if data.PlanQty <= 0 {
+		return fmt.Errorf("ошибка: план должен быть больше нуля")
+	}
+
+	return nil
+}
+
+// ParseShiftTaskDate разбирает дату смены, пустая строка - текущий день.
+func ParseShiftTaskDate(value string) (string, error) {
+	if value == "" {
+		return time.Now().Format(shiftTaskDateLayout), nil
+	}
+
+	date, err := time.Parse(shiftTaskDateLayout, value)
+	if err != nil {
+		return "", fmt.Errorf("ошибка: невалидная дата смены %q", value)
+	}
+
+	return date.Format(shiftTaskDateLayout), nil
+}
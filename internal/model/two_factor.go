@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	TwoFactorNone   = iota // TwoFactorNone - 2FA не подключена и не обязательна, достаточно пароля.
+	TwoFactorVerify        // TwoFactorVerify - 2FA подключена, после пароля нужен код.
+	TwoFactorEnroll        // TwoFactorEnroll - 2FA обязательна для роли, но не подключена: доступно только подключение.
+)
+
+// PerformerTOTP секрет TOTP сотрудника.
+type PerformerTOTP struct {
+	PerformerId int        // PerformerId - табельный номер сотрудника.
+	Secret      string     // Secret - секрет TOTP, зашифрованный ключом TOTP_KEY.
+	ConfirmedAt *time.Time // ConfirmedAt - дата подтверждения кодом, nil - подключение не завершено.
+	LastStep    int64      // LastStep - интервал последнего принятого кода.
+}
+
+// TwoFactorStatus состояние 2FA сотрудника.
+type TwoFactorStatus struct {
+	Enabled      bool `json:"enabled"`      // Enabled - 2FA подключена.
+	Required     bool `json:"required"`     // Required - 2FA обязательна для роли сотрудника.
+	RecoveryLeft int  `json:"recoveryLeft"` // RecoveryLeft - осталось неиспользованных кодов восстановления.
+}
+
+// TOTPEnrollment данные для подключения приложения-аутентификатора.
+type TOTPEnrollment struct {
+	Secret string // Secret - секрет в base32 для ручного ввода.
+	URI    string // URI - otpauth://, закодированный в QR-коде.
+	QRCode string // QRCode - QR-код PNG в виде data URI.
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type AFormsPerformerRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewAFormsPerformerRepo(mssql *sql.DB, logger *common.Logger) *AFormsPerformerRepo {
+	return &AFormsPerformerRepo{mssql: mssql, logg: logger}
+}
+
+type AFormsPerformerRepository interface {
+	All(ctx context.Context) ([]*model.AFormsPerformer, error)
+	UpsertByTabnum(ctx context.Context, performer *model.AFormsPerformer) (int, error)
+	UpdSectorByIds(ctx context.Context, ids []int, sectorId int) error
+}
+
+// All получить всех сотрудников AForms из БД.
+func (a *AFormsPerformerRepo) All(ctx context.Context) ([]*model.AFormsPerformer, error) {
+	rows, err := a.mssql.QueryContext(ctx, FGWsvTBPerformerAllQuery)
+	if err != nil {
+		a.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var performers []*model.AFormsPerformer
+	for rows.Next() {
+		var performer model.AFormsPerformer
+		if err = rows.Scan(
+			&performer.Id,
+			&performer.SectorId,
+			&performer.SectorName,
+			&performer.Folder,
+			&performer.Name,
+			&performer.Barcode,
+			&performer.EditDate,
+			&performer.EditUser,
+			&performer.Tabnum,
+		); err != nil {
+			a.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		performers = append(performers, &performer)
+	}
+
+	if err = rows.Err(); err != nil {
+		a.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return performers, nil
+}
+
+// UpsertByTabnum добавить или обновить сотрудника AForms по табельному номеру, пароль не изменяется.
+func (a *AFormsPerformerRepo) UpsertByTabnum(ctx context.Context, performer *model.AFormsPerformer) (int, error) {
+	var result int
+
+	err := a.mssql.QueryRowContext(ctx, FGWsvTBPerformerUpsertByTabnumQuery,
+		performer.Tabnum,
+		performer.Name,
+		performer.Barcode,
+		performer.EditUser,
+	).Scan(&result)
+	if err != nil {
+		a.logg.LogE(msg.E3215, err)
+
+		return model.UpsertSkipped, err
+	}
+
+	return result, nil
+}
+
+// UpdSectorByIds привязать сотрудников AForms к печке в одной транзакции: при ошибке не привязывается никто.
+func (a *AFormsPerformerRepo) UpdSectorByIds(ctx context.Context, ids []int, sectorId int) error {
+	tx, err := a.mssql.BeginTx(ctx, nil)
+	if err != nil {
+		a.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+	defer rollbackTx(tx, a.logg)
+
+	for _, id := range ids {
+		if _, err = tx.ExecContext(ctx, FGWsvTBUpdPerformerBySectorQuery, id, sectorId); err != nil {
+			a.logg.LogE(msg.E3216, err)
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		a.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type ApiTokenRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewApiTokenRepo(mssql *sql.DB, logger *common.Logger) *ApiTokenRepo {
+	return &ApiTokenRepo{mssql: mssql, logg: logger}
+}
+
+type ApiTokenRepository interface {
+	Add(ctx context.Context, token *model.ApiToken) (int, error)
+	FindByHash(ctx context.Context, tokenHash string) (*model.ApiToken, error)
+	All(ctx context.Context) ([]*model.ApiToken, error)
+	Revoke(ctx context.Context, id int) error
+	Touch(ctx context.Context, id int, now time.Time) error
+}
+
+// Add выдать токен, возвращает его ид.
+func (a *ApiTokenRepo) Add(ctx context.Context, token *model.ApiToken) (int, error) {
+	var id int
+
+	if err := a.mssql.QueryRowContext(ctx, FGWsvTBApiTokenAddQuery,
+		token.Name,
+		token.PerformerId,
+		strings.Join(token.Scopes, ","),
+		token.TokenHash,
+		token.CreatedBy,
+		token.ExpiresAt,
+	).Scan(&id); err != nil {
+		a.logg.LogE(msg.E3215, err)
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// FindByHash получить действующий токен по хешу с текущими ролями сотрудника, nil - токен не выдавался, отозван,
+// истек или сотрудник в архиве.
+func (a *ApiTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*model.ApiToken, error) {
+	var roleId, roleFGWId int
+
+	token, err := scanApiToken(a.mssql.QueryRowContext(ctx, FGWsvTBApiTokenByHashQuery, tokenHash), &roleId, &roleFGWId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		a.logg.LogE(msg.E3204, err)
+
+		return nil, err
+	}
+	token.RoleId, token.RoleFGWId = roleId, roleFGWId
+
+	return token, nil
+}
+
+// All получить список токенов, действующие первыми.
+func (a *ApiTokenRepo) All(ctx context.Context) ([]*model.ApiToken, error) {
+	rows, err := a.mssql.QueryContext(ctx, FGWsvTBApiTokenAllQuery)
+	if err != nil {
+		a.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var tokens []*model.ApiToken
+	for rows.Next() {
+		token, err := scanApiToken(rows)
+		if err != nil {
+			a.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		a.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Revoke отозвать токен.
+func (a *ApiTokenRepo) Revoke(ctx context.Context, id int) error {
+	if _, err := a.mssql.ExecContext(ctx, FGWsvTBApiTokenRevokeQuery, id); err != nil {
+		a.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Touch отметить время последнего запроса по токену.
+func (a *ApiTokenRepo) Touch(ctx context.Context, id int, now time.Time) error {
+	if _, err := a.mssql.ExecContext(ctx, FGWsvTBApiTokenTouchQuery, id, now); err != nil {
+		a.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// scanApiToken прочитать токен из строки результата ХП, extra - следующие за токеном столбцы.
+func scanApiToken(row interface{ Scan(dest ...any) error }, extra ...any) (*model.ApiToken, error) {
+	var token model.ApiToken
+	var scopes string
+
+	dest := []any{
+		&token.Id,
+		&token.Name,
+		&token.PerformerId,
+		&scopes,
+		&token.CreatedAt,
+		&token.CreatedBy,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		token.Scopes = strings.Split(scopes, ",")
+	}
+
+	return &token, nil
+}
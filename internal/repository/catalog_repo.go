@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type CatalogRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewCatalogRepo(mssql *sql.DB, logger *common.Logger) *CatalogRepo {
+	return &CatalogRepo{mssql: mssql, logg: logger}
+}
+
+type CatalogRepository interface {
+	AllByKodcat(ctx context.Context, kodcat int) ([]*model.Catalog, error)
+}
+
+// AllByKodcat получить записи справочника по коду справочника.
+func (c *CatalogRepo) AllByKodcat(ctx context.Context, kodcat int) ([]*model.Catalog, error) {
+	rows, err := c.mssql.QueryContext(ctx, FGWsvCatalogsByKodcatQuery, kodcat)
+	if err != nil {
+		c.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var catalogs []*model.Catalog
+	for rows.Next() {
+		var catalog model.Catalog
+		if err = rows.Scan(
+			&catalog.Id,
+			&catalog.ParId,
+			&catalog.Kodcat,
+			&catalog.Kod,
+			&catalog.Name,
+			&catalog.Comm,
+			&catalog.DopInt1,
+			&catalog.DopInt2,
+			&catalog.DopFloat1,
+			&catalog.DopFloat2,
+			&catalog.DopBit1,
+			&catalog.DopBit2,
+			&catalog.Archive,
+		); err != nil {
+			c.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		catalogs = append(catalogs, &catalog)
+	}
+
+	if err = rows.Err(); err != nil {
+		c.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return catalogs, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type DeclarationRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewDeclarationRepo(mssql *sql.DB, logger *common.Logger) *DeclarationRepo {
+	return &DeclarationRepo{mssql: mssql, logg: logger}
+}
+
+type DeclarationRepository interface {
+	Add(ctx context.Context, declaration *model.Declaration) error
+	AllByProduct(ctx context.Context, productId int) ([]*model.Declaration, error)
+	Expiring(ctx context.Context, days int) ([]*model.Declaration, error)
+}
+
+// Add прикрепить декларацию к продукции.
+func (d *DeclarationRepo) Add(ctx context.Context, declaration *model.Declaration) error {
+	if _, err := d.mssql.ExecContext(ctx, FGWsvTBProductionDeclAddQuery,
+		declaration.ProductId,
+		declaration.Number,
+		declaration.ValidFrom,
+		declaration.ValidTo,
+		declaration.AuditRec.CreatedBy,
+	); err != nil {
+		d.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// AllByProduct получить декларации продукции, действующая первой.
+func (d *DeclarationRepo) AllByProduct(ctx context.Context, productId int) ([]*model.Declaration, error) {
+	return d.query(ctx, FGWsvTBProductionDeclByProductionQuery, productId)
+}
+
+// Expiring получить декларированную продукцию, у которой декларация истекает в течение days дней, истекла или
+// отсутствует.
+func (d *DeclarationRepo) Expiring(ctx context.Context, days int) ([]*model.Declaration, error) {
+	return d.query(ctx, FGWsvTBProductionDeclExpiringQuery, days)
+}
+
+func (d *DeclarationRepo) query(ctx context.Context, query string, args ...interface{}) ([]*model.Declaration, error) {
+	rows, err := d.mssql.QueryContext(ctx, query, args...)
+	if err != nil {
+		d.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var declarations []*model.Declaration
+	for rows.Next() {
+		var declaration model.Declaration
+		if err = rows.Scan(
+			&declaration.Id,
+			&declaration.ProductId,
+			&declaration.ProductArticle,
+			&declaration.ProductName,
+			&declaration.Number,
+			&declaration.ValidFrom,
+			&declaration.ValidTo,
+			&declaration.DaysLeft,
+			&declaration.AuditRec.CreatedAt,
+			&declaration.AuditRec.CreatedBy,
+			&declaration.AuditRec.UpdatedAt,
+			&declaration.AuditRec.UpdatedBy,
+		); err != nil {
+			d.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		declarations = append(declarations, &declaration)
+	}
+
+	if err = rows.Err(); err != nil {
+		d.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return declarations, nil
+}
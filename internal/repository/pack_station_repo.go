@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type PackStationRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewPackStationRepo(mssql *sql.DB, logger *common.Logger) *PackStationRepo {
+	return &PackStationRepo{mssql: mssql, logg: logger}
+}
+
+type PackStationRepository interface {
+	All(ctx context.Context) ([]*model.PackStation, error)
+	Add(ctx context.Context, station *model.PackStation) error
+	UpdById(ctx context.Context, id int, station *model.PackStation) error
+	ExistById(ctx context.Context, id int) (bool, error)
+	ActiveSession(ctx context.Context, stationId int) (*model.PackStationSession, error)
+	OpenSession(ctx context.Context, stationId int, barcode string) (*model.PackStationSession, error)
+	CloseSession(ctx context.Context, stationId int) error
+}
+
+// All получить все станции упаковки из БД.
+func (p *PackStationRepo) All(ctx context.Context) ([]*model.PackStation, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvTBPackStationAllQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var stations []*model.PackStation
+	for rows.Next() {
+		var station model.PackStation
+		if err = rows.Scan(
+			&station.Id,
+			&station.Name,
+			&station.PackAreaId,
+			&station.PackAreaName,
+			&station.Repack,
+			&station.PrinterId,
+			&station.PrinterName,
+			&station.SectorId,
+			&station.SectorName,
+			&station.Archive,
+			&station.AuditRec.CreatedAt,
+			&station.AuditRec.CreatedBy,
+			&station.AuditRec.UpdatedAt,
+			&station.AuditRec.UpdatedBy,
+		); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		stations = append(stations, &station)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return stations, nil
+}
+
+// Add добавить станцию упаковки.
+func (p *PackStationRepo) Add(ctx context.Context, station *model.PackStation) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvTBPackStationAddQuery,
+		station.Name,
+		station.PackAreaId,
+		station.PrinterId,
+		station.SectorId,
+		station.AuditRec.CreatedBy,
+	); err != nil {
+		p.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// UpdById обновить станцию упаковки по ИД.
+func (p *PackStationRepo) UpdById(ctx context.Context, id int, station *model.PackStation) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvTBPackStationUpdByIdQuery,
+		id,
+		station.Name,
+		station.PackAreaId,
+		station.PrinterId,
+		station.SectorId,
+		station.Archive,
+		station.AuditRec.UpdatedBy,
+	); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ExistById проверяет существование станции упаковки.
+func (p *PackStationRepo) ExistById(ctx context.Context, id int) (bool, error) {
+	var exists bool
+
+	err := p.mssql.QueryRowContext(ctx, FGWsvTBPackStationExistsByIdQuery, id).Scan(&exists)
+	if err != nil {
+		p.logg.LogE(msg.E3206, err)
+
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ActiveSession получить открытый сеанс станции, nil - сеанс не открыт.
+func (p *PackStationRepo) ActiveSession(ctx context.Context, stationId int) (*model.PackStationSession, error) {
+	return p.scanSession(p.mssql.QueryRowContext(ctx, FGWsvTBPackStationSessionActiveQuery, stationId))
+}
+
+// OpenSession открыть сеанс станции по штрих-коду бейджа, nil - бейдж или станция не найдены.
+func (p *PackStationRepo) OpenSession(ctx context.Context, stationId int, barcode string) (*model.PackStationSession, error) {
+	return p.scanSession(p.mssql.QueryRowContext(ctx, FGWsvTBPackStationSessionOpenQuery, stationId, barcode))
+}
+
+// CloseSession закрыть открытый сеанс станции.
+func (p *PackStationRepo) CloseSession(ctx context.Context, stationId int) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvTBPackStationSessionCloseQuery, stationId); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *PackStationRepo) scanSession(row *sql.Row) (*model.PackStationSession, error) {
+	var session model.PackStationSession
+
+	if err := row.Scan(
+		&session.Id,
+		&session.StationId,
+		&session.StationName,
+		&session.SectorId,
+		&session.SectorName,
+		&session.PrinterName,
+		&session.PerformerId,
+		&session.PerformerTabnum,
+		&session.PerformerName,
+		&session.OpenedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		p.logg.LogE(msg.E3204, err)
+
+		return nil, err
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type PalletRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewPalletRepo(mssql *sql.DB, logger *common.Logger) *PalletRepo {
+	return &PalletRepo{mssql: mssql, logg: logger}
+}
+
+type PalletRepository interface {
+	Print(ctx context.Context, print *model.PalletPrint, performerId int) (*model.Pallet, error)
+	Ship(ctx context.Context, id, performerId int) (bool, error)
+	Place(ctx context.Context, id, storageAreaId int) (bool, error)
+	CountByStorageArea(ctx context.Context) (map[int]int, error)
+}
+
+// Print зарегистрировать печать п\п в открытом сеансе станции и засчитать его в факт задания в той же транзакции,
+// nil - сеанс не открыт, продукция не найдена или задание не подходит.
+func (p *PalletRepo) Print(ctx context.Context, print *model.PalletPrint, performerId int) (*model.Pallet, error) {
+	return p.scanPallet(p.mssql.QueryRowContext(ctx, FGWsvTBPalletPrintQuery,
+		print.StationId, print.ProductId, print.ShiftTaskId, performerId))
+}
+
+// Ship отгрузить п\п со склада, false - п\п не найден или уже отгружен.
+func (p *PalletRepo) Ship(ctx context.Context, id, performerId int) (bool, error) {
+	var result int
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvTBPalletShipQuery, id, performerId).Scan(&result); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return false, err
+	}
+
+	return result > 0, nil
+}
+
+// Place разместить п\п на участке хранения, false - п\п не найден или уже отгружен.
+func (p *PalletRepo) Place(ctx context.Context, id, storageAreaId int) (bool, error) {
+	var result int
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvTBPalletPlaceQuery, id, storageAreaId).Scan(&result); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return false, err
+	}
+
+	return result > 0, nil
+}
+
+// CountByStorageArea кол-во п\п на складе по ид участка хранения.
+func (p *PalletRepo) CountByStorageArea(ctx context.Context) (map[int]int, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvTBPalletCountByStorageAreaQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var storageAreaId, pallets int
+		if err = rows.Scan(&storageAreaId, &pallets); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		counts[storageAreaId] = pallets
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (p *PalletRepo) scanPallet(row *sql.Row) (*model.Pallet, error) {
+	var pallet model.Pallet
+
+	if err := row.Scan(
+		&pallet.Id,
+		&pallet.SessionId,
+		&pallet.StationId,
+		&pallet.StationName,
+		&pallet.SectorId,
+		&pallet.SectorName,
+		&pallet.PerformerId,
+		&pallet.PerformerTabnum,
+		&pallet.PerformerName,
+		&pallet.ProductId,
+		&pallet.ProductArticle,
+		&pallet.ProductName,
+		&pallet.Qty,
+		&pallet.PrintedAt,
+		&pallet.ShiftTaskId,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		p.logg.LogE(msg.E3204, err)
+
+		return nil, err
+	}
+
+	return &pallet, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type PasswordRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewPasswordRepo(mssql *sql.DB, logger *common.Logger) *PasswordRepo {
+	return &PasswordRepo{mssql: mssql, logg: logger}
+}
+
+type PasswordRepository interface {
+	SetPassById(ctx context.Context, id int, passHash string, mustChange bool, updatedBy int) error
+	PassHistoryById(ctx context.Context, id, limit int) ([]string, error)
+	PassMustChangeById(ctx context.Context, id int) (bool, error)
+}
+
+// SetPassById сохранить новый хеш пароля сотрудника, прежний хеш сохраняется в истории паролей. mustChange = true -
+// пароль временный и должен быть сменен при следующем входе.
+func (p *PasswordRepo) SetPassById(ctx context.Context, id int, passHash string, mustChange bool, updatedBy int) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvPerformerSetPassByIdQuery, id, passHash, mustChange, updatedBy); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// PassHistoryById получить хеши последних limit прежних паролей сотрудника, новые первыми.
+func (p *PasswordRepo) PassHistoryById(ctx context.Context, id, limit int) ([]string, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvPerformerPassHistoryByIdQuery, id, limit)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err = rows.Scan(&hash); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// PassMustChangeById пароль сотрудника временный и должен быть сменен, sql.ErrNoRows - сотрудника нет.
+func (p *PasswordRepo) PassMustChangeById(ctx context.Context, id int) (bool, error) {
+	var mustChange bool
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvPerformerPassMustChangeByIdQuery, id).Scan(&mustChange); err != nil {
+		p.logg.LogE(msg.E3204, err)
+
+		return false, err
+	}
+
+	return mustChange, nil
+}
@@ -22,8 +22,11 @@ func NewPerformerRepo(mssql *sql.DB, logger *common.Logger) *PerformerRepo {
 
 type PerformerRepository interface {
 	All(ctx context.Context) ([]*model.Performer, error)
-	AuthByIdAndPass(ctx context.Context, id int, password string) (bool, error)
+	FindPassById(ctx context.Context, id int) (string, error)
+	UpdPassById(ctx context.Context, id int, passHash string) error
+	AllLegacyPass(ctx context.Context) ([]*model.PerformerPass, error)
 	FindById(ctx context.Context, id int) (*model.Performer, error)
+	FindByBC(ctx context.Context, bc string) (*model.Performer, error)
 	UpdById(ctx context.Context, id int, performer *model.Performer) error
 	ExistById(ctx context.Context, id int) (bool, error)
 	GetPerformersCount(ctx context.Context) (int, error)
@@ -74,18 +77,59 @@ func (p *PerformerRepo) All(ctx context.Context) ([]*model.Performer, error) {
 	return performers, nil
 }
 
-// AuthByIdAndPass проверка существования в БД сотрудника.
-func (p *PerformerRepo) AuthByIdAndPass(ctx context.Context, id int, password string) (bool, error) {
-	var authSuccess bool
+// FindPassById получить хеш пароля не архивного сотрудника, sql.ErrNoRows - сотрудника нет.
+func (p *PerformerRepo) FindPassById(ctx context.Context, id int) (string, error) {
+	var pass string
 
-	err := p.mssql.QueryRowContext(ctx, FGWsvPerformerAuthQuery, id, password).Scan(&authSuccess)
+	if err := p.mssql.QueryRowContext(ctx, FGWsvPerformerPassByIdQuery, id).Scan(&pass); err != nil {
+		p.logg.LogE(msg.E3204, err)
+
+		return "", err
+	}
+
+	return pass, nil
+}
+
+// UpdPassById сохранить хеш пароля сотрудника.
+func (p *PerformerRepo) UpdPassById(ctx context.Context, id int, passHash string) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvPerformerUpdPassByIdQuery, id, passHash); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// AllLegacyPass получить сотрудников, у которых пароль хранится в открытом виде.
+func (p *PerformerRepo) AllLegacyPass(ctx context.Context) ([]*model.PerformerPass, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvPerformerPassLegacyQuery)
 	if err != nil {
 		p.logg.LogE(msg.E3202, err)
 
-		return false, err
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var passes []*model.PerformerPass
+	for rows.Next() {
+		var pass model.PerformerPass
+		if err = rows.Scan(&pass.Id, &pass.Pass); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		passes = append(passes, &pass)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
 	}
 
-	return authSuccess, nil
+	return passes, nil
 }
 
 // FindById ищет сотрудника по ИД.
@@ -108,7 +152,35 @@ func (p *PerformerRepo) FindById(ctx context.Context, id int) (*model.Performer,
 		p.logg.LogE(msg.E3204, err)
 
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("%s: %v", msg.E3206, err)
+			return nil, fmt.Errorf("%s: %w", msg.E3206, err)
+		}
+		return nil, err
+	}
+
+	return &performer, nil
+}
+
+// FindByBC ищет сотрудника по коду доступа (штрих-код бейджа).
+func (p *PerformerRepo) FindByBC(ctx context.Context, bc string) (*model.Performer, error) {
+	var performer model.Performer
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvPerformerFindByBCQuery, bc).Scan(
+		&performer.Id,
+		&performer.FIO,
+		&performer.BC,
+		&performer.Pass,
+		&performer.Archive,
+		&performer.IdRoleAForms,
+		&performer.IdRoleAFGW,
+		&performer.AuditRec.CreatedAt,
+		&performer.AuditRec.CreatedBy,
+		&performer.AuditRec.UpdatedAt,
+		&performer.AuditRec.UpdatedBy,
+	); err != nil {
+		p.logg.LogE(msg.E3204, err)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", msg.E3206, err)
 		}
 		return nil, err
 	}
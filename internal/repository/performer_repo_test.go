@@ -173,85 +173,6 @@ func TestPerformerRepo_All(t *testing.T) {
 	})
 }
 
-func TestPerformerRepo_AuthByIdAndPass(t *testing.T) {
-	mssqlDB, mock, repo := createMock(t)
-	defer db.Close(mssqlDB)
-
-	t.Run("Успех - аутентификация завершена", func(t *testing.T) {
-		//expectedQuery := FGWsvPerformerAuthQuery
-		expectedRows := sqlmock.NewRows([]string{"auth_success"}).AddRow(true)
-
-		mock.ExpectQuery(`.*svPerformerAuth.*`).
-			//WithArgs(12345, "12345").
-			WillReturnRows(expectedRows)
-
-		result, err := repo.AuthByIdAndPass(context.Background(), 12345, "12345")
-
-		require.NoError(t, err)
-		assert.True(t, result)
-
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Неудача - неверный пароль", func(t *testing.T) {
-		//expectedQuery := FGWsvPerformerAuthQuery
-		expectedRows := sqlmock.NewRows([]string{"auth_success"}).AddRow(false)
-
-		mock.ExpectQuery(`.*svPerformerAuth.*`).WillReturnRows(expectedRows)
-
-		result, err := repo.AuthByIdAndPass(context.Background(), 12345, "invalid_password")
-
-		require.NoError(t, err)
-		assert.False(t, result)
-
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Ошибка - запись не найдена", func(t *testing.T) {
-		//expectedQuery := FGWsvPerformerAuthQuery
-
-		mock.ExpectQuery(`.*svPerformerAuth.*`).WillReturnError(sql.ErrNoRows)
-
-		result, err := repo.AuthByIdAndPass(context.Background(), 12345, "password")
-
-		assert.Error(t, err)
-		assert.False(t, result)
-		assert.Contains(t, err.Error(), msg.E3202)
-
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Ошибка - не удалось подключиться к БД", func(t *testing.T) {
-		//expectedQuery := FGWsvPerformerAuthQuery
-		expectedError := errors.New("database connection failed")
-
-		mock.ExpectQuery(`.*svPerformerAuth.*`).WillReturnError(expectedError)
-
-		result, err := repo.AuthByIdAndPass(context.Background(), 12345, "12345")
-
-		assert.Error(t, err)
-		assert.False(t, result)
-		assert.Contains(t, err.Error(), msg.E3202)
-
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-
-	t.Run("Ошибка - неверный тип данных в результате", func(t *testing.T) {
-		//expectedQuery := FGWsvPerformerAuthQuery
-
-		rows := sqlmock.NewRows([]string{"auth_success"}).AddRow("not_a_boolean")
-		mock.ExpectQuery(`.*svPerformerAuth.*`).WillReturnRows(rows)
-
-		result, err := repo.AuthByIdAndPass(context.Background(), 12345, "12345")
-
-		assert.Error(t, err)
-		assert.False(t, result)
-		assert.Contains(t, err.Error(), msg.E3202)
-
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
-}
-
 func TestPerformerRepo_FindById(t *testing.T) {
 	mssqlDB, mock, repo := createMock(t)
 	defer db.Close(mssqlDB)
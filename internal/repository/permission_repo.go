@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"strings"
+)
+
+type PermissionRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewPermissionRepo(mssql *sql.DB, logger *common.Logger) *PermissionRepo {
+	return &PermissionRepo{mssql: mssql, logg: logger}
+}
+
+type PermissionRepository interface {
+	All(ctx context.Context) ([]*model.Permission, error)
+	AllRolePermissions(ctx context.Context) ([]*model.RolePermission, error)
+	CodesByRole(ctx context.Context, roleId int) ([]string, error)
+	SetByRole(ctx context.Context, roleId int, codes []string, performerId int) error
+	Version(ctx context.Context) (int64, error)
+}
+
+// All получить список прав.
+func (p *PermissionRepo) All(ctx context.Context) ([]*model.Permission, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvPermissionAllQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var permissions []*model.Permission
+	for rows.Next() {
+		var permission model.Permission
+		if err = rows.Scan(&permission.Code, &permission.Desc); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		permissions = append(permissions, &permission)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// AllRolePermissions получить права всех ролей.
+func (p *PermissionRepo) AllRolePermissions(ctx context.Context) ([]*model.RolePermission, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvRolePermissionsAllQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var rolePermissions []*model.RolePermission
+	for rows.Next() {
+		var rolePermission model.RolePermission
+		if err = rows.Scan(&rolePermission.RoleId, &rolePermission.Code); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		rolePermissions = append(rolePermissions, &rolePermission)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+// CodesByRole получить коды прав роли.
+func (p *PermissionRepo) CodesByRole(ctx context.Context, roleId int) ([]string, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvRolePermissionsByRoleQuery, roleId)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err = rows.Scan(&code); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// SetByRole заменить права роли, версия прав увеличивается в той же транзакции.
+func (p *PermissionRepo) SetByRole(ctx context.Context, roleId int, codes []string, performerId int) error {
+	if _, err := p.mssql.ExecContext(ctx, FGWsvRolePermissionsSetQuery, roleId, strings.Join(codes, ","), performerId); err != nil {
+		p.logg.LogE(msg.E3217, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Version получить версию прав ролей, общую для всех экземпляров приложения.
+func (p *PermissionRepo) Version(ctx context.Context) (int64, error) {
+	var version int64
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvPermissionsVersionGetQuery).Scan(&version); err != nil {
+		p.logg.LogE(msg.E3204, err)
+
+		return 0, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+type ProductHistoryRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewProductHistoryRepo(mssql *sql.DB, logger *common.Logger) *ProductHistoryRepo {
+	return &ProductHistoryRepo{mssql: mssql, logg: logger}
+}
+
+type ProductHistoryRepository interface {
+	AllByProduct(ctx context.Context, productId int) ([]*model.ProductVersion, error)
+}
+
+// addProductVersion сохранить снимок продукции следующей версией, вызывается в транзакции изменения продукции
+// (ProductRepo.withVersion).
+func addProductVersion(ctx context.Context, executor dbExecutor, product *model.Product, performerId int) error {
+	snapshot, err := json.Marshal(product)
+	if err != nil {
+		return err
+	}
+
+	_, err = executor.ExecContext(ctx, FGWsvTBProductionHistoryAddQuery, product.Id, string(snapshot), performerId)
+
+	return err
+}
+
+// hasProductVersions есть ли у продукции сохраненные версии.
+func hasProductVersions(ctx context.Context, executor dbExecutor, productId int) (bool, error) {
+	rows, err := executor.QueryContext(ctx, FGWsvTBProductionHistoryByProductionQuery, productId)
+	if err != nil {
+		return false, err
+	}
+	defer db.RowsClose(rows)
+
+	return rows.Next(), rows.Err()
+}
+
+// AllByProduct получить версии продукции по порядку.
+func (p *ProductHistoryRepo) AllByProduct(ctx context.Context, productId int) ([]*model.ProductVersion, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvTBProductionHistoryByProductionQuery, productId)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var versions []*model.ProductVersion
+	for rows.Next() {
+		var version model.ProductVersion
+		var snapshot string
+		if err = rows.Scan(
+			&version.ProductId,
+			&version.Version,
+			&snapshot,
+			&version.CreatedAt,
+			&version.CreatedBy,
+		); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		if err = json.Unmarshal([]byte(snapshot), &version.Snapshot); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		versions = append(versions, &version)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return versions, nil
+}
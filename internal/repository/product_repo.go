@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type ProductRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewProductRepo(mssql *sql.DB, logger *common.Logger) *ProductRepo {
+	return &ProductRepo{mssql: mssql, logg: logger}
+}
+
+type ProductRepository interface {
+	All(ctx context.Context) ([]*model.Product, error)
+	FindById(ctx context.Context, id int) (*model.Product, error)
+	UpdById(ctx context.Context, id int, product *model.Product, performerId int) error
+	ExistById(ctx context.Context, id int) (bool, error)
+	ArchiveById(ctx context.Context, id int, archive bool, performerId int) (int, error)
+	MapCatalogs(ctx context.Context) (*model.ProductCatalogMapReport, error)
+	CatalogUnmatched(ctx context.Context) ([]*model.ProductCatalogUnmatched, error)
+}
+
+// dbExecutor запросы к БД вне транзакции (*sql.DB) или в транзакции (*sql.Tx).
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// productScanDest поля продукции в порядке столбцов ХП svTB_Production*.
+func productScanDest(product *model.Product) []interface{} {
+	return []interface{}{
+		&product.Id,
+		&product.Name,
+		&product.ShortName,
+		&product.PackName,
+		&product.Type,
+		&product.Article,
+		&product.Color,
+		&product.BarCode,
+		&product.Count,
+		&product.Rows,
+		&product.Weight,
+		&product.HWD,
+		&product.Info,
+		&product.Status,
+		&product.EditDate,
+		&product.EditUser,
+		&product.Part,
+		&product.PartLastDate,
+		&product.PartAutoInc,
+		&product.Archive,
+		&product.PerGodn,
+		&product.SAP,
+		&product.ProdType,
+		&product.Umbrella,
+		&product.Sun,
+		&product.Decl,
+		&product.Party,
+		&product.GL,
+		&product.VP,
+		&product.ML,
+		&product.ColorId,
+		&product.DesignId,
+		&product.AuditRec.CreatedAt,
+		&product.AuditRec.CreatedBy,
+		&product.AuditRec.UpdatedAt,
+		&product.AuditRec.UpdatedBy,
+	}
+}
+
+// All получить всю продукцию из БД.
+func (p *ProductRepo) All(ctx context.Context) ([]*model.Product, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvTBProductionAllQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var products []*model.Product
+	for rows.Next() {
+		var product model.Product
+		if err = rows.Scan(productScanDest(&product)...); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		products = append(products, &product)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// FindById ищет продукцию по ИД.
+func (p *ProductRepo) FindById(ctx context.Context, id int) (*model.Product, error) {
+	return p.findById(ctx, p.mssql, id)
+}
+
+func (p *ProductRepo) findById(ctx context.Context, executor dbExecutor, id int) (*model.Product, error) {
+	var product model.Product
+
+	if err := executor.QueryRowContext(ctx, FGWsvTBProductionFindByIdQuery, id).Scan(productScanDest(&product)...); err != nil {
+		p.logg.LogE(msg.E3204, err)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %v", msg.E3206, err)
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// UpdById обновить продукцию по ИД в БД, изменение сохраняется версией в истории продукции в той же транзакции.
+// Автор версии - performerId.
+func (p *ProductRepo) UpdById(ctx context.Context, id int, product *model.Product, performerId int) error {
+	return p.withVersion(ctx, id, performerId, func(tx *sql.Tx) (bool, error) {
+		product.AuditRec.UpdatedBy = performerId
+
+		return true, p.updById(ctx, tx, id, product)
+	})
+}
+
+func (p *ProductRepo) updById(ctx context.Context, executor dbExecutor, id int, product *model.Product) error {
+	_, err := executor.ExecContext(ctx, FGWsvTBProductionUpdByIdQuery,
+		id,
+		product.Name,
+		product.ShortName,
+		product.PackName,
+		product.Type,
+		product.Article,
+		product.Color,
+		product.BarCode,
+		product.Count,
+		product.Rows,
+		product.Weight,
+		product.HWD,
+		product.Info,
+		product.Status,
+		product.PerGodn,
+		product.SAP,
+		product.ProdType,
+		product.Umbrella,
+		product.Sun,
+		product.Decl,
+		product.Party,
+		product.GL,
+		product.VP,
+		product.ML,
+		product.ColorId,
+		product.DesignId,
+		product.AuditRec.UpdatedBy,
+	)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ExistById проверяет существование продукции.
+func (p *ProductRepo) ExistById(ctx context.Context, id int) (bool, error) {
+	var exists bool
+
+	err := p.mssql.QueryRowContext(ctx, FGWsvTBProductionExistsByIdQuery, id).Scan(&exists)
+	if err != nil {
+		p.logg.LogE(msg.E3206, err)
+
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ArchiveById архивировать или вернуть из архива продукцию, возвращает результат model.Archive*. Выполненное
+// изменение сохраняется версией в истории продукции в той же транзакции.
+func (p *ProductRepo) ArchiveById(ctx context.Context, id int, archive bool, performerId int) (int, error) {
+	var result int
+
+	err := p.withVersion(ctx, id, performerId, func(tx *sql.Tx) (bool, error) {
+		if err := tx.QueryRowContext(ctx, FGWsvTBProductionArchiveByIdQuery, id, archive, performerId).Scan(&result); err != nil {
+			p.logg.LogE(msg.E3216, err)
+
+			return false, err
+		}
+
+		return result == model.ArchiveDone, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// withVersion выполнить изменение продукции и сохранить версию в истории в одной транзакции. Если истории ещё нет,
+// первой версией сохраняется состояние до изменения. change возвращает false, если продукция не изменилась: версия
+// не сохраняется, транзакция откатывается.
+func (p *ProductRepo) withVersion(ctx context.Context, id, performerId int, change func(tx *sql.Tx) (bool, error)) error {
+	tx, err := p.mssql.BeginTx(ctx, nil)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+	defer rollbackTx(tx, p.logg)
+
+	before, err := p.findById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	changed, err := change(tx)
+	if err != nil || !changed {
+		return err
+	}
+
+	hasVersions, err := hasProductVersions(ctx, tx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return err
+	}
+
+	if !hasVersions {
+		if err = addProductVersion(ctx, tx, before, before.AuditRec.UpdatedBy); err != nil {
+			p.logg.LogE(msg.E3215, err)
+
+			return err
+		}
+	}
+
+	after, err := p.findById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = addProductVersion(ctx, tx, after, performerId); err != nil {
+		p.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// rollbackTx откатить транзакцию, если она не зафиксирована.
+func rollbackTx(tx *sql.Tx, logg *common.Logger) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logg.LogE(msg.E3216, err)
+	}
+}
+
+// MapCatalogs сопоставить текстовые цвет и конструкторское наименование с записями справочников, возвращает кол-во
+// привязанной продукции.
+func (p *ProductRepo) MapCatalogs(ctx context.Context) (*model.ProductCatalogMapReport, error) {
+	var report model.ProductCatalogMapReport
+
+	if err := p.mssql.QueryRowContext(ctx, FGWsvTBProductionMapCatalogsQuery).Scan(&report.Colors, &report.Designs); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// CatalogUnmatched текстовые значения цвета и конструкторского наименования, для которых нет записи в справочниках.
+func (p *ProductRepo) CatalogUnmatched(ctx context.Context) ([]*model.ProductCatalogUnmatched, error) {
+	rows, err := p.mssql.QueryContext(ctx, FGWsvTBProductionCatalogUnmatchedQuery)
+	if err != nil {
+		p.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var unmatched []*model.ProductCatalogUnmatched
+	for rows.Next() {
+		var item model.ProductCatalogUnmatched
+		if err = rows.Scan(&item.Field, &item.Value, &item.Products); err != nil {
+			p.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		unmatched = append(unmatched, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		p.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return unmatched, nil
+}
@@ -3,13 +3,116 @@ package repository
 // СОТРУДНИКИ
 const (
 	FGWsvPerformerAllQuery         = "exec dbo.svPerformerAll;"                // ХП получение всех сотрудников (только не архивных).
-	FGWsvPerformerAuthQuery        = "exec dbo.svPerformerAuth ?, ?;"          // ХП проверяет сотрудника по табельному номеру и паролю для авторизации.
 	FGWsvPerformerFindByIdQuery    = "exec dbo.svPerformerFindById ?;"         // ХП ищет информацию о сотруднике по ИД.
+	FGWsvPerformerFindByBCQuery    = "exec dbo.svPerformerFindByBC ?;"         // ХП ищет сотрудника по коду доступа (бейджу).
 	FGWsvPerformerUpdByIdQuery     = "exec dbo.svPerformerUpdById ?, ?, ?, ?;" // ХП обновляет сотрудника по ИД.
 	FGWsvPerformerExistsByIdQuery  = "exec dbo.svPerformerExistsById ?;"       // ХП проверяет, существует ли сотрудник.
 	FGWsvPerformersCountQuery      = "exec dbo.svPerformersCount;"             // ХП считает общее кол-во сотрудников.
 	FGWsvPerformersPaginationQuery = "exec dbo.svPerformersPagination ?, ?;"   // ХП получает сотрудников с нумерации страниц.
 	FGWsvPerformerFilterByIdQuery  = "exec dbo.svPerformerFilterById ?;"       // ХП ищет сотрудника по табельному номеру.
+	FGWsvPerformerPassByIdQuery    = "exec dbo.svPerformerPassById ?;"         // ХП получает хеш пароля сотрудника.
+	FGWsvPerformerUpdPassByIdQuery = "exec dbo.svPerformerUpdPassById ?, ?;"   // ХП сохраняет хеш пароля сотрудника.
+	FGWsvPerformerPassLegacyQuery  = "exec dbo.svPerformerPassLegacy;"         // ХП получает сотрудников с незахешированным паролем.
+)
+
+// ПАРОЛИ СОТРУДНИКОВ
+const (
+	FGWsvPerformerSetPassByIdQuery        = "exec dbo.svPerformerSetPassById ?, ?, ?, ?;" // ХП сохраняет новый хеш пароля, прежний уходит в историю.
+	FGWsvPerformerPassHistoryByIdQuery    = "exec dbo.svPerformerPassHistoryById ?, ?;"   // ХП получает хеши последних прежних паролей.
+	FGWsvPerformerPassMustChangeByIdQuery = "exec dbo.svPerformerPassMustChangeById ?;"   // ХП проверяет, должен ли сотрудник сменить пароль.
+)
+
+// ДВУХФАКТОРНАЯ АУТЕНТИФИКАЦИЯ
+const (
+	FGWsvTBPerformerTOTPByPerformerQuery   = "exec dbo.svTB_PerformerTOTPByPerformer ?;"     // ХП получает секрет TOTP сотрудника.
+	FGWsvTBPerformerTOTPSetQuery           = "exec dbo.svTB_PerformerTOTPSet ?, ?;"          // ХП сохраняет новый секрет TOTP.
+	FGWsvTBPerformerTOTPConfirmQuery       = "exec dbo.svTB_PerformerTOTPConfirm ?, ?;"      // ХП подключает 2FA после проверки первого кода.
+	FGWsvTBPerformerTOTPUseStepQuery       = "exec dbo.svTB_PerformerTOTPUseStep ?, ?;"      // ХП принимает код интервала, повторно код не принимается.
+	FGWsvTBPerformerTOTPDelQuery           = "exec dbo.svTB_PerformerTOTPDel ?;"             // ХП отключает 2FA.
+	FGWsvTBPerformerRecoveryCodesSetQuery  = "exec dbo.svTB_PerformerRecoveryCodesSet ?, ?;" // ХП заменяет коды восстановления.
+	FGWsvTBPerformerRecoveryCodeUseQuery   = "exec dbo.svTB_PerformerRecoveryCodeUse ?, ?;"  // ХП использует код восстановления.
+	FGWsvTBPerformerRecoveryCodesLeftQuery = "exec dbo.svTB_PerformerRecoveryCodesLeft ?;"   // ХП считает неиспользованные коды восстановления.
+	FGWsvTBPerformerTOTPCountQuery         = "exec dbo.svTB_PerformerTOTPCount;"             // ХП считает секреты TOTP сотрудников.
+)
+
+// РЕЕСТР СЕССИЙ
+const (
+	FGWsvTBSessionAddQuery               = "exec dbo.svTB_SessionAdd ?, ?, ?, ?, ?, ?, ?, ?;" // ХП регистрирует сессию.
+	FGWsvTBSessionTouchQuery             = "exec dbo.svTB_SessionTouch ?, ?;"                 // ХП отмечает активность сессии, возвращает действует ли она.
+	FGWsvTBSessionRevokeQuery            = "exec dbo.svTB_SessionRevoke ?;"                   // ХП отзывает сессию, возвращает отозвана ли она.
+	FGWsvTBSessionRevokeByPerformerQuery = "exec dbo.svTB_SessionRevokeByPerformer ?;"        // ХП отзывает все сессии сотрудника.
+	FGWsvTBSessionActiveQuery            = "exec dbo.svTB_SessionActive;"                     // ХП получает действующие сессии.
+)
+
+// СОТРУДНИКИ AForms
+const (
+	FGWsvTBPerformerAllQuery            = "exec dbo.svTB_PerformerAll;"                       // ХП получение всех сотрудников AForms.
+	FGWsvTBPerformerUpsertByTabnumQuery = "exec dbo.svTB_PerformerUpsertByTabnum ?, ?, ?, ?;" // ХП добавляет или обновляет сотрудника AForms по табельному номеру.
+	FGWsvTBUpdPerformerBySectorQuery    = "exec dbo.svTB_UpdPerformerBySector ?, ?;"          // ХП обновляет привязку печки к сотруднику.
+)
+
+// ПЕЧИ
+const (
+	FGWsvTBSectorAllQuery        = "exec dbo.svTB_SectorAll;"          // ХП получение списка печек.
+	FGWsvTBSectorExistsByIdQuery = "exec dbo.svTB_SectorExistsById ?;" // ХП проверяет, существует ли печь.
+)
+
+// ПРОДУКЦИЯ
+const (
+	FGWsvTBProductionAllQuery              = "exec dbo.svTB_ProductionAll;"                                                                                     // ХП получение всей продукции.
+	FGWsvTBProductionFindByIdQuery         = "exec dbo.svTB_ProductionFindById ?;"                                                                              // ХП ищет продукцию по ИД.
+	FGWsvTBProductionExistsByIdQuery       = "exec dbo.svTB_ProductionExistsById ?;"                                                                            // ХП проверяет, существует ли продукция.
+	FGWsvTBProductionUpdByIdQuery          = "exec dbo.svTB_ProductionUpdById ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?;" // ХП обновляет продукцию по ИД.
+	FGWsvTBProductionArchiveByIdQuery      = "exec dbo.svTB_ProductionArchiveById ?, ?, ?;"                                                                     // ХП архивирует или возвращает из архива продукцию.
+	FGWsvTBProductionMapCatalogsQuery      = "exec dbo.svTB_ProductionMapCatalogs;"                                                                             // ХП сопоставляет цвет и наименование с записями справочников.
+	FGWsvTBProductionCatalogUnmatchedQuery = "exec dbo.svTB_ProductionCatalogUnmatched;"                                                                        // ХП получает значения без записи в справочниках.
+)
+
+// ИСТОРИЯ ПРОДУКЦИИ
+const (
+	FGWsvTBProductionHistoryAddQuery          = "exec dbo.svTB_ProductionHistoryAdd ?, ?, ?;"    // ХП добавляет версию продукции.
+	FGWsvTBProductionHistoryByProductionQuery = "exec dbo.svTB_ProductionHistoryByProduction ?;" // ХП получает версии продукции.
+)
+
+// ДЕКЛАРАЦИИ ПРОДУКЦИИ
+const (
+	FGWsvTBProductionDeclAddQuery          = "exec dbo.svTB_ProductionDeclAdd ?, ?, ?, ?, ?;" // ХП прикрепляет декларацию к продукции.
+	FGWsvTBProductionDeclByProductionQuery = "exec dbo.svTB_ProductionDeclByProduction ?;"    // ХП получает декларации продукции.
+	FGWsvTBProductionDeclExpiringQuery     = "exec dbo.svTB_ProductionDeclExpiring ?;"        // ХП получает истекающие декларации.
+)
+
+// ВЫГРУЗКА В SAP
+const (
+	FGWsvTBSapReceiptsByDateQuery  = "exec dbo.svTB_SapReceiptsByDate ?;"  // ХП получает приход продукции за дату по коду SAP.
+	FGWsvTBSapShipmentsByDateQuery = "exec dbo.svTB_SapShipmentsByDate ?;" // ХП получает отгрузку продукции за дату по коду SAP.
+	FGWsvTBSapStockByDateQuery     = "exec dbo.svTB_SapStockByDate ?;"     // ХП получает остаток продукции на конец даты по коду SAP.
+)
+
+// СМЕННО-СУТОЧНЫЕ ЗАДАНИЯ
+const (
+	FGWsvTBShiftTaskByDateQuery     = "exec dbo.svTB_ShiftTaskByDate ?, ?;"             // ХП получает задания на дату и смену.
+	FGWsvTBShiftTaskAddQuery        = "exec dbo.svTB_ShiftTaskAdd ?, ?, ?, ?, ?, ?, ?;" // ХП добавляет задание.
+	FGWsvTBShiftTaskExistsByIdQuery = "exec dbo.svTB_ShiftTaskExistsById ?;"            // ХП проверяет, существует ли задание.
+)
+
+// СТАНЦИИ УПАКОВКИ
+const (
+	FGWsvTBPackStationAllQuery           = "exec dbo.svTB_PackStationAll;"                         // ХП получение всех станций упаковки.
+	FGWsvTBPackStationAddQuery           = "exec dbo.svTB_PackStationAdd ?, ?, ?, ?, ?;"           // ХП добавляет станцию упаковки.
+	FGWsvTBPackStationUpdByIdQuery       = "exec dbo.svTB_PackStationUpdById ?, ?, ?, ?, ?, ?, ?;" // ХП обновляет станцию упаковки.
+	FGWsvTBPackStationExistsByIdQuery    = "exec dbo.svTB_PackStationExistsById ?;"                // ХП проверяет, существует ли станция.
+	FGWsvTBPackStationSessionActiveQuery = "exec dbo.svTB_PackStationSessionActive ?;"             // ХП получает открытый сеанс станции.
+	FGWsvTBPackStationSessionOpenQuery   = "exec dbo.svTB_PackStationSessionOpen ?, ?;"            // ХП открывает сеанс по штрих-коду бейджа.
+	FGWsvTBPackStationSessionCloseQuery  = "exec dbo.svTB_PackStationSessionClose ?;"              // ХП закрывает сеанс станции.
+)
+
+// П\П
+const (
+	FGWsvTBPalletPrintQuery = "exec dbo.svTB_PalletPrint ?, ?, ?, ?;" // ХП регистрирует печать п\п и увеличивает факт задания.
+	FGWsvTBPalletShipQuery  = "exec dbo.svTB_PalletShip ?, ?;"        // ХП отгружает п\п со склада.
+	FGWsvTBPalletPlaceQuery = "exec dbo.svTB_PalletPlace ?, ?;"       // ХП размещает п\п на участке хранения.
+
+	FGWsvTBPalletCountByStorageAreaQuery = "exec dbo.svTB_PalletCountByStorageArea;" // ХП получает кол-во п\п на складе по участкам хранения.
 )
 
 // РОЛИ
@@ -21,3 +124,26 @@ const (
 	FGWsvRoleExistsByIdQuery = "exec dbo.svRoleExistsById ?;"       // ХП проверяет, существует ли роль.
 	FGWsvRoleDelByIdQuery    = "exec dbo.svRoleDelById ?;"          // ХП проверяет, существует ли роль.
 )
+
+// ПРАВА РОЛЕЙ
+const (
+	FGWsvPermissionAllQuery         = "exec dbo.svPermissionAll;"              // ХП получение списка прав.
+	FGWsvRolePermissionsAllQuery    = "exec dbo.svRolePermissionsAll;"         // ХП получение прав всех ролей.
+	FGWsvRolePermissionsByRoleQuery = "exec dbo.svRolePermissionsByRole ?;"    // ХП получение кодов прав роли.
+	FGWsvRolePermissionsSetQuery    = "exec dbo.svRolePermissionsSet ?, ?, ?;" // ХП заменяет права роли и увеличивает версию прав.
+	FGWsvPermissionsVersionGetQuery = "exec dbo.svPermissionsVersionGet;"      // ХП получает версию прав ролей.
+)
+
+// API-ТОКЕНЫ
+const (
+	FGWsvTBApiTokenAddQuery    = "exec dbo.svTB_ApiTokenAdd ?, ?, ?, ?, ?, ?;" // ХП выдает токен, возвращает его ид.
+	FGWsvTBApiTokenByHashQuery = "exec dbo.svTB_ApiTokenByHash ?;"             // ХП получает действующий токен по хешу с ролями сотрудника.
+	FGWsvTBApiTokenAllQuery    = "exec dbo.svTB_ApiTokenAll;"                  // ХП получает список токенов.
+	FGWsvTBApiTokenRevokeQuery = "exec dbo.svTB_ApiTokenRevoke ?;"             // ХП отзывает токен.
+	FGWsvTBApiTokenTouchQuery  = "exec dbo.svTB_ApiTokenTouch ?, ?;"           // ХП отмечает время последнего запроса по токену.
+)
+
+// СПРАВОЧНИКИ
+const (
+	FGWsvCatalogsByKodcatQuery = "exec dbo.svCatalogsByKodcat ?;" // ХП получает записи справочника по коду справочника.
+)
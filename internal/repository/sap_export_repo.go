@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type SapExportRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewSapExportRepo(mssql *sql.DB, logger *common.Logger) *SapExportRepo {
+	return &SapExportRepo{mssql: mssql, logg: logger}
+}
+
+type SapExportRepository interface {
+	ReceiptsByDate(ctx context.Context, date string) ([]*model.SapRow, error)
+	ShipmentsByDate(ctx context.Context, date string) ([]*model.SapRow, error)
+	StockByDate(ctx context.Context, date string) ([]*model.SapRow, error)
+}
+
+// ReceiptsByDate приход продукции за дату: п\п, напечатанные за день. Продукция без кода SAP не выгружается.
+func (s *SapExportRepo) ReceiptsByDate(ctx context.Context, date string) ([]*model.SapRow, error) {
+	return s.rowsByDate(ctx, FGWsvTBSapReceiptsByDateQuery, date)
+}
+
+// ShipmentsByDate отгрузка продукции за дату: п\п, отгруженные за день.
+func (s *SapExportRepo) ShipmentsByDate(ctx context.Context, date string) ([]*model.SapRow, error) {
+	return s.rowsByDate(ctx, FGWsvTBSapShipmentsByDateQuery, date)
+}
+
+// StockByDate остаток продукции на конец даты: п\п, напечатанные и не отгруженные.
+func (s *SapExportRepo) StockByDate(ctx context.Context, date string) ([]*model.SapRow, error) {
+	return s.rowsByDate(ctx, FGWsvTBSapStockByDateQuery, date)
+}
+
+func (s *SapExportRepo) rowsByDate(ctx context.Context, query, date string) ([]*model.SapRow, error) {
+	rows, err := s.mssql.QueryContext(ctx, query, date)
+	if err != nil {
+		s.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var sapRows []*model.SapRow
+	for rows.Next() {
+		var row model.SapRow
+		if err = rows.Scan(
+			&row.Date,
+			&row.SapCode,
+			&row.Article,
+			&row.Name,
+			&row.Pallets,
+			&row.Qty,
+		); err != nil {
+			s.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		sapRows = append(sapRows, &row)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return sapRows, nil
+}
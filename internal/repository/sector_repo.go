@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type SectorRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewSectorRepo(mssql *sql.DB, logger *common.Logger) *SectorRepo {
+	return &SectorRepo{mssql: mssql, logg: logger}
+}
+
+type SectorRepository interface {
+	All(ctx context.Context) ([]*model.Sector, error)
+	ExistById(ctx context.Context, id int) (bool, error)
+}
+
+// All получить все печи из БД.
+func (s *SectorRepo) All(ctx context.Context) ([]*model.Sector, error) {
+	rows, err := s.mssql.QueryContext(ctx, FGWsvTBSectorAllQuery)
+	if err != nil {
+		s.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var sectors []*model.Sector
+	for rows.Next() {
+		var sector model.Sector
+		if err = rows.Scan(
+			&sector.Id,
+			&sector.Name,
+			&sector.Lines,
+			&sector.TicketSize,
+			&sector.EditDate,
+			&sector.EditUser,
+		); err != nil {
+			s.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		sectors = append(sectors, &sector)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return sectors, nil
+}
+
+// ExistById проверяет существование печки.
+func (s *SectorRepo) ExistById(ctx context.Context, id int) (bool, error) {
+	var exists bool
+
+	err := s.mssql.QueryRowContext(ctx, FGWsvTBSectorExistsByIdQuery, id).Scan(&exists)
+	if err != nil {
+		s.logg.LogE(msg.E3206, err)
+
+		return false, err
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"FGW_WEB/internal/model"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SessionMemoryRepo реестр сессий в памяти процесса. Сессии теряются при перезапуске сервера, подходит для одного
+// экземпляра приложения.
+type SessionMemoryRepo struct {
+	mu       sync.Mutex
+	sessions map[string]*model.SessionRecord
+}
+
+func NewSessionMemoryRepo() *SessionMemoryRepo {
+	return &SessionMemoryRepo{sessions: make(map[string]*model.SessionRecord)}
+}
+
+// Add зарегистрировать сессию, заодно удаляются истекшие.
+func (s *SessionMemoryRepo) Add(_ context.Context, session *model.SessionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, record := range s.sessions {
+		if !record.ExpiresAt.After(now) {
+			delete(s.sessions, id)
+		}
+	}
+
+	record := *session
+	record.LastActivity = record.CreatedAt
+	s.sessions[record.Id] = &record
+
+	return nil
+}
+
+// Touch отметить активность сессии, false - сессия отозвана, истекла или не зарегистрирована.
+func (s *SessionMemoryRepo) Touch(_ context.Context, id string, now time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.sessions[id]
+	if !ok {
+		return false, nil
+	}
+
+	if !record.ExpiresAt.After(now) {
+		delete(s.sessions, id)
+
+		return false, nil
+	}
+
+	record.LastActivity = now
+
+	return true, nil
+}
+
+// Revoke отозвать сессию, false - сессия не найдена или уже отозвана.
+func (s *SessionMemoryRepo) Revoke(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sessions[id]
+	delete(s.sessions, id)
+
+	return ok, nil
+}
+
+// RevokeByPerformer отозвать все сессии сотрудника, возвращает кол-во отозванных.
+func (s *SessionMemoryRepo) RevokeByPerformer(_ context.Context, performerId int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	revoked := 0
+	for id, record := range s.sessions {
+		if record.PerformerId != performerId {
+			continue
+		}
+
+		if record.ExpiresAt.After(now) {
+			revoked++
+		}
+		delete(s.sessions, id)
+	}
+
+	return revoked, nil
+}
+
+// AllActive получить действующие сессии, последние активные первыми.
+func (s *SessionMemoryRepo) AllActive(_ context.Context) ([]*model.SessionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]*model.SessionRecord, 0, len(s.sessions))
+	for _, record := range s.sessions {
+		if record.ExpiresAt.After(now) {
+			session := *record
+			sessions = append(sessions, &session)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActivity.After(sessions[j].LastActivity)
+	})
+
+	return sessions, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type SessionRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewSessionRepo(mssql *sql.DB, logger *common.Logger) *SessionRepo {
+	return &SessionRepo{mssql: mssql, logg: logger}
+}
+
+type SessionRepository interface {
+	Add(ctx context.Context, session *model.SessionRecord) error
+	Touch(ctx context.Context, id string, now time.Time) (bool, error)
+	Revoke(ctx context.Context, id string) (bool, error)
+	RevokeByPerformer(ctx context.Context, performerId int) (int, error)
+	AllActive(ctx context.Context) ([]*model.SessionRecord, error)
+}
+
+// Add зарегистрировать сессию.
+func (s *SessionRepo) Add(ctx context.Context, session *model.SessionRecord) error {
+	if _, err := s.mssql.ExecContext(ctx, FGWsvTBSessionAddQuery,
+		session.Id,
+		session.PerformerId,
+		session.RoleId,
+		session.DeviceType,
+		session.RemoteAddr,
+		session.UserAgent,
+		session.CreatedAt,
+		session.ExpiresAt,
+	); err != nil {
+		s.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Touch отметить активность сессии, false - сессия отозвана, истекла или не зарегистрирована.
+func (s *SessionRepo) Touch(ctx context.Context, id string, now time.Time) (bool, error) {
+	var active bool
+
+	if err := s.mssql.QueryRowContext(ctx, FGWsvTBSessionTouchQuery, id, now).Scan(&active); err != nil {
+		s.logg.LogE(msg.E3204, err)
+
+		return false, err
+	}
+
+	return active, nil
+}
+
+// Revoke отозвать сессию, false - сессия не найдена или уже отозвана. Отзыв атомарен: из параллельных вызовов true
+// получает только один.
+func (s *SessionRepo) Revoke(ctx context.Context, id string) (bool, error) {
+	var revoked bool
+
+	if err := s.mssql.QueryRowContext(ctx, FGWsvTBSessionRevokeQuery, id).Scan(&revoked); err != nil {
+		s.logg.LogE(msg.E3216, err)
+
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// RevokeByPerformer отозвать все сессии сотрудника, возвращает кол-во отозванных.
+func (s *SessionRepo) RevokeByPerformer(ctx context.Context, performerId int) (int, error) {
+	var revoked int
+
+	if err := s.mssql.QueryRowContext(ctx, FGWsvTBSessionRevokeByPerformerQuery, performerId).Scan(&revoked); err != nil {
+		s.logg.LogE(msg.E3217, err)
+
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+// AllActive получить действующие сессии, последние активные первыми.
+func (s *SessionRepo) AllActive(ctx context.Context) ([]*model.SessionRecord, error) {
+	rows, err := s.mssql.QueryContext(ctx, FGWsvTBSessionActiveQuery)
+	if err != nil {
+		s.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var sessions []*model.SessionRecord
+	for rows.Next() {
+		var session model.SessionRecord
+		if err = rows.Scan(
+			&session.Id,
+			&session.PerformerId,
+			&session.RoleId,
+			&session.DeviceType,
+			&session.RemoteAddr,
+			&session.UserAgent,
+			&session.CreatedAt,
+			&session.ExpiresAt,
+			&session.LastActivity,
+		); err != nil {
+			s.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return sessions, nil
+}
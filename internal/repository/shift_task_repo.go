@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"FGW_WEB/internal/config/db"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+)
+
+type ShiftTaskRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewShiftTaskRepo(mssql *sql.DB, logger *common.Logger) *ShiftTaskRepo {
+	return &ShiftTaskRepo{mssql: mssql, logg: logger}
+}
+
+type ShiftTaskRepository interface {
+	AllByDate(ctx context.Context, taskDate string, shiftNum int) ([]*model.ShiftTask, error)
+	Add(ctx context.Context, task *model.ShiftTask) error
+	ExistById(ctx context.Context, id int) (bool, error)
+}
+
+// AllByDate получить задания на дату, shiftNum = 0 - все смены.
+func (s *ShiftTaskRepo) AllByDate(ctx context.Context, taskDate string, shiftNum int) ([]*model.ShiftTask, error) {
+	rows, err := s.mssql.QueryContext(ctx, FGWsvTBShiftTaskByDateQuery, taskDate, shiftNum)
+	if err != nil {
+		s.logg.LogE(msg.E3202, err)
+
+		return nil, err
+	}
+	defer db.RowsClose(rows)
+
+	var tasks []*model.ShiftTask
+	for rows.Next() {
+		var task model.ShiftTask
+		if err = rows.Scan(
+			&task.Id,
+			&task.TaskDate,
+			&task.ShiftNum,
+			&task.SectorId,
+			&task.SectorName,
+			&task.LineNum,
+			&task.ProductId,
+			&task.ProductArticle,
+			&task.ProductName,
+			&task.PlanQty,
+			&task.FactQty,
+			&task.AuditRec.CreatedAt,
+			&task.AuditRec.CreatedBy,
+			&task.AuditRec.UpdatedAt,
+			&task.AuditRec.UpdatedBy,
+		); err != nil {
+			s.logg.LogE(msg.E3204, err)
+
+			return nil, err
+		}
+
+		tasks = append(tasks, &task)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.logg.LogE(msg.E3205, err)
+
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Add добавить сменно-суточное задание.
+func (s *ShiftTaskRepo) Add(ctx context.Context, task *model.ShiftTask) error {
+	if _, err := s.mssql.ExecContext(ctx, FGWsvTBShiftTaskAddQuery,
+		task.TaskDate,
+		task.ShiftNum,
+		task.SectorId,
+		task.LineNum,
+		task.ProductId,
+		task.PlanQty,
+		task.AuditRec.CreatedBy,
+	); err != nil {
+		s.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ExistById проверяет существование задания.
+func (s *ShiftTaskRepo) ExistById(ctx context.Context, id int) (bool, error) {
+	var exists bool
+
+	err := s.mssql.QueryRowContext(ctx, FGWsvTBShiftTaskExistsByIdQuery, id).Scan(&exists)
+	if err != nil {
+		s.logg.LogE(msg.E3206, err)
+
+		return false, err
+	}
+
+	return exists, nil
+}
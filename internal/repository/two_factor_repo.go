@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type TwoFactorRepo struct {
+	mssql *sql.DB
+	logg  *common.Logger
+}
+
+func NewTwoFactorRepo(mssql *sql.DB, logger *common.Logger) *TwoFactorRepo {
+	return &TwoFactorRepo{mssql: mssql, logg: logger}
+}
+
+type TwoFactorRepository interface {
+	FindByPerformer(ctx context.Context, performerId int) (*model.PerformerTOTP, error)
+	SetSecret(ctx context.Context, performerId int, secret string) error
+	Confirm(ctx context.Context, performerId int, step int64) error
+	UseStep(ctx context.Context, performerId int, step int64) (bool, error)
+	Del(ctx context.Context, performerId int) error
+	SetRecoveryCodes(ctx context.Context, performerId int, hashes []string) error
+	UseRecoveryCode(ctx context.Context, performerId int, hash string) (bool, error)
+	RecoveryCodesLeft(ctx context.Context, performerId int) (int, error)
+	CountEnrolled(ctx context.Context) (int, error)
+}
+
+// FindByPerformer получить секрет TOTP сотрудника, nil - 2FA не подключалась.
+func (t *TwoFactorRepo) FindByPerformer(ctx context.Context, performerId int) (*model.PerformerTOTP, error) {
+	var totp model.PerformerTOTP
+	var confirmedAt sql.NullTime
+
+	err := t.mssql.QueryRowContext(ctx, FGWsvTBPerformerTOTPByPerformerQuery, performerId).Scan(
+		&totp.PerformerId,
+		&totp.Secret,
+		&confirmedAt,
+		&totp.LastStep,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		t.logg.LogE(msg.E3204, err)
+
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		totp.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return &totp, nil
+}
+
+// SetSecret сохранить новый секрет TOTP, прежний секрет заменяется, 2FA не подключена до подтверждения.
+func (t *TwoFactorRepo) SetSecret(ctx context.Context, performerId int, secret string) error {
+	if _, err := t.mssql.ExecContext(ctx, FGWsvTBPerformerTOTPSetQuery, performerId, secret); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Confirm подключить 2FA, step - интервал кода, которым подтверждено подключение.
+func (t *TwoFactorRepo) Confirm(ctx context.Context, performerId int, step int64) error {
+	if _, err := t.mssql.ExecContext(ctx, FGWsvTBPerformerTOTPConfirmQuery, performerId, step); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// UseStep принять код интервала step, false - код этого или более позднего интервала уже принимался.
+func (t *TwoFactorRepo) UseStep(ctx context.Context, performerId int, step int64) (bool, error) {
+	var accepted bool
+
+	if err := t.mssql.QueryRowContext(ctx, FGWsvTBPerformerTOTPUseStepQuery, performerId, step).Scan(&accepted); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return false, err
+	}
+
+	return accepted, nil
+}
+
+// Del отключить 2FA: удалить секрет и коды восстановления.
+func (t *TwoFactorRepo) Del(ctx context.Context, performerId int) error {
+	if _, err := t.mssql.ExecContext(ctx, FGWsvTBPerformerTOTPDelQuery, performerId); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// SetRecoveryCodes заменить коды восстановления сотрудника, hashes - SHA-256 кодов (hex).
+func (t *TwoFactorRepo) SetRecoveryCodes(ctx context.Context, performerId int, hashes []string) error {
+	if _, err := t.mssql.ExecContext(ctx, FGWsvTBPerformerRecoveryCodesSetQuery, performerId, strings.Join(hashes, ",")); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode использовать код восстановления, false - кода нет или он уже использован.
+func (t *TwoFactorRepo) UseRecoveryCode(ctx context.Context, performerId int, hash string) (bool, error) {
+	var accepted bool
+
+	if err := t.mssql.QueryRowContext(ctx, FGWsvTBPerformerRecoveryCodeUseQuery, performerId, hash).Scan(&accepted); err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return false, err
+	}
+
+	return accepted, nil
+}
+
+// RecoveryCodesLeft количество неиспользованных кодов восстановления.
+func (t *TwoFactorRepo) RecoveryCodesLeft(ctx context.Context, performerId int) (int, error) {
+	var left int
+
+	if err := t.mssql.QueryRowContext(ctx, FGWsvTBPerformerRecoveryCodesLeftQuery, performerId).Scan(&left); err != nil {
+		t.logg.LogE(msg.E3204, err)
+
+		return 0, err
+	}
+
+	return left, nil
+}
+
+// CountEnrolled количество сотрудников с секретом TOTP, в том числе не подтвердивших подключение.
+func (t *TwoFactorRepo) CountEnrolled(ctx context.Context) (int, error) {
+	var count int
+
+	if err := t.mssql.QueryRowContext(ctx, FGWsvTBPerformerTOTPCountQuery).Scan(&count); err != nil {
+		t.logg.LogE(msg.E3204, err)
+
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AccessTokenService выдача и проверка JWT для клиентов без сессии (ТСД). Токен обновления регистрируется в реестре
+// сессий: его видно на странице сессий и его можно отозвать. Токен доступа ссылается на него (sid) и перестает
+// действовать сразу после отзыва сессии или перевода сотрудника в архив.
+type AccessTokenService struct {
+	cfg           *config.JWTCfg
+	performerRepo repository.PerformerRepository
+	registry      SessionUseCase
+	logg          *common.Logger
+}
+
+func NewAccessTokenService(
+	cfg *config.JWTCfg,
+	performerRepo repository.PerformerRepository,
+	registry SessionUseCase,
+	logger *common.Logger) *AccessTokenService {
+
+	return &AccessTokenService{cfg: cfg, performerRepo: performerRepo, registry: registry, logg: logger}
+}
+
+type AccessTokenUseCase interface {
+	IssueTokens(ctx context.Context, performer *model.Performer, client *model.TokenClient) (*model.TokenResponse, error)
+	RefreshTokens(ctx context.Context, refreshToken string, client *model.TokenClient) (*model.TokenResponse, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	VerifyAccessToken(ctx context.Context, token string) (*model.AccessClaims, bool)
+}
+
+// IssueTokens выдать пару токенов сотруднику после входа.
+func (a *AccessTokenService) IssueTokens(ctx context.Context, performer *model.Performer, client *model.TokenClient) (*model.TokenResponse, error) {
+	now := time.Now()
+
+	claims := model.AccessClaims{
+		Issuer:      config.JWTIssuer,
+		PerformerId: performer.Id,
+		RoleId:      performer.IdRoleAForms,
+		RoleFGWId:   performer.IdRoleAFGW,
+		DeviceType:  client.DeviceType,
+		IssuedAt:    now.Unix(),
+	}
+
+	refreshToken, err := a.sign(claims, model.TokenTypeRefresh, now.Add(a.cfg.RefreshTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	claims.SessionId = model.SessionTokenId(refreshToken)
+	accessToken, err := a.sign(claims, model.TokenTypeAccess, now.Add(a.cfg.AccessTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.registry.RegisterSession(ctx, refreshToken, &model.SessionRecord{
+		PerformerId: performer.Id,
+		RoleId:      performer.IdRoleAForms,
+		DeviceType:  client.DeviceType,
+		RemoteAddr:  client.RemoteAddr,
+		UserAgent:   client.UserAgent,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(a.cfg.RefreshTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &model.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(a.cfg.AccessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int(a.cfg.RefreshTTL.Seconds()),
+		PerformerId:      performer.Id,
+		RoleId:           performer.IdRoleAForms,
+		RoleFGWId:        performer.IdRoleAFGW,
+	}, nil
+}
+
+// RefreshTokens обменять токен обновления на новую пару, старый токен обновления отзывается. Роли берутся
+// текущие. nil - токен недействителен, отозван, уже обменян или сотрудник в архиве. Из параллельных обменов одного
+// токена новую пару получает только тот, чей отзыв сработал.
+func (a *AccessTokenService) RefreshTokens(ctx context.Context, refreshToken string, client *model.TokenClient) (*model.TokenResponse, error) {
+	claims, ok := a.verify(refreshToken, model.TokenTypeRefresh)
+	if !ok {
+		return nil, nil
+	}
+
+	// svPerformerFindById не находит сотрудника в архиве: его сессия отзывается.
+	performer, err := a.performerRepo.FindById(ctx, claims.PerformerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = a.registry.RevokeSession(ctx, refreshToken)
+
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := a.registry.RevokeSession(ctx, refreshToken)
+	if err != nil || !revoked {
+		return nil, err
+	}
+
+	client.DeviceType = claims.DeviceType
+
+	return a.IssueTokens(ctx, performer, client)
+}
+
+// RevokeRefreshToken отозвать токен обновления при выходе с устройства.
+func (a *AccessTokenService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	if _, ok := a.verify(refreshToken, model.TokenTypeRefresh); !ok {
+		err := fmt.Errorf("%s: токен обновления недействителен", msg.E3213)
+		a.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	_, err := a.registry.RevokeSession(ctx, refreshToken)
+
+	return err
+}
+
+// VerifyAccessToken проверить подпись и срок токена доступа, что его сессия в реестре не отозвана и сотрудник не в
+// архиве.
+func (a *AccessTokenService) VerifyAccessToken(ctx context.Context, token string) (*model.AccessClaims, bool) {
+	claims, ok := a.verify(token, model.TokenTypeAccess)
+	if !ok || claims.SessionId == "" {
+		return nil, false
+	}
+
+	active, err := a.registry.CheckSessionById(ctx, claims.SessionId)
+	if err != nil || !active {
+		return nil, false
+	}
+
+	performer, err := a.performerRepo.FindById(ctx, claims.PerformerId)
+	if err != nil || performer.Archive {
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// sign подписать токен типа tokenType активным ключом.
+func (a *AccessTokenService) sign(claims model.AccessClaims, tokenType string, expiresAt time.Time) (string, error) {
+	claims.TokenType = tokenType
+	claims.ExpiresAt = expiresAt.Unix()
+	if claims.Id = config.GenerateSessionToken(); claims.Id == "" {
+		err := fmt.Errorf("%s: не удалось сгенерировать ид токена", msg.E3215)
+		a.logg.LogE(msg.E3215, err)
+
+		return "", err
+	}
+
+	return signJWT(a.cfg.ActiveKid, a.cfg.Keys[a.cfg.ActiveKid], claims)
+}
+
+// verify проверить подпись, издателя, тип и срок токена.
+func (a *AccessTokenService) verify(token, tokenType string) (*model.AccessClaims, bool) {
+	var claims model.AccessClaims
+	if err := parseJWT(token, a.cfg.Keys, &claims); err != nil {
+		return nil, false
+	}
+
+	if claims.Issuer != config.JWTIssuer || claims.TokenType != tokenType || claims.PerformerId <= 0 ||
+		time.Now().Unix() >= claims.ExpiresAt {
+		return nil, false
+	}
+
+	return &claims, true
+}
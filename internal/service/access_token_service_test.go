@@ -0,0 +1,186 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testJWTKey2025 = "2025-0123456789abcdef0123456789ab"
+	testJWTKey2026 = "2026-0123456789abcdef0123456789ab"
+)
+
+func newAccessTokenService(cfg *config.JWTCfg) (*AccessTokenService, *fakePerformerRepo, SessionUseCase) {
+	performerRepo := newFakePerformerRepo()
+	registry := NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+
+	return NewAccessTokenService(cfg, performerRepo, registry, &common.Logger{}), performerRepo, registry
+}
+
+func newTestJWTCfg(activeKid string, keys ...string) *config.JWTCfg {
+	cfg := &config.JWTCfg{Keys: make(map[string][]byte), ActiveKid: activeKid, AccessTTL: time.Minute, RefreshTTL: time.Hour}
+	for _, key := range keys {
+		cfg.Keys[key[:4]] = []byte(key)
+	}
+
+	return cfg
+}
+
+func TestAccessTokenService_VerifyAccessToken(t *testing.T) {
+	ctx := context.Background()
+	accessTokenService, performerRepo, _ := newAccessTokenService(newTestJWTCfg("2025", testJWTKey2025))
+
+	tokens, err := accessTokenService.IssueTokens(ctx, performerRepo.performers[1001], &model.TokenClient{DeviceType: "tsd"})
+	require.NoError(t, err)
+	assert.Equal(t, 60, tokens.ExpiresIn)
+	assert.Equal(t, 3600, tokens.RefreshExpiresIn)
+
+	claims, ok := accessTokenService.VerifyAccessToken(ctx, tokens.AccessToken)
+	require.True(t, ok)
+	assert.Equal(t, 1001, claims.PerformerId)
+	assert.Equal(t, 4, claims.RoleId)
+	assert.Equal(t, "tsd", claims.DeviceType)
+
+	_, ok = accessTokenService.VerifyAccessToken(ctx, tokens.RefreshToken)
+	assert.False(t, ok, "токен обновления не является токеном доступа")
+
+	parts := strings.Split(tokens.AccessToken, ".")
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT","kid":"2025"}`))
+
+	for name, token := range map[string]string{
+		"пустой":             "",
+		"API-токен":          "fgw_abc",
+		"чужая подпись":      parts[0] + "." + parts[1] + "." + base64.RawURLEncoding.EncodeToString([]byte("x")),
+		"без подписи (none)": noneHeader + "." + parts[1] + ".",
+		"подмена утверждений": parts[0] + "." + base64.RawURLEncoding.EncodeToString(
+			[]byte(`{"iss":"fgw_web","pid":1003,"typ":"access","exp":9999999999}`)) + "." + parts[2],
+	} {
+		_, ok = accessTokenService.VerifyAccessToken(ctx, token)
+		assert.False(t, ok, name)
+	}
+
+	expired, err := signJWT("2025", []byte(testJWTKey2025), model.AccessClaims{
+		Issuer: config.JWTIssuer, PerformerId: 1001, TokenType: model.TokenTypeAccess, ExpiresAt: time.Now().Add(-time.Second).Unix(),
+	})
+	require.NoError(t, err)
+	_, ok = accessTokenService.VerifyAccessToken(ctx, expired)
+	assert.False(t, ok, "истекший токен")
+}
+
+func TestAccessTokenService_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	performer := newFakePerformerRepo().performers[1001]
+
+	// Экземпляры с разными ключами работают с общим реестром сессий, как в одной БД.
+	oldService, performerRepo, registry := newAccessTokenService(newTestJWTCfg("2025", testJWTKey2025))
+	oldTokens, err := oldService.IssueTokens(ctx, performer, &model.TokenClient{})
+	require.NoError(t, err)
+
+	rotated := NewAccessTokenService(newTestJWTCfg("2026", testJWTKey2025, testJWTKey2026), performerRepo, registry, &common.Logger{})
+	newTokens, err := rotated.IssueTokens(ctx, performer, &model.TokenClient{})
+	require.NoError(t, err)
+
+	_, ok := rotated.VerifyAccessToken(ctx, oldTokens.AccessToken)
+	assert.True(t, ok, "токен, подписанный прежним ключом, действует до удаления ключа")
+	_, ok = rotated.VerifyAccessToken(ctx, newTokens.AccessToken)
+	assert.True(t, ok)
+	_, ok = oldService.VerifyAccessToken(ctx, newTokens.AccessToken)
+	assert.False(t, ok, "новый ключ неизвестен экземпляру без него")
+
+	retired := NewAccessTokenService(newTestJWTCfg("2026", testJWTKey2026), performerRepo, registry, &common.Logger{})
+	_, ok = retired.VerifyAccessToken(ctx, oldTokens.AccessToken)
+	assert.False(t, ok, "прежний ключ удален")
+}
+
+func TestAccessTokenService_RefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	accessTokenService, performerRepo, registry := newAccessTokenService(newTestJWTCfg("2025", testJWTKey2025))
+
+	tokens, err := accessTokenService.IssueTokens(ctx, performerRepo.performers[1001], &model.TokenClient{DeviceType: "tsd"})
+	require.NoError(t, err)
+
+	sessions, err := registry.GetActiveSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1, "токен обновления виден в реестре сессий")
+	assert.Equal(t, "tsd", sessions[0].DeviceType)
+
+	performerRepo.performers[1001].IdRoleAForms = 3
+	refreshed, err := accessTokenService.RefreshTokens(ctx, tokens.RefreshToken, &model.TokenClient{})
+	require.NoError(t, err)
+	require.NotNil(t, refreshed)
+	assert.Equal(t, 3, refreshed.RoleId, "роли перечитываются при обновлении")
+
+	claims, ok := accessTokenService.VerifyAccessToken(ctx, refreshed.AccessToken)
+	require.True(t, ok)
+	assert.Equal(t, "tsd", claims.DeviceType, "тип устройства сохраняется")
+
+	again, err := accessTokenService.RefreshTokens(ctx, tokens.RefreshToken, &model.TokenClient{})
+	require.NoError(t, err)
+	assert.Nil(t, again, "использованный токен обновления")
+
+	_, ok = accessTokenService.VerifyAccessToken(ctx, tokens.AccessToken)
+	assert.False(t, ok, "токен доступа обмененного токена обновления")
+
+	_, err = registry.RevokePerformerSessions(ctx, 1001)
+	require.NoError(t, err)
+	again, err = accessTokenService.RefreshTokens(ctx, refreshed.RefreshToken, &model.TokenClient{})
+	require.NoError(t, err)
+	assert.Nil(t, again, "сессии сотрудника завершены администратором")
+	_, ok = accessTokenService.VerifyAccessToken(ctx, refreshed.AccessToken)
+	assert.False(t, ok, "токен доступа перестает действовать сразу после завершения сессий")
+
+	archived, err := accessTokenService.IssueTokens(ctx, performerRepo.performers[1003], &model.TokenClient{})
+	require.NoError(t, err)
+	_, ok = accessTokenService.VerifyAccessToken(ctx, archived.AccessToken)
+	require.True(t, ok)
+	performerRepo.performers[1003].Archive = true
+	_, ok = accessTokenService.VerifyAccessToken(ctx, archived.AccessToken)
+	assert.False(t, ok, "токен доступа сотрудника в архиве")
+	again, err = accessTokenService.RefreshTokens(ctx, archived.RefreshToken, &model.TokenClient{})
+	require.NoError(t, err)
+	assert.Nil(t, again, "сотрудник в архиве")
+	active, err := registry.CheckSession(ctx, archived.RefreshToken)
+	require.NoError(t, err)
+	assert.False(t, active, "сессия сотрудника в архиве отозвана")
+
+	assert.Error(t, accessTokenService.RevokeRefreshToken(ctx, tokens.AccessToken), "отзывается только токен обновления")
+}
+
+func TestAccessTokenService_RefreshTokens_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	accessTokenService, performerRepo, _ := newAccessTokenService(newTestJWTCfg("2025", testJWTKey2025))
+
+	tokens, err := accessTokenService.IssueTokens(ctx, performerRepo.performers[1001], &model.TokenClient{DeviceType: "tsd"})
+	require.NoError(t, err)
+
+	const refreshes = 10
+	var wg sync.WaitGroup
+	var issued atomic.Int32
+	for range refreshes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			refreshed, err := accessTokenService.RefreshTokens(ctx, tokens.RefreshToken, &model.TokenClient{})
+			assert.NoError(t, err)
+			if refreshed != nil {
+				issued.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), issued.Load(), "токен обновления обменивается один раз")
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"FGW_WEB/pkg/convert"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	galaktikaComma      = ';' // galaktikaComma - разделитель полей в выгрузке Галактики.
+	galaktikaMinFields  = 2   // galaktikaMinFields - табельный номер и ФИО обязательны.
+	aformsNameMaxLen    = 100 // aformsNameMaxLen - размер поля PfName.
+	aformsBarcodeMaxLen = 20  // aformsBarcodeMaxLen - размер поля PfBarcode.
+)
+
+type AFormsPerformerService struct {
+	aformsPerformerRepo repository.AFormsPerformerRepository
+	logg                *common.Logger
+}
+
+func NewAFormsPerformerService(aformsPerformerRepo repository.AFormsPerformerRepository, logger *common.Logger) *AFormsPerformerService {
+	return &AFormsPerformerService{aformsPerformerRepo: aformsPerformerRepo, logg: logger}
+}
+
+type AFormsPerformerUseCase interface {
+	GetAllAFormsPerformers(ctx context.Context) ([]*model.AFormsPerformer, error)
+	ImportGalaktika(ctx context.Context, src io.Reader, editUser int) (*model.ImportReport, error)
+	AssignSector(ctx context.Context, assign *model.SectorAssign) error
+	GetSectorNamesByTabnum(ctx context.Context) (map[int]string, error)
+}
+
+func (a *AFormsPerformerService) GetAllAFormsPerformers(ctx context.Context) ([]*model.AFormsPerformer, error) {
+	performers, err := a.aformsPerformerRepo.All(ctx)
+	if err != nil {
+		a.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return performers, nil
+}
+
+// AssignSector привязать сотрудников AForms к печке, все сотрудники привязываются одной транзакцией.
+func (a *AFormsPerformerService) AssignSector(ctx context.Context, assign *model.SectorAssign) error {
+	if err := model.ValidateSectorAssign(assign); err != nil {
+		a.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	if err := a.aformsPerformerRepo.UpdSectorByIds(ctx, assign.PerformerIds, assign.SectorId); err != nil {
+		a.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetSectorNamesByTabnum получить наименования привязанных печек по табельному номеру.
+func (a *AFormsPerformerService) GetSectorNamesByTabnum(ctx context.Context) (map[int]string, error) {
+	performers, err := a.GetAllAFormsPerformers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectors := make(map[int]string, len(performers))
+	for _, performer := range performers {
+		if performer.SectorId != 0 {
+			sectors[performer.Tabnum] = performer.SectorName
+		}
+	}
+
+	return sectors, nil
+}
+
+// ImportGalaktika импортирует сотрудников из выгрузки Галактики (CSV в Windows-1251, поля: ТН;ФИО;штрих-код).
+// Сотрудники сопоставляются по табельному номеру, пароль никогда не перезаписывается.
+func (a *AFormsPerformerService) ImportGalaktika(ctx context.Context, src io.Reader, editUser int) (*model.ImportReport, error) {
+	raw, err := io.ReadAll(src)
+	if err != nil {
+		a.logg.LogE(msg.E3219, err)
+
+		return nil, err
+	}
+
+	data, err := convert.Win1251ToUTF8(string(raw))
+	if err != nil {
+		a.logg.LogE(msg.E3219, err)
+
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comma = galaktikaComma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	report := &model.ImportReport{Errors: []string{}}
+	line := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+
+		if err != nil {
+			report.Skip(fmt.Sprintf("строка %d: %v", line, err))
+
+			continue
+		}
+
+		// Заголовок выгрузки не считаем пропущенной строкой.
+		if line == 1 && isGalaktikaHeader(record) {
+			continue
+		}
+
+		performer, reason := parseGalaktikaRecord(record, editUser)
+		if performer == nil {
+			report.Skip(fmt.Sprintf("строка %d: %s", line, reason))
+
+			continue
+		}
+
+		result, err := a.aformsPerformerRepo.UpsertByTabnum(ctx, performer)
+		if err != nil {
+			report.Skip(fmt.Sprintf("строка %d: ТН %d: %v", line, performer.Tabnum, err))
+
+			continue
+		}
+
+		switch result {
+		case model.UpsertCreated:
+			report.Created++
+		case model.UpsertUpdated:
+			report.Updated++
+		default:
+			report.Skip("")
+		}
+	}
+
+	return report, nil
+}
+
+// isGalaktikaHeader строка выгрузки является заголовком, если в первом поле не число.
+func isGalaktikaHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+
+	_, err := strconv.Atoi(strings.TrimSpace(record[0]))
+
+	return err != nil
+}
+
+// parseGalaktikaRecord разбирает строку выгрузки, при ошибке возвращает причину пропуска.
+func parseGalaktikaRecord(record []string, editUser int) (*model.AFormsPerformer, string) {
+	if len(record) < galaktikaMinFields {
+		return nil, "недостаточно полей"
+	}
+
+	tabnumStr := strings.TrimSpace(record[0])
+	tabnum, err := strconv.Atoi(tabnumStr)
+	if err != nil || tabnum <= 0 {
+		return nil, fmt.Sprintf("невалидный табельный номер %q", tabnumStr)
+	}
+
+	name := strings.TrimSpace(record[1])
+	if name == "" {
+		return nil, fmt.Sprintf("ТН %d: пустое ФИО", tabnum)
+	}
+
+	if utf8.RuneCountInString(name) > aformsNameMaxLen {
+		return nil, fmt.Sprintf("ТН %d: ФИО длиннее %d символов", tabnum, aformsNameMaxLen)
+	}
+
+	var barcode string
+	if len(record) > galaktikaMinFields {
+		barcode = strings.TrimSpace(record[2])
+	}
+
+	if len(barcode) > aformsBarcodeMaxLen {
+		return nil, fmt.Sprintf("ТН %d: штрих-код длиннее %d символов", tabnum, aformsBarcodeMaxLen)
+	}
+
+	return &model.AFormsPerformer{
+		Name:     name,
+		Barcode:  barcode,
+		EditUser: editUser,
+		Tabnum:   tabnum,
+	}, ""
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+)
+
+type fakeAFormsPerformerRepo struct {
+	existing map[int]*model.AFormsPerformer
+}
+
+func (f *fakeAFormsPerformerRepo) All(_ context.Context) ([]*model.AFormsPerformer, error) {
+	var performers []*model.AFormsPerformer
+	for _, performer := range f.existing {
+		performers = append(performers, performer)
+	}
+
+	return performers, nil
+}
+
+func (f *fakeAFormsPerformerRepo) UpsertByTabnum(_ context.Context, performer *model.AFormsPerformer) (int, error) {
+	current, ok := f.existing[performer.Tabnum]
+	if !ok {
+		f.existing[performer.Tabnum] = performer
+
+		return model.UpsertCreated, nil
+	}
+
+	if current.Name == performer.Name && current.Barcode == performer.Barcode {
+		return model.UpsertSkipped, nil
+	}
+
+	current.Name, current.Barcode = performer.Name, performer.Barcode
+
+	return model.UpsertUpdated, nil
+}
+
+func (f *fakeAFormsPerformerRepo) UpdSectorByIds(_ context.Context, ids []int, sectorId int) error {
+	for _, id := range ids {
+		for _, performer := range f.existing {
+			if performer.Id == id {
+				performer.SectorId = sectorId
+			}
+		}
+	}
+
+	return nil
+}
+
+func encodeWin1251(t *testing.T, s string) *bytes.Reader {
+	t.Helper()
+
+	data, err := charmap.Windows1251.NewEncoder().String(s)
+	require.NoError(t, err)
+
+	return bytes.NewReader([]byte(data))
+}
+
+func TestAFormsPerformerService_ImportGalaktika(t *testing.T) {
+	repo := &fakeAFormsPerformerRepo{existing: map[int]*model.AFormsPerformer{
+		100: {Tabnum: 100, Name: "Иванов Иван Иванович", Barcode: "0100"},
+		200: {Tabnum: 200, Name: "Петров Петр Петрович", Barcode: "0200"},
+	}}
+	svc := NewAFormsPerformerService(repo, &common.Logger{})
+
+	src := encodeWin1251(t, "Табельный номер;ФИО;Штрих-код\n"+
+		"100;Иванов Иван Иванович;0100\n"+
+		"200;Петров Петр Сергеевич;0200\n"+
+		"300;Сидоров Сидор Сидорович;0300\n"+
+		"abc;Без номера;\n"+
+		"400;;0400\n")
+
+	report, err := svc.ImportGalaktika(context.Background(), src, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 3, report.Skipped)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, "Сидоров Сидор Сидорович", repo.existing[300].Name)
+	assert.Equal(t, "Петров Петр Сергеевич", repo.existing[200].Name)
+}
+
+func TestAFormsPerformerService_AssignSector(t *testing.T) {
+	repo := &fakeAFormsPerformerRepo{existing: map[int]*model.AFormsPerformer{
+		100: {Id: 1, Tabnum: 100},
+		200: {Id: 2, Tabnum: 200},
+		300: {Id: 3, Tabnum: 300},
+	}}
+	svc := NewAFormsPerformerService(repo, &common.Logger{})
+
+	require.NoError(t, svc.AssignSector(context.Background(), &model.SectorAssign{PerformerIds: []int{1, 2}, SectorId: 5}))
+	assert.Equal(t, 5, repo.existing[100].SectorId)
+	assert.Equal(t, 5, repo.existing[200].SectorId)
+	assert.Equal(t, 0, repo.existing[300].SectorId)
+
+	assert.Error(t, svc.AssignSector(context.Background(), &model.SectorAssign{PerformerIds: []int{1, 0}, SectorId: 5}))
+}
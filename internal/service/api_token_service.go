@@ -0,0 +1,170 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	apiTokenPrefix        = "fgw_"      // apiTokenPrefix - префикс токена, чтобы токен было легко опознать в логах и конфигах.
+	apiTokenNameMaxLen    = 100         // apiTokenNameMaxLen - длина Name в svTB_ApiToken.
+	apiTokenTouchInterval = time.Minute // apiTokenTouchInterval - как часто записывать время последнего запроса.
+)
+
+type ApiTokenService struct {
+	apiTokenRepo  repository.ApiTokenRepository
+	performerRepo repository.PerformerRepository
+	logg          *common.Logger
+}
+
+func NewApiTokenService(
+	apiTokenRepo repository.ApiTokenRepository,
+	performerRepo repository.PerformerRepository,
+	logger *common.Logger) *ApiTokenService {
+
+	return &ApiTokenService{apiTokenRepo: apiTokenRepo, performerRepo: performerRepo, logg: logger}
+}
+
+type ApiTokenUseCase interface {
+	CreateApiToken(ctx context.Context, create *model.ApiTokenCreate, createdBy int) (*model.ApiTokenCreated, error)
+	AuthenticateApiToken(ctx context.Context, token string) (*model.ApiToken, error)
+	GetApiTokens(ctx context.Context) ([]*model.ApiToken, error)
+	RevokeApiToken(ctx context.Context, id int) error
+}
+
+// CreateApiToken выдать токен сотруднику. Токен возвращается один раз, в БД сохраняется только его хеш.
+func (a *ApiTokenService) CreateApiToken(ctx context.Context, create *model.ApiTokenCreate, createdBy int) (*model.ApiTokenCreated, error) {
+	name := strings.TrimSpace(create.Name)
+	if name == "" || utf8.RuneCountInString(name) > apiTokenNameMaxLen || create.ExpiresDays < 0 {
+		err := fmt.Errorf("%s: назначение токена до %d символов и срок действия не меньше 0", msg.E3213, apiTokenNameMaxLen)
+		a.logg.LogE(msg.E3213, err)
+
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(create.Scopes))
+	for _, scope := range create.Scopes {
+		if !slices.Contains(model.ApiTokenScopes, scope) {
+			err := fmt.Errorf("%s: неизвестная область доступа %q", msg.E3213, scope)
+			a.logg.LogE(msg.E3213, err)
+
+			return nil, err
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(scopes) == 0 {
+		err := fmt.Errorf("%s: нужна хотя бы одна область доступа", msg.E3213)
+		a.logg.LogE(msg.E3213, err)
+
+		return nil, err
+	}
+
+	exists, err := a.performerRepo.ExistById(ctx, create.PerformerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		err = fmt.Errorf("%s: сотрудник %d", msg.E3212, create.PerformerId)
+		a.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	secret := config.GenerateSessionToken()
+	if secret == "" {
+		err = fmt.Errorf("%s: не удалось сгенерировать токен", msg.E3215)
+		a.logg.LogE(msg.E3215, err)
+
+		return nil, err
+	}
+	token := apiTokenPrefix + secret
+
+	apiToken := &model.ApiToken{
+		Name:        name,
+		PerformerId: create.PerformerId,
+		Scopes:      scopes,
+		TokenHash:   model.ApiTokenHash(token),
+		CreatedAt:   time.Now(),
+		CreatedBy:   createdBy,
+	}
+
+	if create.ExpiresDays > 0 {
+		expiresAt := apiToken.CreatedAt.AddDate(0, 0, create.ExpiresDays)
+		apiToken.ExpiresAt = &expiresAt
+	}
+
+	if apiToken.Id, err = a.apiTokenRepo.Add(ctx, apiToken); err != nil {
+		return nil, err
+	}
+
+	return &model.ApiTokenCreated{
+		Success:  true,
+		Message:  "Токен выдан, сохраните его: повторно он не показывается",
+		Token:    token,
+		ApiToken: apiToken,
+	}, nil
+}
+
+// AuthenticateApiToken найти действующий токен, nil - токен не выдавался, отозван или истек.
+func (a *ApiTokenService) AuthenticateApiToken(ctx context.Context, token string) (*model.ApiToken, error) {
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return nil, nil
+	}
+
+	apiToken, err := a.apiTokenRepo.FindByHash(ctx, model.ApiTokenHash(token))
+	if err != nil || apiToken == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiToken.ExpiresAt != nil && !apiToken.ExpiresAt.After(now) {
+		return nil, nil
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) > apiTokenTouchInterval {
+		// Время последнего запроса справочное: ошибка записи не должна отклонять запрос.
+		if err = a.apiTokenRepo.Touch(ctx, apiToken.Id, now); err == nil {
+			apiToken.LastUsedAt = &now
+		}
+	}
+
+	return apiToken, nil
+}
+
+// GetApiTokens получить список токенов.
+func (a *ApiTokenService) GetApiTokens(ctx context.Context) ([]*model.ApiToken, error) {
+	tokens, err := a.apiTokenRepo.All(ctx)
+	if err != nil {
+		a.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeApiToken отозвать токен, запросы с ним сразу перестают проходить.
+func (a *ApiTokenService) RevokeApiToken(ctx context.Context, id int) error {
+	if id <= 0 {
+		err := fmt.Errorf("%s: ид токена %d", msg.E3213, id)
+		a.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	return a.apiTokenRepo.Revoke(ctx, id)
+}
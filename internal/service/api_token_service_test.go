@@ -0,0 +1,150 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeApiTokenRepo struct {
+	tokens  []*model.ApiToken
+	touched int
+}
+
+func (f *fakeApiTokenRepo) Add(_ context.Context, token *model.ApiToken) (int, error) {
+	f.tokens = append(f.tokens, token)
+
+	return len(f.tokens), nil
+}
+
+func (f *fakeApiTokenRepo) FindByHash(_ context.Context, tokenHash string) (*model.ApiToken, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash == tokenHash && token.RevokedAt == nil {
+			return token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeApiTokenRepo) All(_ context.Context) ([]*model.ApiToken, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeApiTokenRepo) Revoke(_ context.Context, id int) error {
+	now := time.Now()
+	f.tokens[id-1].RevokedAt = &now
+
+	return nil
+}
+
+func (f *fakeApiTokenRepo) Touch(_ context.Context, _ int, _ time.Time) error {
+	f.touched++
+
+	return nil
+}
+
+// fakeApiTokenPerformerRepo репозиторий сотрудников, неиспользуемые методы не реализованы.
+type fakeApiTokenPerformerRepo struct {
+	repository.PerformerRepository
+}
+
+func (f *fakeApiTokenPerformerRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return id == 1001, nil
+}
+
+func newApiTokenService() (*ApiTokenService, *fakeApiTokenRepo) {
+	repo := &fakeApiTokenRepo{}
+
+	return NewApiTokenService(repo, &fakeApiTokenPerformerRepo{}, &common.Logger{}), repo
+}
+
+func TestApiTokenService_CreateApiToken(t *testing.T) {
+	ctx := context.Background()
+	apiTokenService, repo := newApiTokenService()
+
+	created, err := apiTokenService.CreateApiToken(ctx, &model.ApiTokenCreate{
+		Name:        " выгрузка ",
+		PerformerId: 1001,
+		Scopes:      []string{model.ScopeProductsRead, model.ScopeProductsRead, model.ScopeRolesRead},
+		ExpiresDays: 30,
+	}, 1)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, apiTokenPrefix))
+	assert.Equal(t, "выгрузка", created.ApiToken.Name)
+	assert.Equal(t, []string{model.ScopeProductsRead, model.ScopeRolesRead}, created.ApiToken.Scopes, "области без повторов")
+	require.NotNil(t, created.ApiToken.ExpiresAt)
+
+	require.Len(t, repo.tokens, 1)
+	assert.Equal(t, model.ApiTokenHash(created.Token), repo.tokens[0].TokenHash)
+	assert.NotContains(t, repo.tokens[0].TokenHash, created.Token, "в БД хранится только хеш токена")
+
+	tests := []struct {
+		name   string
+		create *model.ApiTokenCreate
+	}{
+		{name: "без назначения", create: &model.ApiTokenCreate{PerformerId: 1001, Scopes: []string{model.ScopeRolesRead}}},
+		{name: "без областей", create: &model.ApiTokenCreate{Name: "т", PerformerId: 1001}},
+		{name: "неизвестная область", create: &model.ApiTokenCreate{Name: "т", PerformerId: 1001, Scopes: []string{"admin"}}},
+		{name: "неизвестный сотрудник", create: &model.ApiTokenCreate{Name: "т", PerformerId: 7, Scopes: []string{model.ScopeRolesRead}}},
+		{name: "отрицательный срок", create: &model.ApiTokenCreate{Name: "т", PerformerId: 1001, Scopes: []string{model.ScopeRolesRead}, ExpiresDays: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := apiTokenService.CreateApiToken(ctx, tt.create, 1)
+			assert.Error(t, err)
+		})
+	}
+
+	assert.Len(t, repo.tokens, 1)
+}
+
+func TestApiTokenService_AuthenticateApiToken(t *testing.T) {
+	ctx := context.Background()
+	apiTokenService, repo := newApiTokenService()
+
+	created, err := apiTokenService.CreateApiToken(ctx, &model.ApiTokenCreate{
+		Name: "интеграция", PerformerId: 1001, Scopes: []string{model.ScopePerformersRead},
+	}, 1)
+	require.NoError(t, err)
+	assert.Nil(t, created.ApiToken.ExpiresAt, "бессрочный токен")
+
+	apiToken, err := apiTokenService.AuthenticateApiToken(ctx, created.Token)
+	require.NoError(t, err)
+	require.NotNil(t, apiToken)
+	assert.Equal(t, 1001, apiToken.PerformerId)
+	assert.True(t, apiToken.HasScope(model.ScopePerformersRead))
+	assert.False(t, apiToken.HasScope(model.ScopePerformersWrite))
+
+	_, err = apiTokenService.AuthenticateApiToken(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.touched, "время последнего запроса пишется не чаще раза в минуту")
+
+	for _, token := range []string{"", "fgw_unknown", strings.TrimPrefix(created.Token, apiTokenPrefix)} {
+		apiToken, err = apiTokenService.AuthenticateApiToken(ctx, token)
+		require.NoError(t, err)
+		assert.Nil(t, apiToken, token)
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	repo.tokens[0].ExpiresAt = &expired
+	apiToken, err = apiTokenService.AuthenticateApiToken(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Nil(t, apiToken, "истекший токен")
+
+	repo.tokens[0].ExpiresAt = nil
+	require.NoError(t, apiTokenService.RevokeApiToken(ctx, created.ApiToken.Id))
+	apiToken, err = apiTokenService.AuthenticateApiToken(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Nil(t, apiToken, "отозванный токен")
+
+	assert.Error(t, apiTokenService.RevokeApiToken(ctx, 0))
+}
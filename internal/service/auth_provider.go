@@ -0,0 +1,61 @@
+package service
+
+import (
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// AuthProvider способ проверки пароля сотрудника по табельному номеру.
+type AuthProvider interface {
+	// Name имя способа для журнала.
+	Name() string
+	// VerifyPassword false - пароль не подошел или учетной записи нет, ошибка - проверить пароль не удалось.
+	VerifyPassword(ctx context.Context, id int, password string) (bool, error)
+}
+
+// DBAuthProvider проверка пароля из svPerformers. Пароль, хранящийся в открытом виде, после успешной проверки
+// заменяется хешем.
+type DBAuthProvider struct {
+	performerRepo repository.PerformerRepository
+	logg          *common.Logger
+}
+
+func NewDBAuthProvider(performerRepo repository.PerformerRepository, logger *common.Logger) *DBAuthProvider {
+	return &DBAuthProvider{performerRepo: performerRepo, logg: logger}
+}
+
+func (d *DBAuthProvider) Name() string {
+	return "db"
+}
+
+func (d *DBAuthProvider) VerifyPassword(ctx context.Context, id int, password string) (bool, error) {
+	stored, err := d.performerRepo.FindPassById(ctx, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	authOK, legacy := verifyPassword(stored, password)
+	if authOK && legacy {
+		d.upgradePassword(ctx, id, password)
+	}
+
+	return authOK, nil
+}
+
+// upgradePassword заменить пароль в открытом виде хешем. Ошибка не мешает входу: пароль обновится при следующем.
+func (d *DBAuthProvider) upgradePassword(ctx context.Context, id int, password string) {
+	hash, err := hashPassword(password)
+	if err != nil {
+		d.logg.LogE(msg.E3216, err)
+
+		return
+	}
+
+	if err = d.performerRepo.UpdPassById(ctx, id, hash); err != nil {
+		d.logg.LogE(msg.E3216, err)
+	}
+}
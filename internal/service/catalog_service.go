@@ -0,0 +1,63 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+)
+
+type CatalogService struct {
+	catalogRepo repository.CatalogRepository
+	palletRepo  repository.PalletRepository
+	logg        *common.Logger
+}
+
+func NewCatalogService(catalogRepo repository.CatalogRepository, palletRepo repository.PalletRepository, logger *common.Logger) *CatalogService {
+	return &CatalogService{catalogRepo: catalogRepo, palletRepo: palletRepo, logg: logger}
+}
+
+type CatalogUseCase interface {
+	GetStorageAreas(ctx context.Context) ([]*model.StorageArea, error)
+	GetStorageAreaOccupancy(ctx context.Context) ([]*model.StorageAreaOccupancy, error)
+}
+
+// GetStorageAreas получить участки хранения с вместимостью.
+func (c *CatalogService) GetStorageAreas(ctx context.Context) ([]*model.StorageArea, error) {
+	catalogs, err := c.catalogRepo.AllByKodcat(ctx, model.KodcatStorageArea)
+	if err != nil {
+		c.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	areas := make([]*model.StorageArea, 0, len(catalogs))
+	for _, catalog := range catalogs {
+		areas = append(areas, model.NewStorageArea(catalog))
+	}
+
+	return areas, nil
+}
+
+// GetStorageAreaOccupancy заполненность участков хранения по п\п на складе, размещенным на участке.
+func (c *CatalogService) GetStorageAreaOccupancy(ctx context.Context) ([]*model.StorageAreaOccupancy, error) {
+	areas, err := c.GetStorageAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := c.palletRepo.CountByStorageArea(ctx)
+	if err != nil {
+		c.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	occupancy := make([]*model.StorageAreaOccupancy, 0, len(areas))
+	for _, area := range areas {
+		occupancy = append(occupancy, model.NewStorageAreaOccupancy(area, counts[area.Id]))
+	}
+
+	return occupancy, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogService_GetStorageAreaOccupancy(t *testing.T) {
+	catalogs := &fakeCatalogRepo{catalogs: []*model.Catalog{
+		{Id: 1, Kodcat: model.KodcatStorageArea, Name: "Площадка 1", DopFloat2: 10},
+		{Id: 2, Kodcat: model.KodcatStorageArea, Name: "Площадка 2", DopFloat2: 10, DopFloat1: 80},
+		{Id: 3, Kodcat: model.KodcatStorageArea, Name: "Площадка 3", DopFloat2: 4},
+		{Id: 4, Kodcat: model.KodcatStorageArea, Name: "Площадка 4"},
+	}}
+
+	pallets := &fakePalletRepo{
+		pallets: []*model.Pallet{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}, {Id: 5}, {Id: 6}, {Id: 7}, {Id: 8}},
+		placed:  map[int]int{1: 1, 2: 2, 3: 2, 4: 2, 5: 2, 6: 2, 7: 2, 8: 3},
+		shipped: map[int]bool{8: true},
+	}
+
+	svc := NewCatalogService(catalogs, pallets, &common.Logger{})
+	occupancy, err := svc.GetStorageAreaOccupancy(context.Background())
+	require.NoError(t, err)
+	require.Len(t, occupancy, 4)
+
+	assert.Equal(t, 1, occupancy[0].Pallets)
+	assert.Equal(t, 10, occupancy[0].Percent())
+	assert.Equal(t, 9.0, occupancy[0].FreeSpace)
+	assert.Equal(t, model.OccupancyLow, occupancy[0].Level)
+
+	// Вместимость 10 при 80% использования: 6 п\п из 8 мест.
+	assert.Equal(t, 6, occupancy[1].Pallets)
+	assert.Equal(t, 75, occupancy[1].Percent())
+	assert.Equal(t, model.OccupancyMedium, occupancy[1].Level)
+
+	assert.Equal(t, 0, occupancy[2].Pallets, "отгруженный п\\п не занимает место")
+	assert.Equal(t, model.OccupancyLow, occupancy[2].Level)
+
+	assert.Equal(t, model.OccupancyUnknown, occupancy[3].Level, "вместимость не задана")
+}
+
+func TestNewStorageAreaOccupancy_High(t *testing.T) {
+	area := model.NewStorageArea(&model.Catalog{Id: 1, DopFloat2: 10})
+
+	occupancy := model.NewStorageAreaOccupancy(area, 12)
+	assert.Equal(t, model.OccupancyHigh, occupancy.Level)
+	assert.Equal(t, 120, occupancy.Percent())
+	assert.Equal(t, 0.0, occupancy.FreeSpace)
+}
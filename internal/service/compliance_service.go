@@ -0,0 +1,131 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+	"time"
+)
+
+type ComplianceService struct {
+	declarationRepo repository.DeclarationRepository
+	productRepo     repository.ProductRepository
+	logg            *common.Logger
+}
+
+func NewComplianceService(
+	declarationRepo repository.DeclarationRepository,
+	productRepo repository.ProductRepository,
+	logger *common.Logger) *ComplianceService {
+
+	return &ComplianceService{declarationRepo: declarationRepo, productRepo: productRepo, logg: logger}
+}
+
+type ComplianceUseCase interface {
+	AddDeclaration(ctx context.Context, declaration *model.Declaration, performerId int) error
+	GetDeclarations(ctx context.Context, productId int) ([]*model.Declaration, error)
+	GetExpiringDeclarations(ctx context.Context, days int) ([]*model.Declaration, error)
+	CheckLabelPrint(ctx context.Context, productId int) (*model.LabelPrintCheck, error)
+}
+
+// AddDeclaration прикрепить декларацию к декларируемой продукции.
+func (c *ComplianceService) AddDeclaration(ctx context.Context, declaration *model.Declaration, performerId int) error {
+	if err := model.ValidateDeclaration(declaration); err != nil {
+		c.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	product, err := c.findProduct(ctx, declaration.ProductId)
+	if err != nil {
+		return err
+	}
+
+	if !product.Declared() {
+		err = fmt.Errorf("%s: продукция %q", msg.E3228, product.Article)
+		c.logg.LogE(msg.E3228, err)
+
+		return err
+	}
+
+	declaration.AuditRec.CreatedBy = performerId
+
+	if err = c.declarationRepo.Add(ctx, declaration); err != nil {
+		c.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetDeclarations получить декларации продукции.
+func (c *ComplianceService) GetDeclarations(ctx context.Context, productId int) ([]*model.Declaration, error) {
+	declarations, err := c.declarationRepo.AllByProduct(ctx, productId)
+	if err != nil {
+		c.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return declarations, nil
+}
+
+// GetExpiringDeclarations отчет по декларируемой продукции: декларация истекает в течение days дней, уже истекла или
+// отсутствует. days <= 0 - горизонт по умолчанию.
+func (c *ComplianceService) GetExpiringDeclarations(ctx context.Context, days int) ([]*model.Declaration, error) {
+	if days <= 0 {
+		days = model.DeclExpiringDefaultDays
+	}
+
+	declarations, err := c.declarationRepo.Expiring(ctx, days)
+	if err != nil {
+		c.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	for _, declaration := range declarations {
+		declaration.SetStatus()
+	}
+
+	return declarations, nil
+}
+
+// CheckLabelPrint проверка перед печатью этикетки: архивная продукция не печатается, декларируемая (PrDecl или PrType) -
+// только при действующей декларации. Отказ не ошибка, пишется предупреждение.
+func (c *ComplianceService) CheckLabelPrint(ctx context.Context, productId int) (*model.LabelPrintCheck, error) {
+	product, err := c.findProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	var declarations []*model.Declaration
+	if product.Declared() {
+		if declarations, err = c.GetDeclarations(ctx, productId); err != nil {
+			return nil, err
+		}
+	}
+
+	check := model.CheckLabelPrint(product, declarations, time.Now())
+	if !check.Allowed {
+		c.logg.LogW(fmt.Sprintf("печать этикетки продукции %q запрещена: %s", product.Article, check.Reason))
+	}
+
+	return check, nil
+}
+
+func (c *ComplianceService) findProduct(ctx context.Context, productId int) (*model.Product, error) {
+	product, err := c.productRepo.FindById(ctx, productId)
+	if err != nil || product == nil {
+		err = fmt.Errorf("%s: продукция %d", msg.E3212, productId)
+		c.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	return product, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeclarationRepo struct {
+	declarations map[int][]*model.Declaration
+	expiring     []*model.Declaration
+	expiringDays int
+}
+
+func (f *fakeDeclarationRepo) Add(_ context.Context, declaration *model.Declaration) error {
+	f.declarations[declaration.ProductId] = append(f.declarations[declaration.ProductId], declaration)
+
+	return nil
+}
+
+func (f *fakeDeclarationRepo) AllByProduct(_ context.Context, productId int) ([]*model.Declaration, error) {
+	return f.declarations[productId], nil
+}
+
+func (f *fakeDeclarationRepo) Expiring(_ context.Context, days int) ([]*model.Declaration, error) {
+	f.expiringDays = days
+
+	return f.expiring, nil
+}
+
+func newComplianceFixture() (*ComplianceService, *fakeDeclarationRepo) {
+	products := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Article: "A0001", Decl: true},
+		2: {Id: 2, Article: "A0002"},
+		3: {Id: 3, Article: "A0003", Decl: true},
+		4: {Id: 4, Article: "A0004", Archive: true},
+		5: {Id: 5, Article: "A0005", Type: " декларированная"},
+	}}
+	declarations := &fakeDeclarationRepo{declarations: map[int][]*model.Declaration{}}
+
+	return NewComplianceService(declarations, products, &common.Logger{}), declarations
+}
+
+func TestComplianceService_AddDeclaration(t *testing.T) {
+	svc, declarations := newComplianceFixture()
+	ctx := context.Background()
+
+	err := svc.AddDeclaration(ctx, &model.Declaration{
+		ProductId: 1, Number: " ЕАЭС N RU Д-RU.1 ", ValidFrom: "2026-01-01", ValidTo: "2027-01-01"}, 7)
+	require.NoError(t, err)
+	require.Len(t, declarations.declarations[1], 1)
+	assert.Equal(t, "ЕАЭС N RU Д-RU.1", declarations.declarations[1][0].Number)
+	assert.Equal(t, 7, declarations.declarations[1][0].AuditRec.CreatedBy)
+
+	err = svc.AddDeclaration(ctx, &model.Declaration{
+		ProductId: 2, Number: "D-2", ValidFrom: "2026-01-01", ValidTo: "2027-01-01"}, 7)
+	assert.Error(t, err, "продукция не декларируемая")
+
+	err = svc.AddDeclaration(ctx, &model.Declaration{
+		ProductId: 1, Number: "D-1", ValidFrom: "2027-01-01", ValidTo: "2026-01-01"}, 7)
+	assert.Error(t, err, "дата окончания раньше даты начала")
+
+	err = svc.AddDeclaration(ctx, &model.Declaration{
+		ProductId: 99, Number: "D-1", ValidFrom: "2026-01-01", ValidTo: "2027-01-01"}, 7)
+	assert.Error(t, err, "продукции нет")
+}
+
+func TestComplianceService_CheckLabelPrint(t *testing.T) {
+	svc, declarations := newComplianceFixture()
+	ctx := context.Background()
+	today := time.Now()
+
+	declarations.declarations[1] = []*model.Declaration{{
+		Id: 1, ProductId: 1,
+		ValidFrom: today.AddDate(-1, 0, 0).Format("2006-01-02"),
+		ValidTo:   today.Format("2006-01-02"),
+	}}
+	declarations.declarations[3] = []*model.Declaration{{
+		Id: 2, ProductId: 3,
+		ValidFrom: today.AddDate(-2, 0, 0).Format("2006-01-02"),
+		ValidTo:   today.AddDate(0, 0, -1).Format("2006-01-02"),
+	}}
+
+	// Новая декларация еще не начала действовать, действует прежняя - истекшая.
+	declarations.declarations[3] = append(declarations.declarations[3], &model.Declaration{
+		Id: 3, ProductId: 3,
+		ValidFrom: today.AddDate(0, 0, 1).Format("2006-01-02"),
+		ValidTo:   today.AddDate(2, 0, 0).Format("2006-01-02"),
+	})
+
+	tests := []struct {
+		productId int
+		allowed   bool
+	}{
+		{productId: 1, allowed: true},  // декларация действует по сегодняшний день включительно.
+		{productId: 2, allowed: true},  // продукция не декларируемая.
+		{productId: 3, allowed: false}, // декларация истекла вчера, следующая начнет действовать завтра.
+		{productId: 4, allowed: false}, // продукция в архиве.
+		{productId: 5, allowed: false}, // декларируемая по PrType, декларации нет.
+	}
+
+	for _, tt := range tests {
+		check, err := svc.CheckLabelPrint(ctx, tt.productId)
+		require.NoError(t, err)
+		assert.Equal(t, tt.allowed, check.Allowed, "продукция %d", tt.productId)
+		if !tt.allowed {
+			assert.NotEmpty(t, check.Reason)
+		}
+	}
+
+	_, err := svc.CheckLabelPrint(ctx, 99)
+	assert.Error(t, err)
+}
+
+func TestComplianceService_GetExpiringDeclarations(t *testing.T) {
+	svc, declarations := newComplianceFixture()
+	declarations.expiring = []*model.Declaration{
+		{Id: 0, ProductId: 1, DaysLeft: -1},
+		{Id: 2, ProductId: 3, DaysLeft: -5},
+		{Id: 3, ProductId: 5, DaysLeft: 10},
+	}
+
+	result, err := svc.GetExpiringDeclarations(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeclExpiringDefaultDays, declarations.expiringDays)
+	assert.Equal(t, model.DeclStatusMissing, result[0].Status)
+	assert.Equal(t, model.DeclStatusExpired, result[1].Status)
+	assert.Equal(t, model.DeclStatusExpiring, result[2].Status)
+
+	_, err = svc.GetExpiringDeclarations(context.Background(), 60)
+	require.NoError(t, err)
+	assert.Equal(t, 60, declarations.expiringDays)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const jwtAlgHS256 = "HS256" // jwtAlgHS256 - единственный принимаемый алгоритм подписи.
+
+var errJWTInvalid = errors.New("JWT: неверный формат, алгоритм, ключ или подпись")
+
+// jwtHeader заголовок JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"` // Kid - ид ключа подписи.
+}
+
+// signJWT подписать утверждения claims ключом key с ид kid.
+func signJWT(kid string, key []byte, claims any) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: jwtAlgHS256, Typ: "JWT", Kid: kid})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(jwtSignature(key, unsigned)), nil
+}
+
+// parseJWT проверить подпись токена ключом из его заголовка и прочитать утверждения в claims. Сроки действия
+// проверяет вызывающий.
+func parseJWT(token string, keys map[string][]byte, claims any) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errJWTInvalid
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errJWTInvalid
+	}
+
+	var header jwtHeader
+	if err = json.Unmarshal(headerJSON, &header); err != nil || header.Alg != jwtAlgHS256 {
+		return errJWTInvalid
+	}
+
+	key, ok := keys[header.Kid]
+	if !ok {
+		return errJWTInvalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, jwtSignature(key, parts[0]+"."+parts[1])) {
+		return errJWTInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(payload, claims) != nil {
+		return errJWTInvalid
+	}
+
+	return nil
+}
+
+// jwtSignature подпись HMAC-SHA256.
+func jwtSignature(key []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/pkg/common"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapConn подключение к серверу LDAP, в тестах подменяется каталогом в памяти.
+type ldapConn interface {
+	Bind(username, password string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// LDAPAuthProvider проверка пароля доменной учетной записи: учетная запись ищется по табельному номеру в атрибуте
+// LDAP_TABNUM_ATTR, пароль проверяется подключением (bind) под ней.
+type LDAPAuthProvider struct {
+	cfg  *config.LDAPCfg
+	dial func() (ldapConn, error)
+	logg *common.Logger
+}
+
+func NewLDAPAuthProvider(cfg *config.LDAPCfg, logger *common.Logger) *LDAPAuthProvider {
+	provider := &LDAPAuthProvider{cfg: cfg, logg: logger}
+	provider.dial = provider.dialServer
+
+	return provider
+}
+
+func (l *LDAPAuthProvider) Name() string {
+	return "ldap"
+}
+
+func (l *LDAPAuthProvider) VerifyPassword(_ context.Context, id int, password string) (bool, error) {
+	// Подключение с пустым паролем сервер считает анонимным и принимает.
+	if id <= 0 || password == "" {
+		return false, nil
+	}
+
+	conn, err := l.dial()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if l.cfg.BindDN != "" {
+		if err = conn.Bind(l.cfg.BindDN, l.cfg.BindPassword); err != nil {
+			return false, fmt.Errorf("учетная запись для поиска: %w", err)
+		}
+	}
+
+	tabnum := strconv.Itoa(id)
+	result, err := conn.Search(ldap.NewSearchRequest(
+		l.cfg.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // две записи достаточно, чтобы отличить единственную от неоднозначной
+		int(l.cfg.Timeout.Seconds()),
+		false,
+		fmt.Sprintf("(&%s(%s=%s))", l.cfg.UserFilter, l.cfg.TabnumAttr, ldap.EscapeFilter(tabnum)),
+		[]string{l.cfg.TabnumAttr},
+		nil,
+	))
+	if err != nil {
+		return false, err
+	}
+
+	if len(result.Entries) != 1 {
+		if len(result.Entries) > 1 {
+			l.logg.LogW(fmt.Sprintf("LDAP: табельный номер %s указан у нескольких учетных записей", tabnum))
+		}
+
+		return false, nil
+	}
+
+	entry := result.Entries[0]
+	if strings.TrimSpace(entry.GetAttributeValue(l.cfg.TabnumAttr)) != tabnum {
+		return false, nil
+	}
+
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// dialServer подключиться к серверу LDAP_URL, при LDAP_START_TLS перейти на TLS.
+func (l *LDAPAuthProvider) dialServer() (ldapConn, error) {
+	conn, err := ldap.DialURL(l.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: l.cfg.Timeout}))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(l.cfg.Timeout)
+
+	if l.cfg.StartTLS {
+		serverURL, err := url.Parse(l.cfg.URL)
+		if err == nil {
+			err = conn.StartTLS(&tls.Config{ServerName: serverURL.Hostname(), MinVersion: tls.VersionTLS12})
+		}
+
+		if err != nil {
+			_ = conn.Close()
+
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/pkg/common"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLDAPEntry учетная запись каталога.
+type fakeLDAPEntry struct {
+	dn       string
+	attrs    map[string]string
+	password string
+}
+
+// fakeLDAPDirectory каталог в памяти вместо сервера LDAP: поиск по равенству атрибута, bind по паролю записи.
+type fakeLDAPDirectory struct {
+	entries []*fakeLDAPEntry
+	down    bool
+	filters []string
+}
+
+func (f *fakeLDAPDirectory) Bind(username, password string) error {
+	for _, entry := range f.entries {
+		if entry.dn == username && entry.password == password {
+			return nil
+		}
+	}
+
+	return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+}
+
+func (f *fakeLDAPDirectory) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	f.filters = append(f.filters, req.Filter)
+
+	result := &ldap.SearchResult{}
+	for _, entry := range f.entries {
+		if !strings.HasSuffix(entry.dn, req.BaseDN) {
+			continue
+		}
+
+		for attr, value := range entry.attrs {
+			if strings.Contains(req.Filter, "("+attr+"="+value+")") {
+				result.Entries = append(result.Entries, ldap.NewEntry(entry.dn, map[string][]string{attr: {value}}))
+
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeLDAPDirectory) Close() error {
+	return nil
+}
+
+func newLDAPAuthProvider(directory *fakeLDAPDirectory) *LDAPAuthProvider {
+	provider := NewLDAPAuthProvider(&config.LDAPCfg{
+		BindDN:       "CN=svc-fgw,OU=Service,DC=corp,DC=local",
+		BindPassword: "svc-secret",
+		BaseDN:       "OU=Staff,DC=corp,DC=local",
+		TabnumAttr:   "employeeID",
+		UserFilter:   "(objectClass=person)",
+		Timeout:      time.Second,
+	}, &common.Logger{})
+
+	provider.dial = func() (ldapConn, error) {
+		if directory.down {
+			return nil, errors.New("сервер LDAP недоступен")
+		}
+
+		return directory, nil
+	}
+
+	return provider
+}
+
+func newFakeLDAPDirectory() *fakeLDAPDirectory {
+	return &fakeLDAPDirectory{entries: []*fakeLDAPEntry{
+		{dn: "CN=svc-fgw,OU=Service,DC=corp,DC=local", password: "svc-secret"},
+		{dn: "CN=Иванов И.И.,OU=Staff,DC=corp,DC=local", attrs: map[string]string{"employeeID": "1001"}, password: "Domain#1001"},
+		{dn: "CN=Дубль 1,OU=Staff,DC=corp,DC=local", attrs: map[string]string{"employeeID": "1005"}, password: "Domain#1005"},
+		{dn: "CN=Дубль 2,OU=Staff,DC=corp,DC=local", attrs: map[string]string{"employeeID": "1005"}, password: "Domain#1005"},
+		{dn: "CN=Петров П.П.,OU=Other,DC=corp,DC=local", attrs: map[string]string{"employeeID": "1002"}, password: "Domain#1002"},
+	}}
+}
+
+func TestLDAPAuthProvider_VerifyPassword(t *testing.T) {
+	ctx := context.Background()
+	directory := newFakeLDAPDirectory()
+	provider := newLDAPAuthProvider(directory)
+
+	ok, err := provider.VerifyPassword(ctx, 1001, "Domain#1001")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "(&(objectClass=person)(employeeID=1001))", directory.filters[0])
+
+	tests := []struct {
+		name     string
+		id       int
+		password string
+	}{
+		{name: "неверный пароль", id: 1001, password: "wrong"},
+		{name: "пустой пароль", id: 1001, password: ""},
+		{name: "нет учетной записи", id: 1003, password: "Domain#1001"},
+		{name: "несколько учетных записей", id: 1005, password: "Domain#1005"},
+		{name: "вне LDAP_BASE_DN", id: 1002, password: "Domain#1002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := provider.VerifyPassword(ctx, tt.id, tt.password)
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+
+	directory.entries[0].password = "expired"
+	_, err = provider.VerifyPassword(ctx, 1001, "Domain#1001")
+	assert.Error(t, err, "учетная запись для поиска не подошла")
+
+	directory.down = true
+	_, err = provider.VerifyPassword(ctx, 1001, "Domain#1001")
+	assert.Error(t, err)
+}
+
+func TestPerformerService_AuthProviders(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakePerformerRepo()
+	directory := newFakeLDAPDirectory()
+	svc := NewPerformerService(repo, &common.Logger{}, newLDAPAuthProvider(directory), NewDBAuthProvider(repo, &common.Logger{}))
+
+	result, err := svc.AuthPerformer(ctx, 1001, "Domain#1001")
+	require.NoError(t, err)
+	assert.True(t, result.Success, "доменный пароль")
+	assert.Equal(t, 1001, result.Performer.Id)
+
+	result, err = svc.AuthPerformer(ctx, 1001, "1001")
+	require.NoError(t, err)
+	assert.True(t, result.Success, "пароль из БД после отказа каталога")
+
+	result, err = svc.AuthPerformer(ctx, 1001, "wrong")
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+
+	directory.down = true
+	result, err = svc.AuthPerformer(ctx, 1003, "1003")
+	require.NoError(t, err)
+	assert.True(t, result.Success, "каталог недоступен, вход по паролю из БД")
+
+	result, err = svc.AuthPerformer(ctx, 1003, "wrong")
+	require.NoError(t, err, "БД отклонила пароль: это отказ, хотя каталог недоступен")
+	assert.False(t, result.Success)
+
+	ldapDown := NewPerformerService(repo, &common.Logger{}, newLDAPAuthProvider(directory))
+	result, err = ldapDown.AuthPerformer(ctx, 1003, "1003")
+	assert.Error(t, err, "ошибка каталога возвращается, если пароль не проверил никто")
+	assert.False(t, result.Success)
+
+	ldapOnly := NewPerformerService(repo, &common.Logger{}, newLDAPAuthProvider(newFakeLDAPDirectory()))
+	result, err = ldapOnly.AuthPerformer(ctx, 1001, "1001")
+	require.NoError(t, err)
+	assert.False(t, result.Success, "только каталог: пароль из БД не принимается")
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// loginAttempts неудачные попытки входа по табельному номеру или адресу клиента.
+type loginAttempts struct {
+	kind         string
+	value        string
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time // blockedUntil - пауза перед следующей попыткой.
+	lockedUntil  time.Time // lockedUntil - блокировка после maxFailures попыток.
+}
+
+// LoginThrottleService защита входа по паролю и бейджу от подбора: после каждой неудачной попытки пауза перед
+// следующей удваивается, после MaxFailures попыток вход блокируется на Lockout. Учет ведется отдельно по табельному
+// номеру и по адресу клиента в памяти процесса, неудачные входы по бейджу учитываются только по адресу. Устаревшие
+// счетчики удаляются не реже раза в Lockout.
+type LoginThrottleService struct {
+	cfg       *config.LoginThrottleCfg
+	mu        sync.Mutex
+	attempts  map[string]*loginAttempts
+	lastSweep time.Time // lastSweep - время последней очистки устаревших счетчиков.
+	logg      *common.Logger
+}
+
+func NewLoginThrottleService(cfg *config.LoginThrottleCfg, logger *common.Logger) *LoginThrottleService {
+	return &LoginThrottleService{cfg: cfg, attempts: make(map[string]*loginAttempts), logg: logger}
+}
+
+type LoginThrottleUseCase interface {
+	CheckLogin(performerId int, addr string) *model.LoginThrottle
+	LoginFailed(performerId int, addr string)
+	LoginSucceeded(performerId int)
+	CheckBadgeLogin(addr string) *model.LoginThrottle
+	BadgeLoginFailed(addr string)
+	UnlockLogin(kind, value string) bool
+	GetLockouts() []*model.LoginLockout
+}
+
+// CheckLogin можно ли сейчас пробовать войти под табельным номером с адреса клиента.
+func (l *LoginThrottleService) CheckLogin(performerId int, addr string) *model.LoginThrottle {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	return l.check(now,
+		l.getAttempts(model.LoginLockPerformer, strconv.Itoa(performerId), now),
+		l.getAttempts(model.LoginLockAddr, addr, now))
+}
+
+// CheckBadgeLogin можно ли сейчас пробовать войти по бейджу с адреса клиента.
+func (l *LoginThrottleService) CheckBadgeLogin(addr string) *model.LoginThrottle {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	return l.check(now, l.getAttempts(model.LoginLockAddr, addr, now))
+}
+
+// check итог проверки по счетчикам попыток, nil - попыток не было.
+func (l *LoginThrottleService) check(now time.Time, counters ...*loginAttempts) *model.LoginThrottle {
+	result := &model.LoginThrottle{Allowed: true}
+
+	for _, attempts := range counters {
+		if attempts == nil {
+			continue
+		}
+
+		if wait := attempts.lockedUntil.Sub(now); wait > 0 {
+			result.Allowed = false
+			result.Locked = true
+			result.RetryAfter = max(result.RetryAfter, wait)
+		} else if wait = attempts.blockedUntil.Sub(now); wait > 0 {
+			result.Allowed = false
+			result.RetryAfter = max(result.RetryAfter, wait)
+		}
+	}
+
+	if !result.Allowed {
+		code := msg.W4003
+		if result.Locked {
+			code = msg.W4000
+		}
+		result.Message = fmt.Sprintf("%s Повторите через %s.", code, result.RetryAfter.Round(time.Second))
+	}
+
+	return result
+}
+
+// LoginFailed учесть неудачную попытку входа.
+func (l *LoginThrottleService) LoginFailed(performerId int, addr string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.failed(model.LoginLockPerformer, strconv.Itoa(performerId), l.cfg.MaxFailures, msg.W4000, now)
+	l.failed(model.LoginLockAddr, addr, l.cfg.MaxFailuresAddr, msg.W4001, now)
+}
+
+// BadgeLoginFailed учесть неудачную попытку входа по бейджу: неизвестный код или устройство.
+func (l *LoginThrottleService) BadgeLoginFailed(addr string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failed(model.LoginLockAddr, addr, l.cfg.MaxFailuresAddr, msg.W4001, time.Now())
+}
+
+// LoginSucceeded сбросить счетчик табельного номера после успешного входа. Счетчик адреса не сбрасывается, чтобы
+// вход под своей учетной записью не открывал подбор чужих.
+func (l *LoginThrottleService) LoginSucceeded(performerId int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, loginAttemptsKey(model.LoginLockPerformer, strconv.Itoa(performerId)))
+}
+
+// UnlockLogin снять блокировку входа администратором, false - блокировки не было.
+func (l *LoginThrottleService) UnlockLogin(kind, value string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := loginAttemptsKey(kind, value)
+	if _, ok := l.attempts[key]; !ok {
+		return false
+	}
+
+	delete(l.attempts, key)
+	l.logg.LogW(fmt.Sprintf("%s %s %s", msg.W4002, kind, value))
+
+	return true
+}
+
+// GetLockouts действующие блокировки входа, ближайшие к окончанию последними.
+func (l *LoginThrottleService) GetLockouts() []*model.LoginLockout {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	lockouts := make([]*model.LoginLockout, 0)
+	for key := range l.attempts {
+		attempts := l.getAttemptsByKey(key, now)
+		if attempts == nil || !attempts.lockedUntil.After(now) {
+			continue
+		}
+
+		lockouts = append(lockouts, &model.LoginLockout{
+			Kind:        attempts.kind,
+			Value:       attempts.value,
+			Failures:    attempts.failures,
+			LockedUntil: attempts.lockedUntil,
+		})
+	}
+
+	sort.Slice(lockouts, func(i, j int) bool {
+		return lockouts[i].LockedUntil.After(lockouts[j].LockedUntil)
+	})
+
+	return lockouts
+}
+
+// failed увеличить счетчик неудачных попыток и назначить паузу или блокировку.
+func (l *LoginThrottleService) failed(kind, value string, maxFailures int, code string, now time.Time) {
+	attempts := l.getAttempts(kind, value, now)
+	if attempts == nil {
+		l.sweep(now)
+		attempts = &loginAttempts{kind: kind, value: value}
+		l.attempts[loginAttemptsKey(kind, value)] = attempts
+	}
+
+	attempts.failures++
+	attempts.lastFailure = now
+	attempts.blockedUntil = now.Add(l.backoff(attempts.failures))
+
+	if attempts.failures >= maxFailures && !attempts.lockedUntil.After(now) {
+		attempts.lockedUntil = now.Add(l.cfg.Lockout)
+		l.logg.LogW(fmt.Sprintf("%s %s %s, попыток: %d", code, kind, value, attempts.failures))
+	}
+}
+
+// backoff пауза после failures неудачных попыток: BackoffBase, удвоенная за каждую следующую, не больше BackoffMax.
+func (l *LoginThrottleService) backoff(failures int) time.Duration {
+	delay := l.cfg.BackoffBase
+	for i := 1; i < failures && delay < l.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, l.cfg.BackoffMax)
+}
+
+// sweep удалить устаревшие счетчики, не чаще раза в Lockout, чтобы подбор по разным табельным номерам и адресам не
+// копил их в памяти.
+func (l *LoginThrottleService) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.cfg.Lockout {
+		return
+	}
+	l.lastSweep = now
+
+	for key := range l.attempts {
+		l.getAttemptsByKey(key, now)
+	}
+}
+
+func (l *LoginThrottleService) getAttempts(kind, value string, now time.Time) *loginAttempts {
+	return l.getAttemptsByKey(loginAttemptsKey(kind, value), now)
+}
+
+// getAttemptsByKey попытки по ключу, счетчик удаляется, если блокировка закончилась и ошибок не было Lockout.
+func (l *LoginThrottleService) getAttemptsByKey(key string, now time.Time) *loginAttempts {
+	attempts, ok := l.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	if !attempts.lockedUntil.After(now) && now.Sub(attempts.lastFailure) > l.cfg.Lockout {
+		delete(l.attempts, key)
+
+		return nil
+	}
+
+	return attempts
+}
+
+func loginAttemptsKey(kind, value string) string {
+	return kind + ":" + value
+}
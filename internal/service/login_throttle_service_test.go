@@ -0,0 +1,102 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoginThrottle(lockout time.Duration) *LoginThrottleService {
+	return NewLoginThrottleService(&config.LoginThrottleCfg{
+		MaxFailures:     3,
+		MaxFailuresAddr: 5,
+		BackoffBase:     time.Second,
+		BackoffMax:      4 * time.Second,
+		Lockout:         lockout,
+	}, &common.Logger{})
+}
+
+func TestLoginThrottleService_Backoff(t *testing.T) {
+	throttle := newTestLoginThrottle(time.Minute)
+
+	assert.Equal(t, time.Second, throttle.backoff(1))
+	assert.Equal(t, 2*time.Second, throttle.backoff(2))
+	assert.Equal(t, 4*time.Second, throttle.backoff(3))
+	assert.Equal(t, 4*time.Second, throttle.backoff(10), "не больше BackoffMax")
+
+	assert.True(t, throttle.CheckLogin(1001, "10.0.0.1").Allowed)
+
+	throttle.LoginFailed(1001, "10.0.0.1")
+	check := throttle.CheckLogin(1001, "10.0.0.1")
+	assert.False(t, check.Allowed, "пауза после неудачной попытки")
+	assert.False(t, check.Locked)
+
+	assert.False(t, throttle.CheckLogin(1002, "10.0.0.1").Allowed, "пауза действует и по адресу клиента")
+	assert.True(t, throttle.CheckLogin(1002, "10.0.0.2").Allowed)
+
+	throttle.LoginSucceeded(1001)
+	assert.False(t, throttle.CheckLogin(1001, "10.0.0.1").Allowed, "успешный вход не сбрасывает счетчик адреса")
+	assert.True(t, throttle.CheckLogin(1001, "10.0.0.2").Allowed)
+}
+
+func TestLoginThrottleService_Lockout(t *testing.T) {
+	throttle := newTestLoginThrottle(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		throttle.LoginFailed(1001, "10.0.0.1")
+	}
+
+	check := throttle.CheckLogin(1001, "10.0.0.9")
+	assert.False(t, check.Allowed)
+	assert.True(t, check.Locked)
+	assert.Contains(t, check.Message, "W4000")
+
+	lockouts := throttle.GetLockouts()
+	require.Len(t, lockouts, 1, "адрес еще не заблокирован")
+	assert.Equal(t, model.LoginLockPerformer, lockouts[0].Kind)
+	assert.Equal(t, "1001", lockouts[0].Value)
+	assert.Equal(t, 3, lockouts[0].Failures)
+
+	assert.True(t, throttle.UnlockLogin(model.LoginLockPerformer, "1001"))
+	assert.False(t, throttle.UnlockLogin(model.LoginLockPerformer, "1001"), "блокировка уже снята")
+	assert.True(t, throttle.CheckLogin(1001, "10.0.0.9").Allowed)
+}
+
+func TestLoginThrottleService_LockoutExpires(t *testing.T) {
+	throttle := NewLoginThrottleService(&config.LoginThrottleCfg{
+		MaxFailures: 1, MaxFailuresAddr: 10, BackoffBase: time.Millisecond, BackoffMax: time.Millisecond,
+		Lockout: 20 * time.Millisecond,
+	}, &common.Logger{})
+
+	throttle.LoginFailed(1001, "10.0.0.1")
+	assert.True(t, throttle.CheckLogin(1001, "10.0.0.2").Locked)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, throttle.CheckLogin(1001, "10.0.0.1").Allowed)
+	assert.Empty(t, throttle.GetLockouts())
+}
+
+func TestLoginThrottleService_Sweep(t *testing.T) {
+	throttle := newTestLoginThrottle(time.Minute)
+
+	for performerId := 1001; performerId <= 1010; performerId++ {
+		throttle.LoginFailed(performerId, "10.0.0.1")
+	}
+	require.Len(t, throttle.attempts, 11, "10 табельных номеров и адрес")
+
+	// Ошибок не было дольше Lockout: следующая неудачная попытка очищает устаревшие счетчики.
+	for _, attempts := range throttle.attempts {
+		attempts.lastFailure = attempts.lastFailure.Add(-2 * time.Minute)
+		attempts.blockedUntil = time.Time{}
+		attempts.lockedUntil = time.Time{}
+	}
+	throttle.lastSweep = throttle.lastSweep.Add(-2 * time.Minute)
+
+	throttle.LoginFailed(2001, "10.0.0.2")
+	assert.Len(t, throttle.attempts, 2, "остались только счетчики новой попытки")
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+)
+
+type PackStationService struct {
+	packStationRepo repository.PackStationRepository
+	palletRepo      repository.PalletRepository
+	compliance      ComplianceUseCase
+	catalogRepo     repository.CatalogRepository
+	sectorRepo      repository.SectorRepository
+	logg            *common.Logger
+}
+
+func NewPackStationService(
+	packStationRepo repository.PackStationRepository,
+	palletRepo repository.PalletRepository,
+	compliance ComplianceUseCase,
+	catalogRepo repository.CatalogRepository,
+	sectorRepo repository.SectorRepository,
+	logger *common.Logger) *PackStationService {
+
+	return &PackStationService{
+		packStationRepo: packStationRepo,
+		palletRepo:      palletRepo,
+		compliance:      compliance,
+		catalogRepo:     catalogRepo,
+		sectorRepo:      sectorRepo,
+		logg:            logger,
+	}
+}
+
+type PackStationUseCase interface {
+	GetAllPackStations(ctx context.Context) ([]*model.PackStation, error)
+	AddPackStation(ctx context.Context, station *model.PackStation, performerId int) error
+	UpdPackStation(ctx context.Context, id int, station *model.PackStation, performerId int) error
+	ExistPackStation(ctx context.Context, id int) (bool, error)
+	OpenSession(ctx context.Context, badge *model.PackStationBadge) (*model.PackStationSession, error)
+	CloseSession(ctx context.Context, stationId int) error
+	GetPrintStamp(ctx context.Context, stationId int) (*model.PackStationSession, error)
+	PrintPallet(ctx context.Context, print *model.PalletPrint, performerId int) (*model.Pallet, error)
+}
+
+func (p *PackStationService) GetAllPackStations(ctx context.Context) ([]*model.PackStation, error) {
+	stations, err := p.packStationRepo.All(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return stations, nil
+}
+
+// AddPackStation добавить станцию, участок упаковки, принтер и печь должны существовать.
+func (p *PackStationService) AddPackStation(ctx context.Context, station *model.PackStation, performerId int) error {
+	if err := p.validate(ctx, station); err != nil {
+		return err
+	}
+
+	station.AuditRec.CreatedBy = performerId
+	if err := p.packStationRepo.Add(ctx, station); err != nil {
+		p.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *PackStationService) UpdPackStation(ctx context.Context, id int, station *model.PackStation, performerId int) error {
+	if err := p.validate(ctx, station); err != nil {
+		return err
+	}
+
+	station.AuditRec.UpdatedBy = performerId
+	if err := p.packStationRepo.UpdById(ctx, id, station); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *PackStationService) ExistPackStation(ctx context.Context, id int) (bool, error) {
+	return p.packStationRepo.ExistById(ctx, id)
+}
+
+// OpenSession открыть сеанс на станции по бейджу, прежний сеанс станции и сотрудника закрывается.
+func (p *PackStationService) OpenSession(ctx context.Context, badge *model.PackStationBadge) (*model.PackStationSession, error) {
+	if err := model.ValidatePackStationBadge(badge); err != nil {
+		p.logg.LogE(msg.E3213, err)
+
+		return nil, err
+	}
+
+	session, err := p.packStationRepo.OpenSession(ctx, badge.StationId, badge.Barcode)
+	if err != nil {
+		p.logg.LogE(msg.E3215, err)
+
+		return nil, err
+	}
+
+	if session == nil {
+		err = fmt.Errorf("%s", msg.E3222)
+		p.logg.LogE(msg.E3222, err)
+
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (p *PackStationService) CloseSession(ctx context.Context, stationId int) error {
+	if err := p.packStationRepo.CloseSession(ctx, stationId); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetPrintStamp отметка станции, печки и сотрудника для п\п, печатаемого на станции. Без открытого сеанса печать
+// запрещена.
+func (p *PackStationService) GetPrintStamp(ctx context.Context, stationId int) (*model.PackStationSession, error) {
+	session, err := p.packStationRepo.ActiveSession(ctx, stationId)
+	if err != nil {
+		p.logg.LogE(msg.E3206, err)
+
+		return nil, err
+	}
+
+	if session == nil {
+		err = fmt.Errorf("%s: станция %d", msg.E3223, stationId)
+		p.logg.LogE(msg.E3223, err)
+
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// PrintPallet зарегистрировать печать п\п на станции. П\п штампуется станцией, печкой и сотрудником открытого сеанса,
+// без открытого сеанса печать запрещена. Этикетка архивной продукции и декларируемой продукции без действующей
+// декларации не печатается. П\п с заданием засчитывается в факт задания, другого способа отметить факт нет.
+func (p *PackStationService) PrintPallet(ctx context.Context, print *model.PalletPrint, performerId int) (*model.Pallet, error) {
+	if err := model.ValidatePalletPrint(print); err != nil {
+		p.logg.LogE(msg.E3213, err)
+
+		return nil, err
+	}
+
+	check, err := p.compliance.CheckLabelPrint(ctx, print.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !check.Allowed {
+		return nil, fmt.Errorf("%s: %s", msg.E3235, check.Reason)
+	}
+
+	pallet, err := p.palletRepo.Print(ctx, print, performerId)
+	if err != nil {
+		p.logg.LogE(msg.E3215, err)
+
+		return nil, err
+	}
+
+	if pallet == nil {
+		return nil, p.printRefused(ctx, print)
+	}
+
+	return pallet, nil
+}
+
+// printRefused причина отказа в печати: нет открытого сеанса или задание не подходит сеансу и продукции.
+func (p *PackStationService) printRefused(ctx context.Context, print *model.PalletPrint) error {
+	session, err := p.packStationRepo.ActiveSession(ctx, print.StationId)
+	if err != nil {
+		p.logg.LogE(msg.E3206, err)
+
+		return err
+	}
+
+	if session == nil {
+		err = fmt.Errorf("%s: станция %d", msg.E3223, print.StationId)
+		p.logg.LogE(msg.E3223, err)
+
+		return err
+	}
+
+	err = fmt.Errorf("%s: задание %d, печь %q", msg.E3237, print.ShiftTaskId, session.SectorName)
+	p.logg.LogE(msg.E3237, err)
+
+	return err
+}
+
+// validate проверка полей станции и ссылок на справочники.
+func (p *PackStationService) validate(ctx context.Context, station *model.PackStation) error {
+	if err := model.ValidatePackStation(station); err != nil {
+		p.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	if err := p.checkCatalog(ctx, model.KodcatPackArea, station.PackAreaId); err != nil {
+		return err
+	}
+
+	if err := p.checkCatalog(ctx, model.KodcatPrinter, station.PrinterId); err != nil {
+		return err
+	}
+
+	exists, err := p.sectorRepo.ExistById(ctx, station.SectorId)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		err = fmt.Errorf("%s: печь %d", msg.E3212, station.SectorId)
+		p.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *PackStationService) checkCatalog(ctx context.Context, kodcat, id int) error {
+	catalogs, err := p.catalogRepo.AllByKodcat(ctx, kodcat)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return err
+	}
+
+	if !model.ContainsCatalogId(catalogs, id) {
+		err = fmt.Errorf("%s: kodcat %d, id %d", msg.E3224, kodcat, id)
+		p.logg.LogE(msg.E3224, err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCatalogRepo struct {
+	catalogs []*model.Catalog
+}
+
+func (f *fakeCatalogRepo) AllByKodcat(_ context.Context, kodcat int) ([]*model.Catalog, error) {
+	var catalogs []*model.Catalog
+	for _, catalog := range f.catalogs {
+		if catalog.Kodcat == kodcat {
+			catalogs = append(catalogs, catalog)
+		}
+	}
+
+	return catalogs, nil
+}
+
+type fakePackStationRepo struct {
+	stations []*model.PackStation
+	sessions map[int]*model.PackStationSession
+	badges   map[string]int
+}
+
+func (f *fakePackStationRepo) All(_ context.Context) ([]*model.PackStation, error) {
+	return f.stations, nil
+}
+
+func (f *fakePackStationRepo) Add(_ context.Context, station *model.PackStation) error {
+	station.Id = len(f.stations) + 1
+	f.stations = append(f.stations, station)
+
+	return nil
+}
+
+func (f *fakePackStationRepo) UpdById(_ context.Context, id int, station *model.PackStation) error {
+	f.stations[id-1] = station
+
+	return nil
+}
+
+func (f *fakePackStationRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return id > 0 && id <= len(f.stations), nil
+}
+
+func (f *fakePackStationRepo) ActiveSession(_ context.Context, stationId int) (*model.PackStationSession, error) {
+	return f.sessions[stationId], nil
+}
+
+func (f *fakePackStationRepo) OpenSession(_ context.Context, stationId int, barcode string) (*model.PackStationSession, error) {
+	performerId, ok := f.badges[barcode]
+	if !ok {
+		return nil, nil
+	}
+
+	for id, session := range f.sessions {
+		if session.PerformerId == performerId {
+			delete(f.sessions, id)
+		}
+	}
+
+	f.sessions[stationId] = &model.PackStationSession{Id: len(f.sessions) + 1, StationId: stationId, SectorId: 1, PerformerId: performerId}
+
+	return f.sessions[stationId], nil
+}
+
+func (f *fakePackStationRepo) CloseSession(_ context.Context, stationId int) error {
+	delete(f.sessions, stationId)
+
+	return nil
+}
+
+type fakePalletRepo struct {
+	stations *fakePackStationRepo
+	tasks    []*model.ShiftTask
+	pallets  []*model.Pallet
+	shipped  map[int]bool
+	placed   map[int]int
+}
+
+func (f *fakePalletRepo) Print(_ context.Context, print *model.PalletPrint, _ int) (*model.Pallet, error) {
+	session := f.stations.sessions[print.StationId]
+	if session == nil {
+		return nil, nil
+	}
+
+	var task *model.ShiftTask
+	if print.ShiftTaskId > 0 {
+		for _, t := range f.tasks {
+			if t.Id == print.ShiftTaskId && t.SectorId == session.SectorId && t.ProductId == print.ProductId {
+				task = t
+			}
+		}
+
+		if task == nil {
+			return nil, nil
+		}
+		task.FactQty++
+	}
+
+	pallet := &model.Pallet{
+		Id:          len(f.pallets) + 1,
+		SessionId:   session.Id,
+		StationId:   session.StationId,
+		SectorId:    session.SectorId,
+		PerformerId: session.PerformerId,
+		ProductId:   print.ProductId,
+		ShiftTaskId: print.ShiftTaskId,
+	}
+	f.pallets = append(f.pallets, pallet)
+
+	return pallet, nil
+}
+
+func (f *fakePalletRepo) Ship(_ context.Context, id, _ int) (bool, error) {
+	if f.shipped == nil {
+		f.shipped = map[int]bool{}
+	}
+
+	for _, pallet := range f.pallets {
+		if pallet.Id == id && !f.shipped[id] {
+			f.shipped[id] = true
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *fakePalletRepo) Place(_ context.Context, id, storageAreaId int) (bool, error) {
+	if f.placed == nil {
+		f.placed = map[int]int{}
+	}
+
+	for _, pallet := range f.pallets {
+		if pallet.Id == id && !f.shipped[id] {
+			f.placed[id] = storageAreaId
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *fakePalletRepo) CountByStorageArea(_ context.Context) (map[int]int, error) {
+	counts := map[int]int{}
+	for id, storageAreaId := range f.placed {
+		if !f.shipped[id] {
+			counts[storageAreaId]++
+		}
+	}
+
+	return counts, nil
+}
+
+func newPackStationService() (*PackStationService, *fakePackStationRepo, *fakePalletRepo) {
+	repo := &fakePackStationRepo{
+		sessions: map[int]*model.PackStationSession{},
+		badges:   map[string]int{"2000000000015": 10},
+	}
+	catalogs := &fakeCatalogRepo{catalogs: []*model.Catalog{
+		{Id: 1, Kodcat: model.KodcatPackArea, Name: "Участок 1"},
+		{Id: 2, Kodcat: model.KodcatPrinter, Name: "Zebra 1"},
+	}}
+
+	compliance := NewComplianceService(
+		&fakeDeclarationRepo{declarations: map[int][]*model.Declaration{}},
+		&fakeProductRepo{products: map[int]*model.Product{
+			1: {Id: 1, Article: "A0001"},
+			2: {Id: 2, Article: "A0002", Archive: true},
+			3: {Id: 3, Article: "A0003", Type: "Декларированная"},
+		}},
+		&common.Logger{})
+
+	pallets := &fakePalletRepo{stations: repo, tasks: []*model.ShiftTask{
+		{Id: 1, SectorId: 1, ProductId: 1, PlanQty: 10},
+		{Id: 2, SectorId: 2, ProductId: 1, PlanQty: 10},
+	}}
+
+	return NewPackStationService(repo, pallets, compliance, catalogs, newFakeSectorRepo(), &common.Logger{}), repo, pallets
+}
+
+func TestPackStationService_AddPackStation(t *testing.T) {
+	svc, repo, _ := newPackStationService()
+
+	require.NoError(t, svc.AddPackStation(context.Background(),
+		&model.PackStation{Name: " Станция 1 ", PackAreaId: 1, PrinterId: 2, SectorId: 1}, 1001))
+	assert.Equal(t, "Станция 1", repo.stations[0].Name)
+	assert.Equal(t, 1001, repo.stations[0].AuditRec.CreatedBy)
+
+	cases := map[string]*model.PackStation{
+		"принтер вместо участка":  {Name: "Станция 2", PackAreaId: 2, PrinterId: 2, SectorId: 1},
+		"участок вместо принтера": {Name: "Станция 2", PackAreaId: 1, PrinterId: 1, SectorId: 1},
+		"неизвестная печь":        {Name: "Станция 2", PackAreaId: 1, PrinterId: 2, SectorId: 9},
+		"пустое наименование":     {Name: " ", PackAreaId: 1, PrinterId: 2, SectorId: 1},
+	}
+
+	for name, station := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, svc.AddPackStation(context.Background(), station, 1001))
+		})
+	}
+
+	assert.Len(t, repo.stations, 1)
+}
+
+func TestPackStationService_PrintStamp(t *testing.T) {
+	svc, _, _ := newPackStationService()
+	ctx := context.Background()
+
+	_, err := svc.GetPrintStamp(ctx, 1)
+	assert.Error(t, err, "без открытого сеанса печать запрещена")
+
+	_, err = svc.OpenSession(ctx, &model.PackStationBadge{StationId: 1, Barcode: "0000"})
+	assert.Error(t, err, "неизвестный бейдж")
+
+	session, err := svc.OpenSession(ctx, &model.PackStationBadge{StationId: 1, Barcode: " 2000000000015 "})
+	require.NoError(t, err)
+	assert.Equal(t, 10, session.PerformerId)
+
+	stamp, err := svc.GetPrintStamp(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, session.Id, stamp.Id)
+
+	// Сотрудник перешел на другую станцию - сеанс первой станции закрыт.
+	_, err = svc.OpenSession(ctx, &model.PackStationBadge{StationId: 2, Barcode: "2000000000015"})
+	require.NoError(t, err)
+	_, err = svc.GetPrintStamp(ctx, 1)
+	assert.Error(t, err)
+
+	require.NoError(t, svc.CloseSession(ctx, 2))
+	_, err = svc.GetPrintStamp(ctx, 2)
+	assert.Error(t, err)
+}
+
+func TestPackStationService_PrintPallet(t *testing.T) {
+	svc, _, pallets := newPackStationService()
+	ctx := context.Background()
+
+	_, err := svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 1}, 1001)
+	assert.Error(t, err, "без открытого сеанса печать запрещена")
+
+	_, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1}, 1001)
+	assert.Error(t, err, "продукция не указана")
+
+	session, err := svc.OpenSession(ctx, &model.PackStationBadge{StationId: 1, Barcode: "2000000000015"})
+	require.NoError(t, err)
+
+	pallet, err := svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 1}, 1001)
+	require.NoError(t, err)
+	assert.Equal(t, session.Id, pallet.SessionId, "п\\п штампуется сеансом станции")
+	assert.Equal(t, 10, pallet.PerformerId)
+
+	pallet, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 1, ShiftTaskId: 1}, 1001)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pallet.ShiftTaskId)
+	assert.Equal(t, 1, pallets.tasks[0].FactQty, "факт задания - напечатанные п\\п")
+
+	_, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 1, ShiftTaskId: 2}, 1001)
+	assert.Error(t, err, "задание на другую печь")
+	assert.Zero(t, pallets.tasks[1].FactQty)
+
+	_, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 2}, 1001)
+	assert.Error(t, err, "продукция в архиве")
+	_, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 3}, 1001)
+	assert.Error(t, err, "декларируемая продукция без декларации")
+
+	require.NoError(t, svc.CloseSession(ctx, 1))
+	_, err = svc.PrintPallet(ctx, &model.PalletPrint{StationId: 1, ProductId: 1}, 1001)
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+)
+
+type PalletService struct {
+	palletRepo  repository.PalletRepository
+	catalogRepo repository.CatalogRepository
+	logg        *common.Logger
+}
+
+func NewPalletService(palletRepo repository.PalletRepository, catalogRepo repository.CatalogRepository, logger *common.Logger) *PalletService {
+	return &PalletService{palletRepo: palletRepo, catalogRepo: catalogRepo, logg: logger}
+}
+
+type PalletUseCase interface {
+	ShipPallet(ctx context.Context, id, performerId int) error
+	PlacePallet(ctx context.Context, id, storageAreaId int) (*model.PalletUpdate, error)
+}
+
+// ShipPallet отгрузить п\п со склада. Отгруженный п\п уходит из остатка и попадает в отгрузку дня для SAP.
+func (p *PalletService) ShipPallet(ctx context.Context, id, performerId int) error {
+	shipped, err := p.palletRepo.Ship(ctx, id, performerId)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	if !shipped {
+		err = fmt.Errorf("%s: п\\п %d не найден или уже отгружен", msg.E3208, id)
+		p.logg.LogE(msg.E3208, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// PlacePallet разместить п\п на складе на участке хранения (svCatalogs kodcat=10), п\п учитывается в заполненности
+// участка до отгрузки. Нет участка, площадка закрыта или заполнена - Success = false с причиной в Message. Нет п\п
+// или он уже отгружен - nil без ошибки.
+func (p *PalletService) PlacePallet(ctx context.Context, id, storageAreaId int) (*model.PalletUpdate, error) {
+	catalogs, err := p.catalogRepo.AllByKodcat(ctx, model.KodcatStorageArea)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	var area *model.StorageArea
+	for _, catalog := range catalogs {
+		if catalog.Id == storageAreaId {
+			area = model.NewStorageArea(catalog)
+
+			break
+		}
+	}
+
+	if area == nil {
+		err = fmt.Errorf("%s: kodcat %d, id %d", msg.E3224, model.KodcatStorageArea, storageAreaId)
+		p.logg.LogE(msg.E3224, err)
+
+		return &model.PalletUpdate{Success: false, Message: err.Error()}, nil
+	}
+
+	counts, err := p.palletRepo.CountByStorageArea(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	if !model.NewStorageAreaOccupancy(area, counts[area.Id]).HasSpace() {
+		err = fmt.Errorf("%s: участок %d", msg.E3240, storageAreaId)
+		p.logg.LogE(msg.E3240, err)
+
+		return &model.PalletUpdate{Success: false, Message: err.Error()}, nil
+	}
+
+	placed, err := p.palletRepo.Place(ctx, id, storageAreaId)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return nil, err
+	}
+
+	if !placed {
+		p.logg.LogE(msg.E3208, fmt.Errorf("%s: п\\п %d не найден или уже отгружен", msg.E3208, id))
+
+		return nil, nil
+	}
+
+	return &model.PalletUpdate{Success: true, Message: "П\\п размещен"}, nil
+}
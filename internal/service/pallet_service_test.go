@@ -0,0 +1,76 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPalletService(repo *fakePalletRepo) *PalletService {
+	catalogs := &fakeCatalogRepo{catalogs: []*model.Catalog{
+		{Id: 10, Kodcat: model.KodcatStorageArea, Name: "Площадка 1", DopFloat2: 10},
+		{Id: 11, Kodcat: model.KodcatStorageArea, Name: "Площадка 2", DopFloat2: 10, DopBit1: true},
+		{Id: 12, Kodcat: model.KodcatStorageArea, Name: "Площадка 3", DopFloat2: 2, DopFloat1: 50},
+		{Id: 2, Kodcat: model.KodcatPrinter, Name: "Zebra 1"},
+	}}
+
+	return NewPalletService(repo, catalogs, &common.Logger{})
+}
+
+func TestPalletService_ShipPallet(t *testing.T) {
+	repo := &fakePalletRepo{pallets: []*model.Pallet{{Id: 1}}}
+	svc := newPalletService(repo)
+	ctx := context.Background()
+
+	assert.NoError(t, svc.ShipPallet(ctx, 1, 1001))
+	assert.Error(t, svc.ShipPallet(ctx, 1, 1001), "п\\п уже отгружен")
+	assert.Error(t, svc.ShipPallet(ctx, 2, 1001), "п\\п не найден")
+}
+
+func TestPalletService_PlacePallet(t *testing.T) {
+	repo := &fakePalletRepo{pallets: []*model.Pallet{{Id: 1}, {Id: 2}, {Id: 3}}}
+	svc := newPalletService(repo)
+	ctx := context.Background()
+
+	result, err := svc.PlacePallet(ctx, 1, 10)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 10, repo.placed[1])
+
+	result, err = svc.PlacePallet(ctx, 2, 2)
+	assert.NoError(t, err)
+	assert.False(t, result.Success, "принтер вместо участка хранения")
+
+	result, err = svc.PlacePallet(ctx, 4, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, result, "п\\п не найден")
+
+	assert.NoError(t, svc.ShipPallet(ctx, 2, 1001))
+	result, err = svc.PlacePallet(ctx, 2, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, result, "п\\п уже отгружен")
+}
+
+func TestPalletService_PlacePallet_NoSpace(t *testing.T) {
+	repo := &fakePalletRepo{pallets: []*model.Pallet{{Id: 1}, {Id: 2}}}
+	svc := newPalletService(repo)
+	ctx := context.Background()
+
+	result, err := svc.PlacePallet(ctx, 1, 11)
+	assert.NoError(t, err)
+	assert.False(t, result.Success, "площадка закрыта")
+	assert.Contains(t, result.Message, "E3240")
+
+	result, err = svc.PlacePallet(ctx, 1, 12)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+
+	result, err = svc.PlacePallet(ctx, 2, 12)
+	assert.NoError(t, err)
+	assert.False(t, result.Success, "участок заполнен: 2 места при 50%")
+	_, placed := repo.placed[2]
+	assert.False(t, placed)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const passHashPrefix = "$2" // passHashPrefix - начало хеша bcrypt ($2a$, $2b$, $2y$).
+
+// dummyPassHash хеш bcrypt той же стоимости, что и пароли сотрудников. С ним сверяется пароль, когда сотрудника нет,
+// чтобы время ответа не выдавало существующие табельные номера.
+const dummyPassHash = "$2a$10$UaW.8qux/TU1Vq0E7D6.Neg766b1Q6q7ZvVm1b.beIsO8CriiUpw6"
+
+// hashPassword хеш пароля для хранения в svPerformers.pass.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// isPasswordHash хранится ли пароль хешем. Пароль в открытом виде не длиннее 30 символов и хешем быть не может.
+func isPasswordHash(stored string) bool {
+	return strings.HasPrefix(stored, passHashPrefix) && len(stored) == 60
+}
+
+// verifyPassword сверить пароль с сохраненным. legacy = true - пароль хранится в открытом виде и его нужно
+// заменить хешем. Если сохраненного пароля нет, пароль сверяется с dummyPassHash и не подходит.
+func verifyPassword(stored, password string) (ok, legacy bool) {
+	if stored == "" {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPassHash), []byte(password))
+
+		return false, false
+	}
+
+	if password == "" {
+		return false, false
+	}
+
+	if isPasswordHash(stored) {
+		return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil, false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1, true
+}
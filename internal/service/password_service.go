@@ -0,0 +1,288 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordMaxBytes    = 72 // passwordMaxBytes - bcrypt учитывает только первые 72 байта пароля.
+	tempPasswordMinLen  = 12 // tempPasswordMinLen - наименьшая длина временного пароля.
+	tempPasswordLower   = "abcdefghijkmnpqrstuvwxyz"
+	tempPasswordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	tempPasswordDigits  = "23456789"
+	tempPasswordSymbols = "!#%+-=?@"
+)
+
+type PasswordService struct {
+	performerRepo repository.PerformerRepository
+	passwordRepo  repository.PasswordRepository
+	policy        *config.PasswordPolicyCfg
+	logg          *common.Logger
+}
+
+func NewPasswordService(
+	performerRepo repository.PerformerRepository,
+	passwordRepo repository.PasswordRepository,
+	policy *config.PasswordPolicyCfg,
+	logger *common.Logger) *PasswordService {
+
+	return &PasswordService{performerRepo: performerRepo, passwordRepo: passwordRepo, policy: policy, logg: logger}
+}
+
+type PasswordUseCase interface {
+	ChangePassword(ctx context.Context, id int, current, password string) error
+	ResetPassword(ctx context.Context, id, resetBy int) (*model.PasswordReset, error)
+	PassMustChange(ctx context.Context, id int) (bool, error)
+	Policy() *config.PasswordPolicyCfg
+}
+
+// ChangePassword смена пароля сотрудником: текущий пароль проверяется по svPerformers, новый должен соответствовать
+// требованиям и не совпадать с текущим и прежними паролями. Признак временного пароля снимается. Без db в
+// AUTH_PROVIDERS пароль в svPerformers не проверяется при входе, и смена недоступна.
+func (p *PasswordService) ChangePassword(ctx context.Context, id int, current, password string) error {
+	if err := p.checkDBPasswords(); err != nil {
+		return err
+	}
+
+	stored, err := p.performerRepo.FindPassById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%s: сотрудник %d", msg.E3212, id)
+		p.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ok, _ := verifyPassword(stored, current); !ok {
+		err = fmt.Errorf("%s: текущий пароль указан неверно", msg.E3210)
+		p.logg.LogE(msg.E3210, err)
+
+		return err
+	}
+
+	if err = p.validatePassword(id, password); err != nil {
+		p.logg.LogE(msg.E3230, err)
+
+		return err
+	}
+
+	reused, err := p.isPasswordReused(ctx, id, stored, password)
+	if err != nil {
+		return err
+	}
+
+	if reused {
+		err = fmt.Errorf("%s: пароль совпадает с текущим или одним из %d прежних", msg.E3230, p.policy.History)
+		p.logg.LogE(msg.E3230, err)
+
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return p.passwordRepo.SetPassById(ctx, id, hash, false, id)
+}
+
+// checkDBPasswords пароли проверяются по svPerformers, иначе менять их в FGW_WEB бессмысленно.
+func (p *PasswordService) checkDBPasswords() error {
+	if p.policy.DBPasswords {
+		return nil
+	}
+
+	err := fmt.Errorf("%s", msg.E3239)
+	p.logg.LogE(msg.E3239, err)
+
+	return err
+}
+
+// ResetPassword сброс пароля администратором resetBy: сотруднику выдается временный пароль, который нужно сменить
+// при следующем входе.
+func (p *PasswordService) ResetPassword(ctx context.Context, id, resetBy int) (*model.PasswordReset, error) {
+	if err := p.checkDBPasswords(); err != nil {
+		return nil, err
+	}
+
+	exists, err := p.performerRepo.ExistById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		err = fmt.Errorf("%s: сотрудник %d", msg.E3212, id)
+		p.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	tempPassword, err := p.generateTempPassword()
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return nil, err
+	}
+
+	hash, err := hashPassword(tempPassword)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return nil, err
+	}
+
+	if err = p.passwordRepo.SetPassById(ctx, id, hash, true, resetBy); err != nil {
+		return nil, err
+	}
+
+	return &model.PasswordReset{
+		Success:      true,
+		Message:      "Пароль сброшен, передайте сотруднику временный пароль: повторно он не показывается",
+		PerformerId:  id,
+		TempPassword: tempPassword,
+	}, nil
+}
+
+// PassMustChange пароль сотрудника временный и должен быть сменен перед работой.
+func (p *PasswordService) PassMustChange(ctx context.Context, id int) (bool, error) {
+	mustChange, err := p.passwordRepo.PassMustChangeById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	return mustChange, err
+}
+
+// Policy требования к паролю для вывода на странице смены пароля.
+func (p *PasswordService) Policy() *config.PasswordPolicyCfg {
+	return p.policy
+}
+
+// validatePassword проверить пароль на соответствие требованиям.
+func (p *PasswordService) validatePassword(id int, password string) error {
+	if utf8.RuneCountInString(password) < p.policy.MinLength {
+		return fmt.Errorf("%s: пароль должен быть не короче %d символов", msg.E3230, p.policy.MinLength)
+	}
+
+	if len(password) > passwordMaxBytes {
+		return fmt.Errorf("%s: пароль должен быть не длиннее %d байт", msg.E3230, passwordMaxBytes)
+	}
+
+	if classes := passwordClasses(password); classes < p.policy.MinClasses {
+		return fmt.Errorf("%s: в пароле нужны символы хотя бы %d групп из 4: строчные и прописные буквы, цифры, "+
+			"прочие символы", msg.E3230, p.policy.MinClasses)
+	}
+
+	if strings.Contains(password, strconv.Itoa(id)) {
+		return fmt.Errorf("%s: пароль не должен содержать табельный номер", msg.E3230)
+	}
+
+	return nil
+}
+
+// isPasswordReused совпадает ли пароль с текущим stored или одним из прежних в пределах PASSWORD_HISTORY.
+func (p *PasswordService) isPasswordReused(ctx context.Context, id int, stored, password string) (bool, error) {
+	if ok, _ := verifyPassword(stored, password); ok {
+		return true, nil
+	}
+
+	if p.policy.History <= 0 {
+		return false, nil
+	}
+
+	hashes, err := p.passwordRepo.PassHistoryById(ctx, id, p.policy.History)
+	if err != nil {
+		return false, err
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// generateTempPassword временный пароль из символов всех четырех групп, без похожих друг на друга (0/O, 1/l/I).
+func (p *PasswordService) generateTempPassword() (string, error) {
+	length := max(p.policy.MinLength, tempPasswordMinLen)
+	groups := []string{tempPasswordLower, tempPasswordUpper, tempPasswordDigits, tempPasswordSymbols}
+	alphabet := strings.Join(groups, "")
+
+	password := make([]byte, length)
+	for i := range password {
+		chars := alphabet
+		if i < len(groups) {
+			chars = groups[i]
+		}
+
+		char, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password[i] = char
+	}
+
+	// Символы обязательных групп стоят первыми, перемешиваем их с остальными.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+// randomChar случайный символ строки chars.
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[n.Int64()], nil
+}
+
+// passwordClasses сколько групп символов есть в пароле: строчные, прописные буквы, цифры, прочие.
+func passwordClasses(password string) int {
+	var lower, upper, digit, other int
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+
+	return lower + upper + digit + other
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/pkg/common"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakePasswordRepo пароли и история паролей поверх fakePerformerRepo, как ХП svPerformerSetPassById.
+type fakePasswordRepo struct {
+	performers *fakePerformerRepo
+	history    map[int][]string
+	mustChange map[int]bool
+}
+
+func (f *fakePasswordRepo) SetPassById(_ context.Context, id int, passHash string, mustChange bool, _ int) error {
+	if previous := f.performers.performers[id].Pass; isPasswordHash(previous) {
+		f.history[id] = append([]string{previous}, f.history[id]...)
+	}
+
+	f.performers.performers[id].Pass = passHash
+	f.mustChange[id] = mustChange
+
+	return nil
+}
+
+func (f *fakePasswordRepo) PassHistoryById(_ context.Context, id, limit int) ([]string, error) {
+	return f.history[id][:min(limit, len(f.history[id]))], nil
+}
+
+func (f *fakePasswordRepo) PassMustChangeById(_ context.Context, id int) (bool, error) {
+	return f.mustChange[id], nil
+}
+
+func newTestPasswordService(history int) (*PasswordService, *fakePasswordRepo) {
+	performers := newFakePerformerRepo()
+	passwords := &fakePasswordRepo{performers: performers, history: map[int][]string{}, mustChange: map[int]bool{}}
+	policy := &config.PasswordPolicyCfg{MinLength: 8, MinClasses: 3, History: history, DBPasswords: true}
+
+	return NewPasswordService(performers, passwords, policy, &common.Logger{}), passwords
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	svc, passwords := newTestPasswordService(2)
+
+	assert.Error(t, svc.ChangePassword(ctx, 1001, "wrong", "Winter#2026"), "неверный текущий пароль")
+
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "короткий", password: "Ab#1"},
+		{name: "мало групп символов", password: "winter2026"},
+		{name: "табельный номер", password: "Pass#1001x"},
+		{name: "длиннее 72 байт", password: "Aa#1" + strings.Repeat("x", 70)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, svc.ChangePassword(ctx, 1001, "1001", tt.password))
+		})
+	}
+
+	require.NoError(t, svc.ChangePassword(ctx, 1001, "1001", "Winter#2026"))
+	assert.True(t, isPasswordHash(passwords.performers.performers[1001].Pass))
+	assert.Error(t, svc.ChangePassword(ctx, 1001, "Winter#2026", "Winter#2026"), "совпадает с текущим")
+
+	require.NoError(t, svc.ChangePassword(ctx, 1001, "Winter#2026", "Spring#2026"))
+	require.NoError(t, svc.ChangePassword(ctx, 1001, "Spring#2026", "Summer#2026"))
+	assert.Error(t, svc.ChangePassword(ctx, 1001, "Summer#2026", "Winter#2026"), "один из двух прежних")
+
+	require.NoError(t, svc.ChangePassword(ctx, 1001, "Summer#2026", "Autumn#2026"))
+	assert.NoError(t, svc.ChangePassword(ctx, 1001, "Autumn#2026", "Winter#2026"), "вышел за пределы истории")
+
+	assert.Error(t, svc.ChangePassword(ctx, 1002, "1002", "Winter#2026"), "архивный сотрудник")
+}
+
+func TestPasswordService_NoDBPasswords(t *testing.T) {
+	ctx := context.Background()
+	svc, passwords := newTestPasswordService(2)
+	svc.policy.DBPasswords = false
+
+	assert.ErrorContains(t, svc.ChangePassword(ctx, 1001, "1001", "Winter#2026"), "E3239", "пароль только в LDAP")
+	assert.Equal(t, "1001", passwords.performers.performers[1001].Pass, "пароль в svPerformers не изменен")
+
+	_, err := svc.ResetPassword(ctx, 1001, 1)
+	assert.ErrorContains(t, err, "E3239")
+}
+
+func TestPasswordService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	svc, passwords := newTestPasswordService(5)
+
+	reset, err := svc.ResetPassword(ctx, 1003, 1001)
+	require.NoError(t, err)
+	assert.True(t, reset.Success)
+	assert.Len(t, reset.TempPassword, tempPasswordMinLen)
+	assert.Equal(t, 4, passwordClasses(reset.TempPassword))
+
+	mustChange, err := svc.PassMustChange(ctx, 1003)
+	require.NoError(t, err)
+	assert.True(t, mustChange)
+
+	ok, _ := verifyPassword(passwords.performers.performers[1003].Pass, reset.TempPassword)
+	assert.True(t, ok, "временным паролем можно войти")
+
+	require.NoError(t, svc.ChangePassword(ctx, 1003, reset.TempPassword, "Winter#2026"))
+	mustChange, err = svc.PassMustChange(ctx, 1003)
+	require.NoError(t, err)
+	assert.False(t, mustChange, "после смены пароль постоянный")
+
+	_, err = svc.ResetPassword(ctx, 9999, 1001)
+	assert.Error(t, err, "нет сотрудника")
+
+	again, err := svc.ResetPassword(ctx, 1003, 1001)
+	require.NoError(t, err)
+	assert.NotEqual(t, reset.TempPassword, again.TempPassword)
+}
+
+func TestVerifyPassword_UnknownPerformer(t *testing.T) {
+	// Пароль неизвестного сотрудника сверяется с хешем той же стоимости, что и пароли сотрудников.
+	cost, err := bcrypt.Cost([]byte(dummyPassHash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost)
+	assert.True(t, isPasswordHash(dummyPassHash))
+
+	ok, legacy := verifyPassword("", "fgw-dummy-password")
+	assert.False(t, ok)
+	assert.False(t, legacy)
+}
@@ -6,21 +6,36 @@ import (
 	"FGW_WEB/pkg/common"
 	"FGW_WEB/pkg/common/msg"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"strings"
 )
 
 type PerformerService struct {
 	performerRepo repository.PerformerRepository
+	authProviders []AuthProvider // authProviders - способы проверки пароля в порядке опроса.
 	logg          *common.Logger
 }
 
-func NewPerformerService(performerRepo repository.PerformerRepository, logger *common.Logger) *PerformerService {
-	return &PerformerService{performerRepo: performerRepo, logg: logger}
+// NewPerformerService сервис сотрудников. Без authProviders пароль проверяется только по svPerformers.
+func NewPerformerService(
+	performerRepo repository.PerformerRepository,
+	logger *common.Logger,
+	authProviders ...AuthProvider) *PerformerService {
+
+	if len(authProviders) == 0 {
+		authProviders = []AuthProvider{NewDBAuthProvider(performerRepo, logger)}
+	}
+
+	return &PerformerService{performerRepo: performerRepo, authProviders: authProviders, logg: logger}
 }
 
 type PerformerUseCase interface {
 	GetAllPerformers(ctx context.Context) ([]*model.Performer, error)
 	AuthPerformer(ctx context.Context, id int, password string) (*model.AuthPerformer, error)
+	AuthPerformerByBC(ctx context.Context, bc string) (*model.AuthPerformer, error)
+	HashLegacyPasswords(ctx context.Context) (*model.PerformerHashReport, error)
 	UpdPerformer(ctx context.Context, id int, performer *model.Performer) error
 	ExistPerformer(ctx context.Context, id int) (bool, error)
 	FindByIdPerformer(ctx context.Context, id int) (*model.Performer, error)
@@ -40,6 +55,10 @@ func (p *PerformerService) GetAllPerformers(ctx context.Context) ([]*model.Perfo
 	return performers, nil
 }
 
+// AuthPerformer вход по табельному номеру и паролю. Способы проверки пароля опрашиваются по порядку до первого,
+// подтвердившего пароль: недоступность одного способа не мешает войти через следующий. Если хотя бы один способ
+// отклонил пароль, это отказ, а не ошибка: неудачная попытка учитывается и при недоступном LDAP. Ошибка
+// возвращается, только если пароль не проверил ни один способ.
 func (p *PerformerService) AuthPerformer(ctx context.Context, id int, password string) (*model.AuthPerformer, error) {
 	if id <= 0 || password == "" {
 		p.logg.LogE(msg.E3211, nil)
@@ -47,17 +66,32 @@ func (p *PerformerService) AuthPerformer(ctx context.Context, id int, password s
 		return &model.AuthPerformer{Success: false, Message: msg.E3211}, nil
 	}
 
-	authOK, err := p.performerRepo.AuthByIdAndPass(ctx, id, password)
-	if err != nil {
-		p.logg.LogE(msg.E3210, err)
+	var providerErr error
+	authOK, rejected := false, false
+
+	for _, provider := range p.authProviders {
+		ok, err := provider.VerifyPassword(ctx, id, password)
+		if err != nil {
+			p.logg.LogE(msg.E3210, fmt.Errorf("%s: %w", provider.Name(), err))
+			providerErr = err
 
-		return &model.AuthPerformer{Success: false, Message: msg.E3210}, err
+			continue
+		}
+
+		if authOK = ok; authOK {
+			break
+		}
+		rejected = true
 	}
 
 	if !authOK {
-		p.logg.LogE(msg.E3210, err)
+		p.logg.LogE(msg.E3210, nil)
+
+		if rejected {
+			return &model.AuthPerformer{Success: false, Message: msg.E3210}, nil
+		}
 
-		return &model.AuthPerformer{Success: false, Message: msg.E3210}, err
+		return &model.AuthPerformer{Success: false, Message: msg.E3210}, providerErr
 	}
 
 	performer, err := p.performerRepo.FindById(ctx, id)
@@ -72,6 +106,64 @@ func (p *PerformerService) AuthPerformer(ctx context.Context, id int, password s
 	}, nil
 }
 
+// HashLegacyPasswords заменить хешем все пароли, которые ещё хранятся в открытом виде.
+func (p *PerformerService) HashLegacyPasswords(ctx context.Context) (*model.PerformerHashReport, error) {
+	passes, err := p.performerRepo.AllLegacyPass(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	report := &model.PerformerHashReport{}
+	for _, pass := range passes {
+		hash, err := hashPassword(pass.Pass)
+		if err == nil {
+			err = p.performerRepo.UpdPassById(ctx, pass.Id, hash)
+		}
+
+		if err != nil {
+			p.logg.LogE(msg.E3216, err)
+			report.Errors = append(report.Errors, fmt.Sprintf("сотрудник %d: %v", pass.Id, err))
+
+			continue
+		}
+
+		report.Hashed++
+	}
+
+	return report, nil
+}
+
+// AuthPerformerByBC вход по штрих-коду бейджа (код доступа bc) для ТСД и терминалов цеха.
+func (p *PerformerService) AuthPerformerByBC(ctx context.Context, bc string) (*model.AuthPerformer, error) {
+	bc = strings.TrimSpace(bc)
+	if bc == "" || len(bc) > model.PerformerBCMaxLen {
+		p.logg.LogE(msg.E3222, nil)
+
+		return &model.AuthPerformer{Success: false, Message: msg.E3222}, nil
+	}
+
+	performer, err := p.performerRepo.FindByBC(ctx, bc)
+	if errors.Is(err, sql.ErrNoRows) {
+		p.logg.LogW(msg.E3222)
+
+		return &model.AuthPerformer{Success: false, Message: msg.E3222}, nil
+	}
+
+	if err != nil {
+		p.logg.LogE(msg.E3222, err)
+
+		return &model.AuthPerformer{Success: false, Message: msg.E3222}, err
+	}
+
+	return &model.AuthPerformer{
+		Success:   true,
+		Performer: *performer,
+		Message:   "Успешный вход",
+	}, nil
+}
+
 func (p *PerformerService) UpdPerformer(ctx context.Context, id int, performer *model.Performer) error {
 	if err := model.ValidateUpdateDataPerformer(performer); err != nil {
 		p.logg.LogE(msg.E3213, err)
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePerformerRepo struct {
+	performers map[int]*model.Performer
+}
+
+func (f *fakePerformerRepo) All(_ context.Context) ([]*model.Performer, error) {
+	var performers []*model.Performer
+	for _, performer := range f.performers {
+		performers = append(performers, performer)
+	}
+
+	return performers, nil
+}
+
+func (f *fakePerformerRepo) FindPassById(_ context.Context, id int) (string, error) {
+	performer, ok := f.performers[id]
+	if !ok || performer.Archive {
+		return "", sql.ErrNoRows
+	}
+
+	return performer.Pass, nil
+}
+
+func (f *fakePerformerRepo) UpdPassById(_ context.Context, id int, passHash string) error {
+	f.performers[id].Pass = passHash
+
+	return nil
+}
+
+func (f *fakePerformerRepo) AllLegacyPass(_ context.Context) ([]*model.PerformerPass, error) {
+	var passes []*model.PerformerPass
+	for id, performer := range f.performers {
+		if performer.Pass != "" && !isPasswordHash(performer.Pass) {
+			passes = append(passes, &model.PerformerPass{Id: id, Pass: performer.Pass})
+		}
+	}
+
+	return passes, nil
+}
+
+func (f *fakePerformerRepo) FindById(_ context.Context, id int) (*model.Performer, error) {
+	performer, ok := f.performers[id]
+	if !ok || performer.Archive {
+		return nil, fmt.Errorf("%s: %w", msg.E3206, sql.ErrNoRows)
+	}
+
+	return performer, nil
+}
+
+func (f *fakePerformerRepo) FindByBC(_ context.Context, bc string) (*model.Performer, error) {
+	for _, performer := range f.performers {
+		if performer.BC == bc && !performer.Archive {
+			return performer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", msg.E3206, sql.ErrNoRows)
+}
+
+func (f *fakePerformerRepo) UpdById(_ context.Context, id int, performer *model.Performer) error {
+	f.performers[id] = performer
+
+	return nil
+}
+
+func (f *fakePerformerRepo) ExistById(_ context.Context, id int) (bool, error) {
+	_, ok := f.performers[id]
+
+	return ok, nil
+}
+
+func (f *fakePerformerRepo) GetPerformersCount(_ context.Context) (int, error) {
+	return len(f.performers), nil
+}
+
+func (f *fakePerformerRepo) GetPerformersWithPagination(ctx context.Context, _, _ int) ([]*model.Performer, error) {
+	return f.All(ctx)
+}
+
+func (f *fakePerformerRepo) FilterById(ctx context.Context, _ string) ([]*model.Performer, error) {
+	return f.All(ctx)
+}
+
+func newFakePerformerRepo() *fakePerformerRepo {
+	return &fakePerformerRepo{performers: map[int]*model.Performer{
+		1001: {Id: 1001, FIO: "Иванов И.И.", BC: "2000000010011", Pass: "1001", IdRoleAForms: 4},
+		1002: {Id: 1002, FIO: "Петров П.П.", BC: "2000000010028", Pass: "1002", IdRoleAForms: 4, Archive: true},
+		1003: {Id: 1003, FIO: "Сидоров С.С.", BC: "", Pass: "1003", IdRoleAForms: 3},
+	}}
+}
+
+func TestPerformerService_AuthPerformerByBC(t *testing.T) {
+	svc := NewPerformerService(newFakePerformerRepo(), &common.Logger{})
+	ctx := context.Background()
+
+	result, err := svc.AuthPerformerByBC(ctx, " 2000000010011 ")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 1001, result.Performer.Id)
+
+	for name, bc := range map[string]string{
+		"архивный сотрудник": "2000000010028",
+		"неизвестный бейдж":  "2000000099999",
+		"пустой бейдж":       " ",
+		"длинный бейдж":      "20000000100110",
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := svc.AuthPerformerByBC(ctx, bc)
+			require.NoError(t, err, "неизвестный бейдж - отказ, а не ошибка")
+			assert.False(t, result.Success)
+		})
+	}
+}
+
+func TestPerformerService_AuthPerformer(t *testing.T) {
+	repo := newFakePerformerRepo()
+	svc := NewPerformerService(repo, &common.Logger{})
+	ctx := context.Background()
+
+	t.Run("Успешно: пароль в открытом виде заменяется хешем", func(t *testing.T) {
+		auth, err := svc.AuthPerformer(ctx, 1001, "1001")
+
+		require.NoError(t, err)
+		assert.True(t, auth.Success)
+		assert.True(t, isPasswordHash(repo.performers[1001].Pass))
+	})
+
+	t.Run("Успешно: вход по хешу", func(t *testing.T) {
+		hash := repo.performers[1001].Pass
+
+		auth, err := svc.AuthPerformer(ctx, 1001, "1001")
+
+		require.NoError(t, err)
+		assert.True(t, auth.Success)
+		assert.Equal(t, hash, repo.performers[1001].Pass, "хеш не перезаписывается")
+	})
+
+	t.Run("Не успешно: неверный пароль", func(t *testing.T) {
+		auth, err := svc.AuthPerformer(ctx, 1001, "1002")
+
+		require.NoError(t, err)
+		assert.False(t, auth.Success)
+	})
+
+	t.Run("Не успешно: сотрудника нет или он в архиве", func(t *testing.T) {
+		for _, id := range []int{1002, 9999} {
+			auth, err := svc.AuthPerformer(ctx, id, "1002")
+
+			require.NoError(t, err)
+			assert.False(t, auth.Success)
+		}
+	})
+}
+
+func TestPerformerService_HashLegacyPasswords(t *testing.T) {
+	repo := newFakePerformerRepo()
+	svc := NewPerformerService(repo, &common.Logger{})
+
+	report, err := svc.HashLegacyPasswords(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, len(repo.performers), report.Hashed)
+	assert.Empty(t, report.Errors)
+	for id, performer := range repo.performers {
+		assert.True(t, isPasswordHash(performer.Pass), "сотрудник %d", id)
+	}
+
+	report, err = svc.HashLegacyPasswords(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, report.Hashed, "повторный запуск ничего не меняет")
+}
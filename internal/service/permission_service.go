@@ -0,0 +1,161 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// permissionsVersionTTL - сколько версия прав ролей читается из памяти, не обращаясь к БД. Изменения прав на другом
+// экземпляре приложения доходят до сессий этого экземпляра не позже чем через это время.
+const permissionsVersionTTL = 5 * time.Second
+
+type PermissionService struct {
+	permissionRepo repository.PermissionRepository
+	roleRepo       repository.RoleRepository
+	mu             sync.Mutex
+	version        int64     // version - версия прав ролей из БД, сессии сверяют с ней свой кеш прав.
+	versionAt      time.Time // versionAt - когда версия прочитана из БД, нулевое - перечитать.
+	logg           *common.Logger
+}
+
+func NewPermissionService(
+	permissionRepo repository.PermissionRepository,
+	roleRepo repository.RoleRepository,
+	logger *common.Logger) *PermissionService {
+
+	return &PermissionService{permissionRepo: permissionRepo, roleRepo: roleRepo, logg: logger}
+}
+
+type PermissionUseCase interface {
+	GetPermissions(ctx context.Context) ([]*model.Permission, error)
+	GetRolePermissions(ctx context.Context) (map[int]map[string]bool, error)
+	GetRolePermissionCodes(ctx context.Context, roleId int) ([]string, error)
+	SetRolePermissions(ctx context.Context, update *model.RolePermissionsUpdate, performerId int) error
+	PermissionsVersion(ctx context.Context) (int64, error)
+}
+
+// GetPermissions получить список прав.
+func (p *PermissionService) GetPermissions(ctx context.Context) ([]*model.Permission, error) {
+	permissions, err := p.permissionRepo.All(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// GetRolePermissions получить права всех ролей: ид роли -> код права.
+func (p *PermissionService) GetRolePermissions(ctx context.Context) (map[int]map[string]bool, error) {
+	rolePermissions, err := p.permissionRepo.AllRolePermissions(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	result := make(map[int]map[string]bool)
+	for _, rolePermission := range rolePermissions {
+		if result[rolePermission.RoleId] == nil {
+			result[rolePermission.RoleId] = make(map[string]bool)
+		}
+		result[rolePermission.RoleId][rolePermission.Code] = true
+	}
+
+	return result, nil
+}
+
+// GetRolePermissionCodes получить коды прав роли.
+func (p *PermissionService) GetRolePermissionCodes(ctx context.Context, roleId int) ([]string, error) {
+	codes, err := p.permissionRepo.CodesByRole(ctx, roleId)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// SetRolePermissions заменить права роли. Коды прав проверяются по справочнику прав, сессии перечитают права при
+// следующем запросе.
+func (p *PermissionService) SetRolePermissions(ctx context.Context, update *model.RolePermissionsUpdate, performerId int) error {
+	exists, err := p.roleRepo.ExistById(ctx, update.RoleId)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		err = fmt.Errorf("%s: роль %d", msg.E3212, update.RoleId)
+		p.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	permissions, err := p.GetPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission.Code] = true
+	}
+
+	unique := make(map[string]bool, len(update.Codes))
+	codes := make([]string, 0, len(update.Codes))
+	for _, code := range update.Codes {
+		if !known[code] {
+			err = fmt.Errorf("%s: неизвестное право %q", msg.E3213, code)
+			p.logg.LogE(msg.E3213, err)
+
+			return err
+		}
+
+		if !unique[code] {
+			unique[code] = true
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	if err = p.permissionRepo.SetByRole(ctx, update.RoleId, codes, performerId); err != nil {
+		return err
+	}
+
+	// Версию увеличила ХП, этот экземпляр перечитывает ее сразу.
+	p.mu.Lock()
+	p.versionAt = time.Time{}
+	p.mu.Unlock()
+
+	return nil
+}
+
+// PermissionsVersion версия прав ролей. Хранится в БД, поэтому не сбрасывается при перезапуске и общая для всех
+// экземпляров приложения; читается не чаще раза в permissionsVersionTTL.
+func (p *PermissionService) PermissionsVersion(ctx context.Context) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.versionAt.IsZero() && time.Since(p.versionAt) < permissionsVersionTTL {
+		return p.version, nil
+	}
+
+	version, err := p.permissionRepo.Version(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return 0, err
+	}
+	p.version, p.versionAt = version, time.Now()
+
+	return version, nil
+}
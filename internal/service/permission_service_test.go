@@ -0,0 +1,138 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePermissionRepo struct {
+	permissions []*model.Permission
+	roles       map[int][]string
+	version     int64 // version - как svPermissionsVersion, растет в SetByRole.
+}
+
+func (f *fakePermissionRepo) All(_ context.Context) ([]*model.Permission, error) {
+	return f.permissions, nil
+}
+
+func (f *fakePermissionRepo) AllRolePermissions(_ context.Context) ([]*model.RolePermission, error) {
+	var rolePermissions []*model.RolePermission
+	for roleId, codes := range f.roles {
+		for _, code := range codes {
+			rolePermissions = append(rolePermissions, &model.RolePermission{RoleId: roleId, Code: code})
+		}
+	}
+
+	return rolePermissions, nil
+}
+
+func (f *fakePermissionRepo) CodesByRole(_ context.Context, roleId int) ([]string, error) {
+	return f.roles[roleId], nil
+}
+
+func (f *fakePermissionRepo) SetByRole(_ context.Context, roleId int, codes []string, _ int) error {
+	f.roles[roleId] = codes
+	f.version++
+
+	return nil
+}
+
+func (f *fakePermissionRepo) Version(_ context.Context) (int64, error) {
+	return f.version, nil
+}
+
+// fakeRoleRepo репозиторий ролей, неиспользуемые методы не реализованы.
+type fakeRoleRepo struct {
+	repository.RoleRepository
+	roles map[int]bool
+}
+
+func (f *fakeRoleRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return f.roles[id], nil
+}
+
+func newPermissionService() (*PermissionService, *fakePermissionRepo) {
+	repo := &fakePermissionRepo{
+		permissions: []*model.Permission{
+			{Code: model.PermAdminAccess}, {Code: model.PermRolesEdit}, {Code: model.PermShiftTasksEdit},
+		},
+		roles: map[int][]string{
+			3: {model.PermAdminAccess, model.PermRolesEdit, model.PermShiftTasksEdit},
+			5: {model.PermShiftTasksEdit},
+		},
+	}
+
+	return NewPermissionService(repo, &fakeRoleRepo{roles: map[int]bool{3: true, 4: true, 5: true}}, &common.Logger{}), repo
+}
+
+func TestPermissionService_GetRolePermissions(t *testing.T) {
+	permissionService, _ := newPermissionService()
+
+	rolePermissions, err := permissionService.GetRolePermissions(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rolePermissions[3][model.PermRolesEdit])
+	assert.True(t, rolePermissions[5][model.PermShiftTasksEdit])
+	assert.False(t, rolePermissions[5][model.PermAdminAccess])
+	assert.Empty(t, rolePermissions[4], "роль без прав")
+}
+
+func TestPermissionService_SetRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	permissionService, repo := newPermissionService()
+	version, err := permissionService.PermissionsVersion(ctx)
+	require.NoError(t, err)
+
+	err = permissionService.SetRolePermissions(ctx, &model.RolePermissionsUpdate{
+		RoleId: 4, Codes: []string{model.PermShiftTasksEdit, model.PermAdminAccess, model.PermShiftTasksEdit},
+	}, 1001)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.PermAdminAccess, model.PermShiftTasksEdit}, repo.roles[4], "коды без повторов")
+
+	changed, err := permissionService.PermissionsVersion(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, changed, version, "сессии должны перечитать права")
+
+	tests := []struct {
+		name   string
+		update *model.RolePermissionsUpdate
+	}{
+		{name: "неизвестная роль", update: &model.RolePermissionsUpdate{RoleId: 99}},
+		{name: "неизвестное право", update: &model.RolePermissionsUpdate{RoleId: 4, Codes: []string{"reports.view"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, permissionService.SetRolePermissions(ctx, tt.update, 1001))
+			assert.Equal(t, changed, repo.version)
+		})
+	}
+
+	assert.Len(t, repo.roles[4], 2, "права роли не изменились")
+}
+
+func TestPermissionService_PermissionsVersion(t *testing.T) {
+	ctx := context.Background()
+	permissionService, repo := newPermissionService()
+	repo.version = 7
+
+	version, err := permissionService.PermissionsVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), version, "версия из БД, а не с нуля после перезапуска")
+
+	// Права изменили на другом экземпляре: до истечения permissionsVersionTTL версия берется из памяти.
+	repo.version = 8
+	version, err = permissionService.PermissionsVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), version)
+
+	permissionService.versionAt = permissionService.versionAt.Add(-permissionsVersionTTL)
+	version, err = permissionService.PermissionsVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), version)
+}
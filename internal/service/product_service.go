@@ -0,0 +1,395 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+)
+
+type ProductService struct {
+	productRepo        repository.ProductRepository
+	sectorRepo         repository.SectorRepository
+	productHistoryRepo repository.ProductHistoryRepository
+	catalogRepo        repository.CatalogRepository
+	logg               *common.Logger
+}
+
+func NewProductService(
+	productRepo repository.ProductRepository,
+	sectorRepo repository.SectorRepository,
+	productHistoryRepo repository.ProductHistoryRepository,
+	catalogRepo repository.CatalogRepository,
+	logger *common.Logger) *ProductService {
+
+	return &ProductService{
+		productRepo:        productRepo,
+		sectorRepo:         sectorRepo,
+		productHistoryRepo: productHistoryRepo,
+		catalogRepo:        catalogRepo,
+		logg:               logger,
+	}
+}
+
+type ProductUseCase interface {
+	GetAllProducts(ctx context.Context) ([]*model.Product, error)
+	FindProductById(ctx context.Context, id int) (*model.Product, error)
+	UpdProduct(ctx context.Context, id int, product *model.Product, performerId int) error
+	ExistProduct(ctx context.Context, id int) (bool, error)
+	CheckProductLines(ctx context.Context) ([]*model.ProductLineIssue, error)
+	GetProductHistory(ctx context.Context, id int) ([]*model.ProductVersion, error)
+	DiffProductVersions(ctx context.Context, id, from, to int) (*model.ProductDiff, error)
+	RestoreProductVersion(ctx context.Context, id, version, performerId int) error
+	GetActiveProducts(ctx context.Context) ([]*model.Product, error)
+	GetProductsByArchive(ctx context.Context, filter model.ProductFilter) (*model.ProductArchiveList, error)
+	FilterProducts(ctx context.Context, filter model.ProductFilter) ([]*model.Product, error)
+	GetProductCatalogs(ctx context.Context) (*model.ProductCatalogs, error)
+	MapProductCatalogs(ctx context.Context) (*model.ProductCatalogMapReport, error)
+	GetProductCatalogUnmatched(ctx context.Context) ([]*model.ProductCatalogUnmatched, error)
+	ArchiveProduct(ctx context.Context, id, performerId int) error
+	UnarchiveProduct(ctx context.Context, id, performerId int) error
+}
+
+func (p *ProductService) GetAllProducts(ctx context.Context) ([]*model.Product, error) {
+	products, err := p.productRepo.All(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetActiveProducts продукция для выбора (без архивной).
+func (p *ProductService) GetActiveProducts(ctx context.Context) ([]*model.Product, error) {
+	list, err := p.GetProductsByArchive(ctx, model.ProductFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Active, nil
+}
+
+// GetProductsByArchive продукция, разделенная на действующую и архивную, с отбором по справочникам.
+func (p *ProductService) GetProductsByArchive(ctx context.Context, filter model.ProductFilter) (*model.ProductArchiveList, error) {
+	products, err := p.FilterProducts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &model.ProductArchiveList{Active: []*model.Product{}, Archived: []*model.Product{}}
+	for _, product := range products {
+		if product.Archive {
+			list.Archived = append(list.Archived, product)
+		} else {
+			list.Active = append(list.Active, product)
+		}
+	}
+
+	return list, nil
+}
+
+// FilterProducts продукция с отбором по цвету и конструкторскому наименованию.
+func (p *ProductService) FilterProducts(ctx context.Context, filter model.ProductFilter) ([]*model.Product, error) {
+	products, err := p.GetAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*model.Product, 0, len(products))
+	for _, product := range products {
+		if filter.Match(product) {
+			filtered = append(filtered, product)
+		}
+	}
+
+	return filtered, nil
+}
+
+// GetProductCatalogs справочники цветов и конструкторских наименований.
+func (p *ProductService) GetProductCatalogs(ctx context.Context) (*model.ProductCatalogs, error) {
+	colors, err := p.catalogRepo.AllByKodcat(ctx, model.KodcatColor)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	designs, err := p.catalogRepo.AllByKodcat(ctx, model.KodcatDesign)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return &model.ProductCatalogs{Colors: colors, Designs: designs}, nil
+}
+
+// MapProductCatalogs привязать продукцию к справочникам по текстовым PrColor и PrShortName, вернуть итог и
+// несопоставленные значения.
+func (p *ProductService) MapProductCatalogs(ctx context.Context) (*model.ProductCatalogMapReport, error) {
+	report, err := p.productRepo.MapCatalogs(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3217, err)
+
+		return nil, err
+	}
+
+	if report.Unmatched, err = p.GetProductCatalogUnmatched(ctx); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GetProductCatalogUnmatched текстовые значения продукции без записи в справочниках.
+func (p *ProductService) GetProductCatalogUnmatched(ctx context.Context) ([]*model.ProductCatalogUnmatched, error) {
+	unmatched, err := p.productRepo.CatalogUnmatched(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	if unmatched == nil {
+		unmatched = []*model.ProductCatalogUnmatched{}
+	}
+
+	return unmatched, nil
+}
+
+// ArchiveProduct архивировать продукцию. Продукцию с остатком на складе (не отгруженные п\п) или с открытыми заявками
+// (невыполненные сменно-суточные задания) архивировать нельзя. Архивная продукция не выбирается в заданиях и не
+// печатается.
+func (p *ProductService) ArchiveProduct(ctx context.Context, id, performerId int) error {
+	return p.setArchive(ctx, id, true, performerId)
+}
+
+// UnarchiveProduct вернуть продукцию из архива.
+func (p *ProductService) UnarchiveProduct(ctx context.Context, id, performerId int) error {
+	return p.setArchive(ctx, id, false, performerId)
+}
+
+func (p *ProductService) FindProductById(ctx context.Context, id int) (*model.Product, error) {
+	product, err := p.productRepo.FindById(ctx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// UpdProduct обновляет продукцию, отклоняя машинную линию, которая не принадлежит печи продукции.
+// Каждое обновление сохраняется версией в истории продукции, автор версии - сотрудник performerId.
+func (p *ProductService) UpdProduct(ctx context.Context, id int, product *model.Product, performerId int) error {
+	if err := model.ValidateUpdateDataProduct(product); err != nil {
+		p.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	furnaceLines, err := p.furnaceLines(ctx)
+	if err != nil {
+		return err
+	}
+
+	product.Id = id
+	if issue := model.CheckProductLine(product, furnaceLines); issue != nil {
+		err = fmt.Errorf("%s: %s", msg.E3220, issue.Reason)
+		p.logg.LogE(msg.E3220, err)
+
+		return err
+	}
+
+	if err = p.checkProductCatalogs(ctx, product); err != nil {
+		return err
+	}
+
+	if err = p.productRepo.UpdById(ctx, id, product, performerId); err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetProductHistory версии продукции с изменениями относительно предыдущей версии.
+func (p *ProductService) GetProductHistory(ctx context.Context, id int) ([]*model.ProductVersion, error) {
+	versions, err := p.productHistoryRepo.AllByProduct(ctx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	for i := 1; i < len(versions); i++ {
+		versions[i].Changes = model.DiffProducts(versions[i-1].Snapshot, versions[i].Snapshot)
+	}
+
+	return versions, nil
+}
+
+// DiffProductVersions изменения полей продукции между версиями from и to.
+func (p *ProductService) DiffProductVersions(ctx context.Context, id, from, to int) (*model.ProductDiff, error) {
+	versions, err := p.productHistoryRepo.AllByProduct(ctx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	fromVersion, err := model.FindProductVersion(versions, from)
+	if err != nil {
+		p.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	toVersion, err := model.FindProductVersion(versions, to)
+	if err != nil {
+		p.logg.LogE(msg.E3212, err)
+
+		return nil, err
+	}
+
+	return &model.ProductDiff{
+		ProductId: id,
+		From:      from,
+		To:        to,
+		Changes:   model.DiffProducts(fromVersion.Snapshot, toVersion.Snapshot),
+	}, nil
+}
+
+// RestoreProductVersion восстановить продукцию из версии, восстановление сохраняется новой версией.
+func (p *ProductService) RestoreProductVersion(ctx context.Context, id, version, performerId int) error {
+	versions, err := p.productHistoryRepo.AllByProduct(ctx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return err
+	}
+
+	restored, err := model.FindProductVersion(versions, version)
+	if err != nil {
+		p.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	product := *restored.Snapshot
+
+	return p.UpdProduct(ctx, id, &product, performerId)
+}
+
+// setArchive изменить признак архива, изменение сохраняется версией в истории продукции.
+func (p *ProductService) setArchive(ctx context.Context, id int, archive bool, performerId int) error {
+	before, err := p.productRepo.FindById(ctx, id)
+	if err != nil {
+		p.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	if before.Archive == archive {
+		return nil
+	}
+
+	result, err := p.productRepo.ArchiveById(ctx, id, archive, performerId)
+	if err != nil {
+		p.logg.LogE(msg.E3216, err)
+
+		return err
+	}
+
+	switch result {
+	case model.ArchiveBlockedShiftTask:
+		err = fmt.Errorf("%s: продукция %d", msg.E3225, id)
+		p.logg.LogE(msg.E3225, err)
+
+		return err
+	case model.ArchiveBlockedStock:
+		err = fmt.Errorf("%s: продукция %d", msg.E3236, id)
+		p.logg.LogE(msg.E3236, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *ProductService) ExistProduct(ctx context.Context, id int) (bool, error) {
+	return p.productRepo.ExistById(ctx, id)
+}
+
+// CheckProductLines отчет о продукции, у которой машинная линия не принадлежит печи.
+func (p *ProductService) CheckProductLines(ctx context.Context) ([]*model.ProductLineIssue, error) {
+	products, err := p.GetAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	furnaceLines, err := p.furnaceLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]*model.ProductLineIssue, 0)
+	for _, product := range products {
+		if issue := model.CheckProductLine(product, furnaceLines); issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// checkProductCatalogs цвет и конструкторское наименование продукции должны быть действующими записями справочников.
+func (p *ProductService) checkProductCatalogs(ctx context.Context, product *model.Product) error {
+	refs := []struct {
+		kodcat int
+		id     int
+	}{
+		{kodcat: model.KodcatColor, id: product.ColorId},
+		{kodcat: model.KodcatDesign, id: product.DesignId},
+	}
+
+	for _, ref := range refs {
+		if ref.id == 0 {
+			continue
+		}
+
+		catalogs, err := p.catalogRepo.AllByKodcat(ctx, ref.kodcat)
+		if err != nil {
+			p.logg.LogE(msg.E3209, err)
+
+			return err
+		}
+
+		if !model.ContainsCatalogId(catalogs, ref.id) {
+			err = fmt.Errorf("%s: kodcat %d, ид %d", msg.E3224, ref.kodcat, ref.id)
+			p.logg.LogE(msg.E3224, err)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// furnaceLines линии печей по номеру ванной печи из svTB_Sector.
+func (p *ProductService) furnaceLines(ctx context.Context) (map[int][]int, error) {
+	sectors, err := p.sectorRepo.All(ctx)
+	if err != nil {
+		p.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return model.FurnaceLines(sectors), nil
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProductRepo репозиторий продукции в памяти, изменения сохраняются версиями в history, если она задана.
+type fakeProductRepo struct {
+	products map[int]*model.Product
+	updated  []int
+	inTasks  map[int]bool
+	inStock  map[int]bool
+	history  *fakeProductHistoryRepo
+}
+
+func (f *fakeProductRepo) All(_ context.Context) ([]*model.Product, error) {
+	var products []*model.Product
+	for id := 1; id <= len(f.products); id++ {
+		products = append(products, f.products[id])
+	}
+
+	return products, nil
+}
+
+func (f *fakeProductRepo) FindById(_ context.Context, id int) (*model.Product, error) {
+	return f.products[id], nil
+}
+
+func (f *fakeProductRepo) UpdById(_ context.Context, id int, product *model.Product, performerId int) error {
+	before := f.products[id]
+	product.AuditRec.UpdatedBy = performerId
+	f.products[id] = product
+	f.updated = append(f.updated, id)
+	f.history.record(before, product, performerId)
+
+	return nil
+}
+
+func (f *fakeProductRepo) ExistById(_ context.Context, id int) (bool, error) {
+	_, ok := f.products[id]
+
+	return ok, nil
+}
+
+func (f *fakeProductRepo) ArchiveById(_ context.Context, id int, archive bool, performerId int) (int, error) {
+	if archive && f.inTasks[id] {
+		return model.ArchiveBlockedShiftTask, nil
+	}
+
+	if archive && f.inStock[id] {
+		return model.ArchiveBlockedStock, nil
+	}
+
+	before := f.products[id]
+	product := *before
+	product.Archive = archive
+	f.products[id] = &product
+	f.history.record(before, &product, performerId)
+
+	return model.ArchiveDone, nil
+}
+
+func (f *fakeProductRepo) MapCatalogs(_ context.Context) (*model.ProductCatalogMapReport, error) {
+	return &model.ProductCatalogMapReport{}, nil
+}
+
+func (f *fakeProductRepo) CatalogUnmatched(_ context.Context) ([]*model.ProductCatalogUnmatched, error) {
+	return nil, nil
+}
+
+type fakeProductHistoryRepo struct {
+	versions []*model.ProductVersion
+}
+
+// record сохранить версию после изменения, как ProductRepo.withVersion: без истории первой версией сохраняется
+// состояние до изменения.
+func (f *fakeProductHistoryRepo) record(before, after *model.Product, performerId int) {
+	if f == nil {
+		return
+	}
+
+	versions, _ := f.AllByProduct(context.Background(), before.Id)
+	if len(versions) == 0 {
+		f.add(before, before.AuditRec.UpdatedBy)
+	}
+	f.add(after, performerId)
+}
+
+func (f *fakeProductHistoryRepo) add(product *model.Product, performerId int) {
+	snapshot := *product
+	version := 1
+	for _, v := range f.versions {
+		if v.ProductId == product.Id {
+			version++
+		}
+	}
+
+	f.versions = append(f.versions, &model.ProductVersion{
+		ProductId: product.Id,
+		Version:   version,
+		Snapshot:  &snapshot,
+		CreatedBy: performerId,
+	})
+}
+
+func (f *fakeProductHistoryRepo) AllByProduct(_ context.Context, productId int) ([]*model.ProductVersion, error) {
+	var versions []*model.ProductVersion
+	for _, v := range f.versions {
+		if v.ProductId == productId {
+			copied := *v
+			versions = append(versions, &copied)
+		}
+	}
+
+	return versions, nil
+}
+
+type fakeSectorRepo struct {
+	sectors []*model.Sector
+}
+
+func (f *fakeSectorRepo) All(_ context.Context) ([]*model.Sector, error) {
+	return f.sectors, nil
+}
+
+func (f *fakeSectorRepo) ExistById(_ context.Context, id int) (bool, error) {
+	for _, sector := range f.sectors {
+		if sector.Id == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func newFakeSectorRepo() *fakeSectorRepo {
+	return &fakeSectorRepo{sectors: []*model.Sector{
+		{Id: 1, Name: "ВП №3 (Лин:31,32)", Lines: "31,32"},
+		{Id: 2, Name: "ВП №7 (ВП-№7)", Lines: "71,72,73"},
+		{Id: 3, Name: "Переупаковка (УПУ)", Lines: ""},
+	}}
+}
+
+func TestProductService_CheckProductLines(t *testing.T) {
+	repo := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Article: "A0001", VP: 3, ML: 31},
+		2: {Id: 2, Article: "A0002", VP: 3, ML: 72},
+		3: {Id: 3, Article: "A0003", VP: 9, ML: 91},
+		4: {Id: 4, Article: "A0004", VP: 0, ML: 0},
+	}}
+	svc := NewProductService(repo, newFakeSectorRepo(), &fakeProductHistoryRepo{}, &fakeCatalogRepo{}, &common.Logger{})
+
+	issues, err := svc.CheckProductLines(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, 2, issues[0].ProductId)
+	assert.Equal(t, 3, issues[1].ProductId)
+}
+
+func TestProductService_UpdProduct(t *testing.T) {
+	repo := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Article: "A0001", VP: 3, ML: 31},
+	}}
+	svc := NewProductService(repo, newFakeSectorRepo(), &fakeProductHistoryRepo{}, &fakeCatalogRepo{}, &common.Logger{})
+
+	t.Run("Не успешно: линия не принадлежит печи", func(t *testing.T) {
+		product := &model.Product{Name: "Бутылка", Article: "A0001", VP: 3, ML: 71, AuditRec: model.Audit{UpdatedBy: 1}}
+
+		err := svc.UpdProduct(context.Background(), 1, product, 1)
+
+		assert.Error(t, err)
+		assert.Empty(t, repo.updated)
+	})
+
+	t.Run("Успешно: линия принадлежит печи", func(t *testing.T) {
+		product := &model.Product{Name: "Бутылка", Article: "A0001", VP: 7, ML: 73, AuditRec: model.Audit{UpdatedBy: 1}}
+
+		err := svc.UpdProduct(context.Background(), 1, product, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, repo.updated)
+	})
+}
+
+func TestProductService_History(t *testing.T) {
+	repo := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Name: "Бутылка", Article: "A0001", BarCode: "4600000000017", PerGodn: 12, AuditRec: model.Audit{UpdatedBy: 5}},
+	}}
+	history := &fakeProductHistoryRepo{}
+	repo.history = history
+	svc := NewProductService(repo, newFakeSectorRepo(), history, &fakeCatalogRepo{}, &common.Logger{})
+	ctx := context.Background()
+
+	update := *repo.products[1]
+	update.BarCode = "4600000000024"
+	update.AuditRec.UpdatedBy = 1 // автор из тела запроса не учитывается
+	require.NoError(t, svc.UpdProduct(ctx, 1, &update, 7))
+
+	update = *repo.products[1]
+	update.PerGodn = 24
+	require.NoError(t, svc.UpdProduct(ctx, 1, &update, 8))
+
+	versions, err := svc.GetProductHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 3, "исходное состояние и две правки")
+	assert.Empty(t, versions[0].Changes)
+	assert.Equal(t, []*model.ProductFieldDiff{{Field: "barCode", Old: "4600000000017", New: "4600000000024"}}, versions[1].Changes)
+	assert.Equal(t, 7, versions[1].CreatedBy)
+	assert.Equal(t, 8, versions[2].CreatedBy)
+	assert.Equal(t, []*model.ProductFieldDiff{{Field: "perGodn", Old: 12, New: 24}}, versions[2].Changes)
+
+	diff, err := svc.DiffProductVersions(ctx, 1, 1, 3)
+	require.NoError(t, err)
+	assert.Len(t, diff.Changes, 2)
+
+	_, err = svc.DiffProductVersions(ctx, 1, 1, 9)
+	assert.Error(t, err)
+
+	require.NoError(t, svc.RestoreProductVersion(ctx, 1, 1, 9))
+	assert.Equal(t, "4600000000017", repo.products[1].BarCode)
+	assert.Equal(t, 12, repo.products[1].PerGodn)
+
+	versions, err = svc.GetProductHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 4)
+	assert.Equal(t, 9, versions[3].CreatedBy)
+	assert.Len(t, versions[3].Changes, 2)
+}
+
+func TestProductService_Archive(t *testing.T) {
+	repo := &fakeProductRepo{
+		products: map[int]*model.Product{
+			1: {Id: 1, Article: "A0001"},
+			2: {Id: 2, Article: "A0002"},
+			3: {Id: 3, Article: "A0003"},
+		},
+		inTasks: map[int]bool{2: true},
+		inStock: map[int]bool{3: true},
+	}
+	history := &fakeProductHistoryRepo{}
+	repo.history = history
+	svc := NewProductService(repo, newFakeSectorRepo(), history, &fakeCatalogRepo{}, &common.Logger{})
+	ctx := context.Background()
+
+	require.NoError(t, svc.ArchiveProduct(ctx, 1, 5))
+	assert.True(t, repo.products[1].Archive)
+
+	err := svc.ArchiveProduct(ctx, 2, 5)
+	assert.Error(t, err, "есть невыполненные сменно-суточные задания")
+	assert.False(t, repo.products[2].Archive)
+
+	err = svc.ArchiveProduct(ctx, 3, 5)
+	assert.Error(t, err, "есть остаток на складе")
+	assert.False(t, repo.products[3].Archive)
+
+	list, err := svc.GetProductsByArchive(ctx, model.ProductFilter{})
+	require.NoError(t, err)
+	require.Len(t, list.Active, 2)
+	require.Len(t, list.Archived, 1)
+	assert.Equal(t, 2, list.Active[0].Id)
+	assert.Equal(t, 1, list.Archived[0].Id)
+
+	require.NoError(t, svc.UnarchiveProduct(ctx, 1, 5))
+	assert.False(t, repo.products[1].Archive)
+
+	versions, err := svc.GetProductHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, []*model.ProductFieldDiff{{Field: "archive", Old: false, New: true}}, versions[1].Changes)
+}
+
+func TestProductService_Catalogs(t *testing.T) {
+	repo := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Name: "Бутылка", Article: "A0001", ColorId: 10, DesignId: 20},
+		2: {Id: 2, Name: "Бутылка", Article: "A0002", ColorId: 11, DesignId: 20},
+		3: {Id: 3, Name: "Банка", Article: "A0003", ColorId: 10},
+	}}
+	catalogs := &fakeCatalogRepo{catalogs: []*model.Catalog{
+		{Id: 10, Kodcat: model.KodcatColor, Name: "Бесцветный"},
+		{Id: 11, Kodcat: model.KodcatColor, Name: "Коричневый"},
+		{Id: 20, Kodcat: model.KodcatDesign, Name: "КПМ-30-500"},
+	}}
+	svc := NewProductService(repo, newFakeSectorRepo(), &fakeProductHistoryRepo{}, catalogs, &common.Logger{})
+	ctx := context.Background()
+
+	products, err := svc.FilterProducts(ctx, model.ProductFilter{ColorId: 10})
+	require.NoError(t, err)
+	assert.Len(t, products, 2)
+
+	products, err = svc.FilterProducts(ctx, model.ProductFilter{ColorId: 10, DesignId: 20})
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, 1, products[0].Id)
+
+	update := &model.Product{Name: "Банка", Article: "A0003", ColorId: 20, AuditRec: model.Audit{UpdatedBy: 1}}
+	assert.Error(t, svc.UpdProduct(ctx, 3, update, 1), "ид записи не из справочника цветов")
+	assert.Empty(t, repo.updated)
+
+	update.ColorId, update.DesignId = 11, 20
+	require.NoError(t, svc.UpdProduct(ctx, 3, update, 1))
+	assert.Equal(t, 11, repo.products[3].ColorId)
+
+	report, err := svc.MapProductCatalogs(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, report.Unmatched)
+}
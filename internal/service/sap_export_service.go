@@ -0,0 +1,166 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+type SapExportService struct {
+	sapExportRepo repository.SapExportRepository
+	cfg           *config.SapExportCfg
+	logg          *common.Logger
+}
+
+func NewSapExportService(sapExportRepo repository.SapExportRepository, cfg *config.SapExportCfg, logger *common.Logger) *SapExportService {
+	return &SapExportService{sapExportRepo: sapExportRepo, cfg: cfg, logg: logger}
+}
+
+type SapExportUseCase interface {
+	ExportRange(ctx context.Context, from, to time.Time) ([]*model.SapExportReport, error)
+}
+
+// ExportRange выгрузить приход, отгрузку и остаток за каждый день периода. Повторная выгрузка дня перезаписывает его
+// файлы.
+func (s *SapExportService) ExportRange(ctx context.Context, from, to time.Time) ([]*model.SapExportReport, error) {
+	if err := os.MkdirAll(s.cfg.Dir, 0o755); err != nil {
+		s.logg.LogE(msg.E3229, err)
+
+		return nil, err
+	}
+
+	var reports []*model.SapExportReport
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		report, err := s.exportDay(ctx, day)
+		if err != nil {
+			return reports, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (s *SapExportService) exportDay(ctx context.Context, day time.Time) (*model.SapExportReport, error) {
+	date := day.Format(model.SapExportDateLayout)
+	report := &model.SapExportReport{Date: date, Rows: map[string]int{}}
+
+	kinds := []struct {
+		kind  model.SapKind
+		fetch func(ctx context.Context, date string) ([]*model.SapRow, error)
+	}{
+		{kind: model.SapKindReceipts, fetch: s.sapExportRepo.ReceiptsByDate},
+		{kind: model.SapKindShipments, fetch: s.sapExportRepo.ShipmentsByDate},
+		{kind: model.SapKindStock, fetch: s.sapExportRepo.StockByDate},
+	}
+
+	for _, k := range kinds {
+		rows, err := k.fetch(ctx, date)
+		if err != nil {
+			s.logg.LogE(msg.E3209, err)
+
+			return nil, err
+		}
+
+		report.Rows[k.kind.Name] = len(rows)
+
+		files, err := s.writeKind(k.kind, day, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Files = append(report.Files, files...)
+	}
+
+	return report, nil
+}
+
+// writeKind записать файлы вида выгрузки за день во всех форматах.
+func (s *SapExportService) writeKind(kind model.SapKind, day time.Time, rows []*model.SapRow) ([]string, error) {
+	var files []string
+
+	for _, format := range s.cfg.Formats {
+		var data []byte
+		var err error
+		switch format {
+		case config.SapFormatCSV:
+			data, err = sapCSV(rows)
+		case config.SapFormatXML:
+			data, err = sapXML(kind, day, rows)
+		default:
+			continue
+		}
+
+		if err != nil {
+			s.logg.LogE(msg.E3229, err)
+
+			return nil, err
+		}
+
+		fileName := filepath.Join(s.cfg.Dir, model.SapFileName(kind.Name, day, format))
+		if err = writeFileAtomic(fileName, data); err != nil {
+			s.logg.LogE(msg.E3229, err)
+
+			return nil, err
+		}
+
+		files = append(files, fileName)
+	}
+
+	return files, nil
+}
+
+func sapCSV(rows []*model.SapRow) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+
+	records := [][]string{{"DATE", "MATNR", "ARTICLE", "MAKTX", "PALLETS", "MENGE"}}
+	for _, row := range rows {
+		records = append(records, []string{
+			row.Date,
+			row.SapCode,
+			row.Article,
+			row.Name,
+			strconv.Itoa(row.Pallets),
+			strconv.Itoa(row.Qty),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func sapXML(kind model.SapKind, day time.Time, rows []*model.SapRow) ([]byte, error) {
+	data, err := xml.MarshalIndent(model.NewSapIDoc(kind, day, rows), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// writeFileAtomic запись через временный файл, чтобы SAP не забрал недописанный файл.
+func writeFileAtomic(fileName string, data []byte) error {
+	tmpName := fileName + ".tmp"
+	if err := os.WriteFile(tmpName, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, fileName)
+}
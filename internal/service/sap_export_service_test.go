@@ -0,0 +1,90 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSapExportRepo struct {
+	receipts  map[string][]*model.SapRow
+	shipments map[string][]*model.SapRow
+	stock     map[string][]*model.SapRow
+}
+
+func (f *fakeSapExportRepo) ReceiptsByDate(_ context.Context, date string) ([]*model.SapRow, error) {
+	return f.receipts[date], nil
+}
+
+func (f *fakeSapExportRepo) ShipmentsByDate(_ context.Context, date string) ([]*model.SapRow, error) {
+	return f.shipments[date], nil
+}
+
+func (f *fakeSapExportRepo) StockByDate(_ context.Context, date string) ([]*model.SapRow, error) {
+	return f.stock[date], nil
+}
+
+func TestSapExportService_ExportRange(t *testing.T) {
+	dir := t.TempDir()
+	repo := &fakeSapExportRepo{
+		receipts: map[string][]*model.SapRow{
+			"2026-10-01": {{Date: "2026-10-01", SapCode: "100200", Article: "A0001", Name: "Бутылка 0,5", Pallets: 3, Qty: 5400}},
+		},
+		shipments: map[string][]*model.SapRow{
+			"2026-10-01": {{Date: "2026-10-01", SapCode: "100200", Article: "A0001", Name: "Бутылка 0,5", Pallets: 1, Qty: 1800}},
+		},
+		stock: map[string][]*model.SapRow{
+			"2026-10-01": {{Date: "2026-10-01", SapCode: "100200", Article: "A0001", Name: "Бутылка 0,5", Pallets: 2, Qty: 3600}},
+			"2026-10-02": {{Date: "2026-10-02", SapCode: "100200", Article: "A0001", Name: "Бутылка 0,5", Pallets: 2, Qty: 3600}},
+		},
+	}
+	cfg := &config.SapExportCfg{Dir: dir, Formats: []string{config.SapFormatCSV, config.SapFormatXML}}
+	svc := NewSapExportService(repo, cfg, &common.Logger{})
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	reports, err := svc.ExportRange(context.Background(), from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, map[string]int{"receipts": 1, "shipments": 1, "stock": 1}, reports[0].Rows)
+	assert.Equal(t, map[string]int{"receipts": 0, "shipments": 0, "stock": 1}, reports[1].Rows)
+
+	csvData, err := os.ReadFile(filepath.Join(dir, "FGW_RECEIPTS_20261001.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(csvData), "2026-10-01;100200;A0001;Бутылка 0,5;3;5400")
+
+	xmlData, err := os.ReadFile(filepath.Join(dir, "FGW_RECEIPTS_20261001.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(xmlData), "<DOCNUM>FGW20261001</DOCNUM>")
+	assert.Contains(t, string(xmlData), "<MATNR>100200</MATNR>")
+	assert.Contains(t, string(xmlData), "<E1FGWRCPT SEGMENT=\"1\">")
+
+	csvData, err = os.ReadFile(filepath.Join(dir, "FGW_SHIPMENTS_20261001.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(csvData), "2026-10-01;100200;A0001;Бутылка 0,5;1;1800")
+
+	xmlData, err = os.ReadFile(filepath.Join(dir, "FGW_STOCK_20261002.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(xmlData), "<ZFGW_STOCK>")
+	assert.Contains(t, string(xmlData), "<DOCNUM>FGB20261002</DOCNUM>")
+	assert.Contains(t, string(xmlData), "<E1FGWSTCK SEGMENT=\"1\">")
+
+	// повторная выгрузка дня перезаписывает те же файлы.
+	_, err = svc.ExportRange(context.Background(), from, from)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 12, "3 вида выгрузки * 2 формата * 2 дня")
+	for _, entry := range entries {
+		assert.False(t, strings.HasSuffix(entry.Name(), ".tmp"))
+	}
+}
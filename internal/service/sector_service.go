@@ -0,0 +1,38 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+)
+
+type SectorService struct {
+	sectorRepo repository.SectorRepository
+	logg       *common.Logger
+}
+
+func NewSectorService(sectorRepo repository.SectorRepository, logger *common.Logger) *SectorService {
+	return &SectorService{sectorRepo: sectorRepo, logg: logger}
+}
+
+type SectorUseCase interface {
+	GetAllSectors(ctx context.Context) ([]*model.Sector, error)
+	ExistSector(ctx context.Context, id int) (bool, error)
+}
+
+func (s *SectorService) GetAllSectors(ctx context.Context) ([]*model.Sector, error) {
+	sectors, err := s.sectorRepo.All(ctx)
+	if err != nil {
+		s.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return sectors, nil
+}
+
+func (s *SectorService) ExistSector(ctx context.Context, id int) (bool, error) {
+	return s.sectorRepo.ExistById(ctx, id)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+	"time"
+)
+
+// sessionIdLen длина ид сессии - SHA-256 токена в hex.
+const sessionIdLen = 64
+
+type SessionService struct {
+	sessionRepo repository.SessionRepository
+	logg        *common.Logger
+}
+
+func NewSessionService(sessionRepo repository.SessionRepository, logger *common.Logger) *SessionService {
+	return &SessionService{sessionRepo: sessionRepo, logg: logger}
+}
+
+type SessionUseCase interface {
+	RegisterSession(ctx context.Context, token string, session *model.SessionRecord) error
+	CheckSession(ctx context.Context, token string) (bool, error)
+	CheckSessionById(ctx context.Context, id string) (bool, error)
+	RevokeSession(ctx context.Context, token string) (bool, error)
+	RevokeSessionById(ctx context.Context, id string) error
+	RevokePerformerSessions(ctx context.Context, performerId int) (int, error)
+	GetActiveSessions(ctx context.Context) ([]*model.SessionRecord, error)
+}
+
+// RegisterSession зарегистрировать сессию по токену, в реестре сохраняется только хеш токена.
+func (s *SessionService) RegisterSession(ctx context.Context, token string, session *model.SessionRecord) error {
+	if token == "" || session.PerformerId == 0 || !session.ExpiresAt.After(session.CreatedAt) {
+		err := fmt.Errorf("%s: токен, сотрудник и срок действия сессии обязательны", msg.E3213)
+		s.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	session.Id = model.SessionTokenId(token)
+
+	return s.sessionRepo.Add(ctx, session)
+}
+
+// CheckSession проверить, что сессия зарегистрирована и не отозвана, и отметить её активность.
+func (s *SessionService) CheckSession(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	return s.sessionRepo.Touch(ctx, model.SessionTokenId(token), time.Now())
+}
+
+// CheckSessionById проверить сессию по ид в реестре, для токена доступа, который ссылается на токен обновления.
+func (s *SessionService) CheckSessionById(ctx context.Context, id string) (bool, error) {
+	if len(id) != sessionIdLen {
+		return false, nil
+	}
+
+	return s.sessionRepo.Touch(ctx, id, time.Now())
+}
+
+// RevokeSession отозвать сессию по токену, false - сессия не найдена или уже отозвана.
+func (s *SessionService) RevokeSession(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	return s.sessionRepo.Revoke(ctx, model.SessionTokenId(token))
+}
+
+// RevokeSessionById отозвать сессию по ид в реестре, для администратора, которому токен сессии неизвестен.
+func (s *SessionService) RevokeSessionById(ctx context.Context, id string) error {
+	if len(id) != sessionIdLen {
+		err := fmt.Errorf("%s: ид сессии %q", msg.E3213, id)
+		s.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	_, err := s.sessionRepo.Revoke(ctx, id)
+
+	return err
+}
+
+// RevokePerformerSessions отозвать все сессии сотрудника, возвращает кол-во отозванных.
+func (s *SessionService) RevokePerformerSessions(ctx context.Context, performerId int) (int, error) {
+	return s.sessionRepo.RevokeByPerformer(ctx, performerId)
+}
+
+// GetActiveSessions получить действующие сессии.
+func (s *SessionService) GetActiveSessions(ctx context.Context) ([]*model.SessionRecord, error) {
+	sessions, err := s.sessionRepo.AllActive(ctx)
+	if err != nil {
+		s.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return sessions, nil
+}
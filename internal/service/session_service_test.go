@@ -0,0 +1,100 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession(performerId int, ttl time.Duration) *model.SessionRecord {
+	now := time.Now()
+
+	return &model.SessionRecord{PerformerId: performerId, RoleId: 4, CreatedAt: now, ExpiresAt: now.Add(ttl)}
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	sessionService := NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+
+	require.NoError(t, sessionService.RegisterSession(ctx, "token-a", newTestSession(1001, time.Hour)))
+	require.NoError(t, sessionService.RegisterSession(ctx, "token-b", newTestSession(1001, time.Hour)))
+	require.NoError(t, sessionService.RegisterSession(ctx, "token-c", newTestSession(1002, time.Hour)))
+
+	active, err := sessionService.CheckSession(ctx, "token-a")
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	active, err = sessionService.CheckSession(ctx, "token-unknown")
+	require.NoError(t, err)
+	assert.False(t, active, "незарегистрированный токен")
+
+	sessions, err := sessionService.GetActiveSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	for _, session := range sessions {
+		assert.Len(t, session.Id, 64)
+		assert.NotContains(t, session.Id, "token", "в реестре хранится только хеш токена")
+	}
+
+	revokedNow, err := sessionService.RevokeSession(ctx, "token-a")
+	require.NoError(t, err)
+	assert.True(t, revokedNow)
+
+	revokedNow, err = sessionService.RevokeSession(ctx, "token-a")
+	require.NoError(t, err)
+	assert.False(t, revokedNow, "сессия уже отозвана")
+
+	active, err = sessionService.CheckSession(ctx, "token-a")
+	require.NoError(t, err)
+	assert.False(t, active, "отозванная сессия")
+
+	revoked, err := sessionService.RevokePerformerSessions(ctx, 1001)
+	require.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+
+	active, err = sessionService.CheckSession(ctx, "token-b")
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	active, err = sessionService.CheckSession(ctx, "token-c")
+	require.NoError(t, err)
+	assert.True(t, active, "сессии другого сотрудника не отзываются")
+}
+
+func TestSessionService_Register(t *testing.T) {
+	ctx := context.Background()
+	sessionService := NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+
+	assert.Error(t, sessionService.RegisterSession(ctx, "", newTestSession(1001, time.Hour)))
+	assert.Error(t, sessionService.RegisterSession(ctx, "token", newTestSession(0, time.Hour)))
+
+	assert.Error(t, sessionService.RegisterSession(ctx, "token", newTestSession(1001, -time.Hour)))
+
+	expired := newTestSession(1001, time.Hour)
+	expired.CreatedAt = expired.CreatedAt.Add(-2 * time.Hour)
+	expired.ExpiresAt = expired.ExpiresAt.Add(-2 * time.Hour)
+	require.NoError(t, sessionService.RegisterSession(ctx, "expired", expired))
+	active, err := sessionService.CheckSession(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, active, "истекшая сессия")
+}
+
+func TestSessionService_RevokeSessionById(t *testing.T) {
+	ctx := context.Background()
+	sessionService := NewSessionService(repository.NewSessionMemoryRepo(), &common.Logger{})
+
+	require.NoError(t, sessionService.RegisterSession(ctx, "token-a", newTestSession(1001, time.Hour)))
+
+	assert.Error(t, sessionService.RevokeSessionById(ctx, "token-a"), "токен вместо ид сессии")
+
+	require.NoError(t, sessionService.RevokeSessionById(ctx, model.SessionTokenId("token-a")))
+	active, err := sessionService.CheckSession(ctx, "token-a")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
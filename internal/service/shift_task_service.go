@@ -0,0 +1,132 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"fmt"
+)
+
+type ShiftTaskService struct {
+	shiftTaskRepo repository.ShiftTaskRepository
+	sectorRepo    repository.SectorRepository
+	productRepo   repository.ProductRepository
+	logg          *common.Logger
+}
+
+func NewShiftTaskService(
+	shiftTaskRepo repository.ShiftTaskRepository,
+	sectorRepo repository.SectorRepository,
+	productRepo repository.ProductRepository,
+	logger *common.Logger) *ShiftTaskService {
+
+	return &ShiftTaskService{shiftTaskRepo: shiftTaskRepo, sectorRepo: sectorRepo, productRepo: productRepo, logg: logger}
+}
+
+type ShiftTaskUseCase interface {
+	GetShiftTasks(ctx context.Context, taskDate string, shiftNum int) ([]*model.ShiftTask, error)
+	AddShiftTask(ctx context.Context, task *model.ShiftTask, performerId int) error
+	ExistShiftTask(ctx context.Context, id int) (bool, error)
+}
+
+// GetShiftTasks получить задания на дату (пустая - текущий день), shiftNum = 0 - все смены.
+func (s *ShiftTaskService) GetShiftTasks(ctx context.Context, taskDate string, shiftNum int) ([]*model.ShiftTask, error) {
+	date, err := model.ParseShiftTaskDate(taskDate)
+	if err != nil {
+		s.logg.LogE(msg.E3213, err)
+
+		return nil, err
+	}
+
+	tasks, err := s.shiftTaskRepo.AllByDate(ctx, date, shiftNum)
+	if err != nil {
+		s.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// AddShiftTask добавить задание, машинная линия должна принадлежать печи, продукция должна существовать и не быть
+// архивной.
+func (s *ShiftTaskService) AddShiftTask(ctx context.Context, task *model.ShiftTask, performerId int) error {
+	if err := model.ValidateShiftTask(task); err != nil {
+		s.logg.LogE(msg.E3213, err)
+
+		return err
+	}
+
+	sector, err := s.findSector(ctx, task.SectorId)
+	if err != nil {
+		return err
+	}
+
+	if !containsLine(sector.LineNumbers(), task.LineNum) {
+		err = fmt.Errorf("%s: линия %d, печь %q", msg.E3221, task.LineNum, sector.Name)
+		s.logg.LogE(msg.E3221, err)
+
+		return err
+	}
+
+	product, err := s.productRepo.FindById(ctx, task.ProductId)
+	if err != nil || product == nil {
+		err = fmt.Errorf("%s: продукция %d", msg.E3212, task.ProductId)
+		s.logg.LogE(msg.E3212, err)
+
+		return err
+	}
+
+	if product.Archive {
+		err = fmt.Errorf("%s: продукция %d", msg.E3226, task.ProductId)
+		s.logg.LogE(msg.E3226, err)
+
+		return err
+	}
+
+	task.AuditRec.CreatedBy = performerId
+	if err = s.shiftTaskRepo.Add(ctx, task); err != nil {
+		s.logg.LogE(msg.E3215, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *ShiftTaskService) ExistShiftTask(ctx context.Context, id int) (bool, error) {
+	return s.shiftTaskRepo.ExistById(ctx, id)
+}
+
+// findSector найти печь задания.
+func (s *ShiftTaskService) findSector(ctx context.Context, id int) (*model.Sector, error) {
+	sectors, err := s.sectorRepo.All(ctx)
+	if err != nil {
+		s.logg.LogE(msg.E3209, err)
+
+		return nil, err
+	}
+
+	for _, sector := range sectors {
+		if sector.Id == id {
+			return sector, nil
+		}
+	}
+
+	err = fmt.Errorf("%s: печь %d", msg.E3212, id)
+	s.logg.LogE(msg.E3212, err)
+
+	return nil, err
+}
+
+func containsLine(lines []int, line int) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+
+	return false
+}
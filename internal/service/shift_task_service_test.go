@@ -0,0 +1,81 @@
+package service
+
+import (
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeShiftTaskRepo struct {
+	tasks []*model.ShiftTask
+}
+
+func (f *fakeShiftTaskRepo) AllByDate(_ context.Context, taskDate string, shiftNum int) ([]*model.ShiftTask, error) {
+	var tasks []*model.ShiftTask
+	for _, task := range f.tasks {
+		if task.TaskDate == taskDate && (shiftNum == 0 || task.ShiftNum == shiftNum) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+func (f *fakeShiftTaskRepo) Add(_ context.Context, task *model.ShiftTask) error {
+	task.Id = len(f.tasks) + 1
+	f.tasks = append(f.tasks, task)
+
+	return nil
+}
+
+func (f *fakeShiftTaskRepo) ExistById(_ context.Context, id int) (bool, error) {
+	return id > 0 && id <= len(f.tasks), nil
+}
+
+func newShiftTaskService() (*ShiftTaskService, *fakeShiftTaskRepo) {
+	repo := &fakeShiftTaskRepo{}
+	products := &fakeProductRepo{products: map[int]*model.Product{
+		1: {Id: 1, Article: "A0001", VP: 3, ML: 31},
+		2: {Id: 2, Article: "A0002", VP: 3, ML: 31, Archive: true},
+	}}
+
+	return NewShiftTaskService(repo, newFakeSectorRepo(), products, &common.Logger{}), repo
+}
+
+func TestShiftTaskService_AddShiftTask(t *testing.T) {
+	svc, repo := newShiftTaskService()
+
+	task := &model.ShiftTask{TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 1, LineNum: 32, ProductId: 1, PlanQty: 40}
+	require.NoError(t, svc.AddShiftTask(context.Background(), task, 1001))
+	assert.Equal(t, 1001, repo.tasks[0].AuditRec.CreatedBy)
+
+	tasks, err := svc.GetShiftTasks(context.Background(), "2026-10-19", 1)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+}
+
+func TestShiftTaskService_AddShiftTask_Rejects(t *testing.T) {
+	svc, repo := newShiftTaskService()
+
+	cases := map[string]*model.ShiftTask{
+		"линия другой печи":     {TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 1, LineNum: 71, ProductId: 1, PlanQty: 40},
+		"неизвестная печь":      {TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 9, LineNum: 31, ProductId: 1, PlanQty: 40},
+		"неизвестная продукция": {TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 1, LineNum: 31, ProductId: 7, PlanQty: 40},
+		"архивная продукция":    {TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 1, LineNum: 31, ProductId: 2, PlanQty: 40},
+		"невалидная смена":      {TaskDate: "2026-10-19", ShiftNum: 3, SectorId: 1, LineNum: 31, ProductId: 1, PlanQty: 40},
+		"невалидная дата":       {TaskDate: "19.10.2026", ShiftNum: 1, SectorId: 1, LineNum: 31, ProductId: 1, PlanQty: 40},
+		"пустой план":           {TaskDate: "2026-10-19", ShiftNum: 1, SectorId: 1, LineNum: 31, ProductId: 1, PlanQty: 0},
+	}
+
+	for name, task := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, svc.AddShiftTask(context.Background(), task, 1001))
+		})
+	}
+
+	assert.Empty(t, repo.tasks)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretBytes  = 20     // totpSecretBytes - длина секрета, 160 бит по RFC 4226.
+	totpDigits       = 6      // totpDigits - цифр в коде.
+	totpPeriod       = 30     // totpPeriod - длительность интервала кода, сек.
+	totpSkew         = 1      // totpSkew - принимаются коды соседних интервалов из-за расхождения часов.
+	totpSealedPrefix = "gcm:" // totpSealedPrefix - признак секрета, зашифрованного ключом TOTP_KEY.
+)
+
+var (
+	totpEncoding      = base32.StdEncoding.WithPadding(base32.NoPadding)
+	errTOTPSecretOpen = errors.New("TOTP: не удалось расшифровать секрет, проверьте TOTP_KEY")
+)
+
+// newTOTPSecret сгенерировать секрет TOTP в base32.
+func newTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// totpStep номер интервала кода для момента t.
+func totpStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// totpCode код интервала step по RFC 6238 (HMAC-SHA1), secret - в base32.
+func totpCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// totpMatch найти интервал, код которого совпадает с code, в пределах totpSkew от момента now. false - код не подошел.
+func totpMatch(secret, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	if _, err := strconv.Atoi(code); err != nil {
+		return 0, false
+	}
+
+	current := totpStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totpCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpURI ссылка otpauth:// для приложения-аутентификатора.
+func totpURI(issuer string, performerId int, secret string) string {
+	label := url.PathEscape(issuer + ":" + strconv.Itoa(performerId))
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(totpDigits))
+	params.Set("period", strconv.Itoa(totpPeriod))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// sealTOTPSecret зашифровать секрет ключом key (AES-256-GCM), без ключа секрет не сохраняется.
+func sealTOTPSecret(key []byte, secret string) (string, error) {
+	if len(key) == 0 {
+		return "", errTOTPSecretOpen
+	}
+
+	aead, err := totpAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return totpSealedPrefix + base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// openTOTPSecret расшифровать секрет, сохраненный sealTOTPSecret. Незашифрованный секрет не принимается.
+func openTOTPSecret(key []byte, stored string) (string, error) {
+	sealed, ok := strings.CutPrefix(stored, totpSealedPrefix)
+	if !ok || len(key) == 0 {
+		return "", errTOTPSecretOpen
+	}
+
+	aead, err := totpAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", errTOTPSecretOpen
+	}
+
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errTOTPSecretOpen
+	}
+
+	return string(secret), nil
+}
+
+func totpAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/internal/repository"
+	"FGW_WEB/pkg/common"
+	"FGW_WEB/pkg/common/msg"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	recoveryCodeCount    = 10                                // recoveryCodeCount - выдается кодов восстановления.
+	recoveryCodeHalfLen  = 5                                 // recoveryCodeHalfLen - символов в каждой половине кода xxxxx-xxxxx.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789" // recoveryCodeAlphabet - без похожих символов (0/o, 1/l/i).
+	totpQRCodeSize       = 256                               // totpQRCodeSize - размер QR-кода, пикс.
+)
+
+type TwoFactorService struct {
+	twoFactorRepo repository.TwoFactorRepository
+	cfg           *config.TOTPCfg
+	logg          *common.Logger
+}
+
+func NewTwoFactorService(twoFactorRepo repository.TwoFactorRepository, cfg *config.TOTPCfg, logger *common.Logger) *TwoFactorService {
+	return &TwoFactorService{twoFactorRepo: twoFactorRepo, cfg: cfg, logg: logger}
+}
+
+type TwoFactorUseCase interface {
+	LoginState(ctx context.Context, performer *model.Performer) (int, error)
+	Status(ctx context.Context, performer *model.Performer) (*model.TwoFactorStatus, error)
+	BeginEnrollment(ctx context.Context, performerId int) (*model.TOTPEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, performerId int, code string) ([]string, error)
+	Verify(ctx context.Context, performerId int, code string) (bool, error)
+	RegenerateRecoveryCodes(ctx context.Context, performerId int, code string) ([]string, error)
+	Disable(ctx context.Context, performer *model.Performer, code string) error
+}
+
+// CheckKey проверка при запуске: без TOTP_KEY в БД не должно быть секретов TOTP, иначе подключенная 2FA не работает.
+func (t *TwoFactorService) CheckKey(ctx context.Context) error {
+	if t.cfg.Enabled() {
+		return nil
+	}
+
+	count, err := t.twoFactorRepo.CountEnrolled(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%s: 2FA подключали сотрудников: %d", msg.E3238, count)
+	}
+
+	return nil
+}
+
+// LoginState что нужно сотруднику после проверки пароля: ничего (model.TwoFactorNone), код (model.TwoFactorVerify)
+// или подключение 2FA, обязательной для его роли (model.TwoFactorEnroll).
+func (t *TwoFactorService) LoginState(ctx context.Context, performer *model.Performer) (int, error) {
+	totp, err := t.twoFactorRepo.FindByPerformer(ctx, performer.Id)
+	if err != nil {
+		return model.TwoFactorNone, err
+	}
+
+	switch {
+	case totp != nil && totp.ConfirmedAt != nil:
+		return model.TwoFactorVerify, nil
+	case t.isRequired(performer):
+		return model.TwoFactorEnroll, nil
+	default:
+		return model.TwoFactorNone, nil
+	}
+}
+
+// Status состояние 2FA сотрудника для страницы настроек.
+func (t *TwoFactorService) Status(ctx context.Context, performer *model.Performer) (*model.TwoFactorStatus, error) {
+	totp, err := t.twoFactorRepo.FindByPerformer(ctx, performer.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &model.TwoFactorStatus{Required: t.isRequired(performer)}
+	if totp == nil || totp.ConfirmedAt == nil {
+		return status, nil
+	}
+
+	status.Enabled = true
+	if status.RecoveryLeft, err = t.twoFactorRepo.RecoveryCodesLeft(ctx, performer.Id); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment выдать новый секрет и QR-код для приложения-аутентификатора. 2FA подключается только после
+// ConfirmEnrollment, до этого вход работает по-прежнему. Подключенную 2FA нужно сначала отключить.
+func (t *TwoFactorService) BeginEnrollment(ctx context.Context, performerId int) (*model.TOTPEnrollment, error) {
+	if !t.cfg.Enabled() {
+		return nil, fmt.Errorf("%s", msg.E3238)
+	}
+
+	totp, err := t.twoFactorRepo.FindByPerformer(ctx, performerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if totp != nil && totp.ConfirmedAt != nil {
+		return nil, fmt.Errorf("%s: 2FA уже подключена", msg.E3208)
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := sealTOTPSecret(t.cfg.Key, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.twoFactorRepo.SetSecret(ctx, performerId, sealed); err != nil {
+		return nil, err
+	}
+
+	uri := totpURI(t.cfg.Issuer, performerId, secret)
+
+	png, err := qrcode.Encode(uri, qrcode.Medium, totpQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TOTPEnrollment{
+		Secret: secret,
+		URI:    uri,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// ConfirmEnrollment подключить 2FA после проверки первого кода из приложения. Возвращает коды восстановления,
+// повторно они не показываются.
+func (t *TwoFactorService) ConfirmEnrollment(ctx context.Context, performerId int, code string) ([]string, error) {
+	totp, secret, err := t.findSecret(ctx, performerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if totp == nil || totp.ConfirmedAt != nil {
+		return nil, fmt.Errorf("%s: подключение 2FA не начато", msg.E3208)
+	}
+
+	step, ok := totpMatch(secret, normalizeTwoFactorCode(code), time.Now())
+	if !ok {
+		err = fmt.Errorf("%s: сотрудник %d", msg.E3232, performerId)
+		t.logg.LogE(msg.E3232, err)
+
+		return nil, err
+	}
+
+	codes, err := t.setRecoveryCodes(ctx, performerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.twoFactorRepo.Confirm(ctx, performerId, step); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Verify проверить код из приложения или код восстановления. Принятый код повторно не принимается. false - код
+// не подошел или 2FA не подключена.
+func (t *TwoFactorService) Verify(ctx context.Context, performerId int, code string) (bool, error) {
+	totp, secret, err := t.findSecret(ctx, performerId)
+	if err != nil || totp == nil || totp.ConfirmedAt == nil {
+		return false, err
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if len(code) == totpDigits {
+		step, ok := totpMatch(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+
+		return t.twoFactorRepo.UseStep(ctx, performerId, step)
+	}
+
+	if len(code) != 2*recoveryCodeHalfLen {
+		return false, nil
+	}
+
+	accepted, err := t.twoFactorRepo.UseRecoveryCode(ctx, performerId, hashRecoveryCode(code))
+	if accepted {
+		t.logg.LogW(fmt.Sprintf("2FA: сотрудник %d вошел по коду восстановления", performerId))
+	}
+
+	return accepted, err
+}
+
+// RegenerateRecoveryCodes выдать новые коды восстановления взамен прежних после проверки кода.
+func (t *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, performerId int, code string) ([]string, error) {
+	if err := t.verifyCode(ctx, performerId, code); err != nil {
+		return nil, err
+	}
+
+	return t.setRecoveryCodes(ctx, performerId)
+}
+
+// Disable отключить 2FA после проверки кода. Если 2FA обязательна для роли сотрудника, отключить ее нельзя.
+func (t *TwoFactorService) Disable(ctx context.Context, performer *model.Performer, code string) error {
+	if t.isRequired(performer) {
+		err := fmt.Errorf("%s: сотрудник %d", msg.E3234, performer.Id)
+		t.logg.LogE(msg.E3234, err)
+
+		return err
+	}
+
+	if err := t.verifyCode(ctx, performer.Id, code); err != nil {
+		return err
+	}
+
+	return t.twoFactorRepo.Del(ctx, performer.Id)
+}
+
+// verifyCode Verify с ошибкой msg.E3232, если код не подошел.
+func (t *TwoFactorService) verifyCode(ctx context.Context, performerId int, code string) error {
+	ok, err := t.Verify(ctx, performerId, code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		err = fmt.Errorf("%s: сотрудник %d", msg.E3232, performerId)
+		t.logg.LogE(msg.E3232, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// findSecret секрет TOTP сотрудника в base32, totp = nil - 2FA не подключалась.
+func (t *TwoFactorService) findSecret(ctx context.Context, performerId int) (*model.PerformerTOTP, string, error) {
+	totp, err := t.twoFactorRepo.FindByPerformer(ctx, performerId)
+	if err != nil || totp == nil {
+		return nil, "", err
+	}
+
+	secret, err := openTOTPSecret(t.cfg.Key, totp.Secret)
+	if err != nil {
+		t.logg.LogE(msg.E3208, err)
+
+		return nil, "", err
+	}
+
+	return totp, secret, nil
+}
+
+// setRecoveryCodes сгенерировать коды восстановления, в БД сохраняются только их хеши.
+func (t *TwoFactorService) setRecoveryCodes(ctx context.Context, performerId int) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		chars := make([]byte, 2*recoveryCodeHalfLen)
+		for j := range chars {
+			char, err := randomChar(recoveryCodeAlphabet)
+			if err != nil {
+				return nil, err
+			}
+			chars[j] = char
+		}
+
+		codes[i] = string(chars[:recoveryCodeHalfLen]) + "-" + string(chars[recoveryCodeHalfLen:])
+		hashes[i] = hashRecoveryCode(string(chars))
+	}
+
+	if err := t.twoFactorRepo.SetRecoveryCodes(ctx, performerId, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// isRequired обязательна ли 2FA для роли сотрудника в AForms или FGW.
+func (t *TwoFactorService) isRequired(performer *model.Performer) bool {
+	return t.cfg.IsRequired(performer.IdRoleAForms, performer.IdRoleAFGW)
+}
+
+// normalizeTwoFactorCode убрать пробелы и дефисы, которые сотрудник мог ввести вместе с кодом.
+func normalizeTwoFactorCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+// hashRecoveryCode SHA-256 (hex) кода восстановления без дефиса.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"FGW_WEB/internal/config"
+	"FGW_WEB/internal/model"
+	"FGW_WEB/pkg/common"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTwoFactorRepo секреты и коды восстановления в памяти, как ХП svTB_PerformerTOTP*.
+type fakeTwoFactorRepo struct {
+	totp     map[int]*model.PerformerTOTP
+	recovery map[int]map[string]bool // recovery - хеш кода: использован ли.
+}
+
+func newFakeTwoFactorRepo() *fakeTwoFactorRepo {
+	return &fakeTwoFactorRepo{totp: map[int]*model.PerformerTOTP{}, recovery: map[int]map[string]bool{}}
+}
+
+func (f *fakeTwoFactorRepo) FindByPerformer(_ context.Context, performerId int) (*model.PerformerTOTP, error) {
+	if totp, ok := f.totp[performerId]; ok {
+		copied := *totp
+
+		return &copied, nil
+	}
+
+	return nil, nil
+}
+
+func (f *fakeTwoFactorRepo) SetSecret(_ context.Context, performerId int, secret string) error {
+	f.totp[performerId] = &model.PerformerTOTP{PerformerId: performerId, Secret: secret}
+
+	return nil
+}
+
+func (f *fakeTwoFactorRepo) Confirm(_ context.Context, performerId int, step int64) error {
+	now := time.Now()
+	f.totp[performerId].ConfirmedAt = &now
+	f.totp[performerId].LastStep = step
+
+	return nil
+}
+
+func (f *fakeTwoFactorRepo) UseStep(_ context.Context, performerId int, step int64) (bool, error) {
+	if f.totp[performerId].LastStep >= step {
+		return false, nil
+	}
+	f.totp[performerId].LastStep = step
+
+	return true, nil
+}
+
+func (f *fakeTwoFactorRepo) Del(_ context.Context, performerId int) error {
+	delete(f.totp, performerId)
+	delete(f.recovery, performerId)
+
+	return nil
+}
+
+func (f *fakeTwoFactorRepo) SetRecoveryCodes(_ context.Context, performerId int, hashes []string) error {
+	f.recovery[performerId] = map[string]bool{}
+	for _, hash := range hashes {
+		f.recovery[performerId][hash] = false
+	}
+
+	return nil
+}
+
+func (f *fakeTwoFactorRepo) UseRecoveryCode(_ context.Context, performerId int, hash string) (bool, error) {
+	used, ok := f.recovery[performerId][hash]
+	if !ok || used {
+		return false, nil
+	}
+	f.recovery[performerId][hash] = true
+
+	return true, nil
+}
+
+func (f *fakeTwoFactorRepo) RecoveryCodesLeft(_ context.Context, performerId int) (int, error) {
+	var left int
+	for _, used := range f.recovery[performerId] {
+		if !used {
+			left++
+		}
+	}
+
+	return left, nil
+}
+
+func (f *fakeTwoFactorRepo) CountEnrolled(_ context.Context) (int, error) {
+	return len(f.totp), nil
+}
+
+func TestTOTPCode(t *testing.T) {
+	// Тестовые значения RFC 6238, приложение B (SHA1, 8 цифр), последние 6 цифр.
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := totpCode(secret, totpStep(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code)
+	}
+
+	now := time.Unix(1111111109, 0)
+	step, ok := totpMatch(secret, "081804", now.Add(totpPeriod*time.Second))
+	assert.True(t, ok, "код предыдущего интервала принимается")
+	assert.Equal(t, totpStep(now), step)
+
+	_, ok = totpMatch(secret, "081804", now.Add(3*totpPeriod*time.Second))
+	assert.False(t, ok, "устаревший код")
+
+	_, ok = totpMatch(secret, "08180", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPSecretSeal(t *testing.T) {
+	key := make([]byte, 32)
+
+	sealed, err := sealTOTPSecret(key, "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, totpSealedPrefix))
+
+	secret, err := openTOTPSecret(key, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+
+	_, err = openTOTPSecret(nil, sealed)
+	assert.Error(t, err, "без ключа зашифрованный секрет не прочитать")
+
+	_, err = sealTOTPSecret(nil, "JBSWY3DPEHPK3PXP")
+	assert.Error(t, err, "без TOTP_KEY секрет не сохраняется открыто")
+
+	_, err = openTOTPSecret(key, "JBSWY3DPEHPK3PXP")
+	assert.Error(t, err, "незашифрованный секрет не принимается")
+}
+
+func TestTwoFactorService_NoKey(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeTwoFactorRepo()
+	svc := NewTwoFactorService(repo, &config.TOTPCfg{Issuer: "FGW_WEB", RequiredRoles: map[int]bool{}}, &common.Logger{})
+
+	require.NoError(t, svc.CheckKey(ctx), "без подключений запуск без ключа допустим")
+
+	_, err := svc.BeginEnrollment(ctx, 1001)
+	assert.ErrorContains(t, err, "E3238")
+	assert.Empty(t, repo.totp, "секрет не сохранен")
+
+	repo.totp[1001] = &model.PerformerTOTP{PerformerId: 1001, Secret: "JBSWY3DPEHPK3PXP"}
+	assert.ErrorContains(t, svc.CheckKey(ctx), "E3238", "подключения есть, ключа нет - запуск запрещен")
+}
+
+func TestTwoFactorService(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeTwoFactorRepo()
+	cfg := &config.TOTPCfg{Issuer: "FGW_WEB", RequiredRoles: map[int]bool{3: true}, Key: make([]byte, 32)}
+	svc := NewTwoFactorService(repo, cfg, &common.Logger{})
+
+	admin := &model.Performer{Id: 1001, IdRoleAForms: 3}
+	worker := &model.Performer{Id: 1002, IdRoleAFGW: 5}
+
+	state, err := svc.LoginState(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, model.TwoFactorEnroll, state, "обязательна для роли")
+
+	state, err = svc.LoginState(ctx, worker)
+	require.NoError(t, err)
+	assert.Equal(t, model.TwoFactorNone, state)
+
+	enrollment, err := svc.BeginEnrollment(ctx, admin.Id)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/FGW_WEB:1001?")
+	assert.True(t, strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,"))
+	assert.NotContains(t, repo.totp[admin.Id].Secret, enrollment.Secret, "секрет в БД зашифрован")
+
+	state, err = svc.LoginState(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, model.TwoFactorEnroll, state, "до подтверждения 2FA не подключена")
+
+	_, err = svc.ConfirmEnrollment(ctx, admin.Id, "000000")
+	assert.Error(t, err)
+
+	step := totpStep(time.Now())
+	code, err := totpCode(enrollment.Secret, step)
+	require.NoError(t, err)
+
+	recovery, err := svc.ConfirmEnrollment(ctx, admin.Id, code[:3]+" "+code[3:])
+	require.NoError(t, err)
+	assert.Len(t, recovery, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, recovery[0])
+
+	state, err = svc.LoginState(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, model.TwoFactorVerify, state)
+
+	ok, err := svc.Verify(ctx, admin.Id, code)
+	require.NoError(t, err)
+	assert.False(t, ok, "код подключения повторно не принимается")
+
+	next, err := totpCode(enrollment.Secret, step+1)
+	require.NoError(t, err)
+	ok, err = svc.Verify(ctx, admin.Id, next)
+	require.NoError(t, err)
+	assert.True(t, ok, "код следующего интервала")
+
+	ok, err = svc.Verify(ctx, admin.Id, strings.ToUpper(recovery[0]))
+	require.NoError(t, err)
+	assert.True(t, ok, "код восстановления")
+
+	ok, err = svc.Verify(ctx, admin.Id, recovery[0])
+	require.NoError(t, err)
+	assert.False(t, ok, "код восстановления одноразовый")
+
+	status, err := svc.Status(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, &model.TwoFactorStatus{Enabled: true, Required: true, RecoveryLeft: recoveryCodeCount - 1}, status)
+
+	assert.Error(t, svc.Disable(ctx, admin, recovery[1]), "обязательную 2FA отключить нельзя")
+
+	regenerated, err := svc.RegenerateRecoveryCodes(ctx, admin.Id, recovery[1])
+	require.NoError(t, err)
+	ok, err = svc.Verify(ctx, admin.Id, recovery[2])
+	require.NoError(t, err)
+	assert.False(t, ok, "прежние коды восстановления больше не действуют")
+
+	cfg.RequiredRoles = map[int]bool{}
+	assert.Error(t, svc.Disable(ctx, admin, "123456"))
+	require.NoError(t, svc.Disable(ctx, admin, regenerated[0]))
+
+	state, err = svc.LoginState(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, model.TwoFactorNone, state)
+}
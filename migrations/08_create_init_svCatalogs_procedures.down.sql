@@ -0,0 +1 @@
+DROP PROCEDURE IF EXISTS dbo.svCatalogsByKodcat;
\ No newline at end of file
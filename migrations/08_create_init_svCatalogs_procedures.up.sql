@@ -0,0 +1,25 @@
+CREATE PROCEDURE dbo.svCatalogsByKodcat -- ХП получает не архивные записи справочника по коду справочника.
+@Kodcat SMALLINT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT id,
+           parid,
+           kodcat,
+           kod,
+           name,
+           comm,
+           dop_int_1,
+           dop_int_2,
+           dop_float_1,
+           dop_float_2,
+           dop_bit_1,
+           dop_bit_2,
+           archive
+    FROM dbo.svCatalogs
+    WHERE kodcat = @Kodcat
+      AND archive = 0
+    ORDER BY kod;
+END
+GO;
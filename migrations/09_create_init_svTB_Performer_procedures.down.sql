@@ -0,0 +1,2 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerAll;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerUpsertByTabnum;
\ No newline at end of file
@@ -0,0 +1,56 @@
+CREATE PROCEDURE dbo.svTB_PerformerAll -- ХП получение всех сотрудников AForms.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idPerformer,
+           ISNULL(extSector, 0),
+           PfFolder,
+           PfName,
+           PfBarcode,
+           PfEditDate,
+           PfEditUser,
+           PfTabnum
+    FROM dbo.svTB_Performer
+    ORDER BY PfTabnum;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerUpsertByTabnum -- ХП добавляет или обновляет сотрудника AForms по табельному номеру, пароль не изменяет.
+    @Tabnum INT, -- табельный номер
+    @Name VARCHAR(100), -- ФИО
+    @Barcode VARCHAR(20), -- штрих-код
+    @EditUser INT -- ид пользователя редактирования
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    -- 0 - пропущен (данные не изменились), 1 - добавлен, 2 - обновлен.
+    DECLARE @Result INT = 0;
+
+    IF NOT EXISTS(SELECT 1 FROM dbo.svTB_Performer WHERE PfTabnum = @Tabnum)
+        BEGIN
+            INSERT INTO dbo.svTB_Performer (PfName, PfBarcode, PfEditDate, PfEditUser, PfTabnum)
+            VALUES (@Name, @Barcode, GETDATE(), @EditUser, @Tabnum);
+
+            SET @Result = 1;
+        END
+    ELSE
+        IF EXISTS(SELECT 1
+                  FROM dbo.svTB_Performer
+                  WHERE PfTabnum = @Tabnum
+                    AND (PfName <> @Name OR PfBarcode <> @Barcode))
+            BEGIN
+                UPDATE dbo.svTB_Performer
+                SET PfName     = @Name,
+                    PfBarcode  = @Barcode,
+                    PfEditDate = GETDATE(),
+                    PfEditUser = @EditUser
+                WHERE PfTabnum = @Tabnum;
+
+                SET @Result = 2;
+            END
+
+    SELECT @Result AS upsert_result;
+END
+GO;
@@ -0,0 +1,21 @@
+-- Вернуть ХП сотрудников AForms к версии без наименования привязанной печки.
+ALTER PROCEDURE dbo.svTB_PerformerAll -- ХП получение всех сотрудников AForms.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idPerformer,
+           ISNULL(extSector, 0),
+           PfFolder,
+           PfName,
+           PfBarcode,
+           PfEditDate,
+           PfEditUser,
+           PfTabnum
+    FROM dbo.svTB_Performer
+    ORDER BY PfTabnum;
+END
+GO;
+
+DROP PROCEDURE IF EXISTS dbo.svTB_SectorAll;
+DROP PROCEDURE IF EXISTS dbo.svTB_SectorExistsById;
\ No newline at end of file
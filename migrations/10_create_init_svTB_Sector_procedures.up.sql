@@ -0,0 +1,52 @@
+CREATE PROCEDURE dbo.svTB_SectorAll -- ХП получение списка печек.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idSector,
+           SectorName,
+           SecVPML,
+           ISNULL(TicketSize, ''),
+           SectorEditDate,
+           SectorEditUser
+    FROM dbo.svTB_Sector
+    ORDER BY SectorName;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SectorExistsById -- ХП проверяет, существует ли печь.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @Exists BIT = 0;
+
+    IF EXISTS(SELECT 1 FROM dbo.svTB_Sector WHERE idSector = @Id)
+        BEGIN
+            SET @Exists = 1
+        END
+
+    SELECT @Exists AS exists_flag;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_PerformerAll -- ХП получение всех сотрудников AForms с привязанной печкой.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT p.idPerformer,
+           ISNULL(p.extSector, 0),
+           ISNULL(s.SectorName, ''),
+           p.PfFolder,
+           p.PfName,
+           p.PfBarcode,
+           p.PfEditDate,
+           p.PfEditUser,
+           p.PfTabnum
+    FROM dbo.svTB_Performer p
+             LEFT JOIN dbo.svTB_Sector s ON s.idSector = p.extSector
+    ORDER BY p.PfTabnum;
+END
+GO;
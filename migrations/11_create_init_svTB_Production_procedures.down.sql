@@ -0,0 +1,4 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionAll;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionFindById;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionUpdById;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionExistsById;
\ No newline at end of file
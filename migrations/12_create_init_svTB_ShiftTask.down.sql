@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS dbo.svTB_ShiftTask;
+DROP PROCEDURE IF EXISTS dbo.svTB_ShiftTaskByDate;
+DROP PROCEDURE IF EXISTS dbo.svTB_ShiftTaskAdd;
+DROP PROCEDURE IF EXISTS dbo.svTB_ShiftTaskAddFact;
+DROP PROCEDURE IF EXISTS dbo.svTB_ShiftTaskExistsById;
\ No newline at end of file
@@ -0,0 +1,107 @@
+-- СОЗДАТЬ ТАБЛИЦУ СМЕННО-СУТОЧНЫХ ЗАДАНИЙ. Задание заводит диспетчер (роль 5 - operator).
+CREATE TABLE dbo.svTB_ShiftTask
+(
+    idShiftTask   INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_ShiftTask PRIMARY KEY NONCLUSTERED, -- idShiftTask - ид задания.
+    TaskDate      DATE                   NOT NULL,           -- TaskDate - дата смены.
+    ShiftNum      SMALLINT               NOT NULL,           -- ShiftNum - номер смены.
+    extSector     INT                    NOT NULL,           -- extSector - внешний ключ на таблицу svTB_Sector.
+    LineNum       SMALLINT               NOT NULL,           -- LineNum - машинная линия на печи.
+    extProduction INT                    NOT NULL,           -- extProduction - внешний ключ на таблицу svTB_Production.
+    PlanQty       INT          DEFAULT 0 NOT NULL,           -- PlanQty - план, кол-во п\п.
+    FactQty       INT          DEFAULT 0 NOT NULL,           -- FactQty - факт, кол-во напечатанных п\п.
+    created_at    DATETIME     DEFAULT GETDATE(),            -- created_at - дата создания записи.
+    created_by    INT                    NOT NULL,           -- created_by - табельный номер диспетчера.
+    updated_at    DATETIME     DEFAULT GETDATE(),            -- updated_at - дата изменения записи.
+    updated_by    INT                    NOT NULL,           -- updated_by - табельный номер сотрудника изменивший запись.
+
+    CONSTRAINT UQ_svTB_ShiftTask_line UNIQUE (TaskDate, ShiftNum, extSector, LineNum),
+    CONSTRAINT CHK_svTB_ShiftTask_plan CHECK (PlanQty >= 0),
+    CONSTRAINT FK_svTB_ShiftTask_sector FOREIGN KEY (extSector) REFERENCES dbo.svTB_Sector (idSector),
+    CONSTRAINT FK_svTB_ShiftTask_production FOREIGN KEY (extProduction) REFERENCES dbo.svTB_Production (idProduction)
+);
+
+CREATE INDEX IX_svTB_ShiftTask_date ON dbo.svTB_ShiftTask (TaskDate, ShiftNum);
+
+CREATE PROCEDURE dbo.svTB_ShiftTaskByDate -- ХП получает задания на дату (ShiftNum = 0 - все смены).
+    @TaskDate DATE,
+    @ShiftNum SMALLINT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT t.idShiftTask,
+           CONVERT(VARCHAR(10), t.TaskDate, 23) AS TaskDate,
+           t.ShiftNum,
+           t.extSector,
+           s.SectorName,
+           t.LineNum,
+           t.extProduction,
+           p.PrArticle,
+           p.PrName,
+           t.PlanQty,
+           t.FactQty,
+           t.created_at,
+           t.created_by,
+           t.updated_at,
+           t.updated_by
+    FROM dbo.svTB_ShiftTask t
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = t.extSector
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = t.extProduction
+    WHERE t.TaskDate = @TaskDate
+      AND (@ShiftNum = 0 OR t.ShiftNum = @ShiftNum)
+    ORDER BY t.ShiftNum, s.SectorName, t.LineNum;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ShiftTaskAdd -- ХП добавляет сменно-суточное задание.
+    @TaskDate DATE,
+    @ShiftNum SMALLINT,
+    @SectorId INT,
+    @LineNum SMALLINT,
+    @ProductionId INT,
+    @PlanQty INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_ShiftTask (TaskDate, ShiftNum, extSector, LineNum, extProduction, PlanQty, FactQty,
+                                    created_at, created_by, updated_at, updated_by)
+    VALUES (@TaskDate, @ShiftNum, @SectorId, @LineNum, @ProductionId, @PlanQty, 0,
+            GETDATE(), @PerformerId, GETDATE(), @PerformerId);
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ShiftTaskAddFact -- ХП увеличивает факт задания при печати п\п.
+    @Id INT,
+    @Qty INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_ShiftTask
+    SET FactQty    = FactQty + @Qty,
+        updated_at = GETDATE(),
+        updated_by = @PerformerId
+    WHERE idShiftTask = @Id;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ShiftTaskExistsById -- ХП проверяет, существует ли задание.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @Exists BIT = 0;
+
+    IF EXISTS(SELECT 1 FROM dbo.svTB_ShiftTask WHERE idShiftTask = @Id)
+        BEGIN
+            SET @Exists = 1
+        END
+
+    SELECT @Exists AS exists_flag;
+END
+GO;
@@ -0,0 +1,9 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationSessionClose;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationSessionOpen;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationSessionActive;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationExistsById;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationUpdById;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationAdd;
+DROP PROCEDURE IF EXISTS dbo.svTB_PackStationAll;
+DROP TABLE IF EXISTS dbo.svTB_PackStationSession;
+DROP TABLE IF EXISTS dbo.svTB_PackStation;
\ No newline at end of file
@@ -0,0 +1,198 @@
+-- СОЗДАТЬ ТАБЛИЦУ СТАНЦИЙ УПАКОВКИ. Станция привязана к участку упаковки (svCatalogs kodcat=9), принтеру
+-- (svCatalogs kodcat=4) и печке.
+CREATE TABLE dbo.svTB_PackStation
+(
+    idPackStation INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_PackStation PRIMARY KEY NONCLUSTERED, -- idPackStation - ид станции.
+    StName        VARCHAR(100)           NOT NULL,               -- StName - наименование станции.
+    extPackArea   INT                    NOT NULL,               -- extPackArea - участок упаковки, svCatalogs kodcat=9.
+    extPrinter    INT                    NOT NULL,               -- extPrinter - принтер, svCatalogs kodcat=4.
+    extSector     INT                    NOT NULL,               -- extSector - внешний ключ на таблицу svTB_Sector.
+    archive       BIT          DEFAULT 0 NOT NULL,               -- archive - флаг архивной станции.
+    created_at    DATETIME     DEFAULT GETDATE(),                -- created_at - дата создания записи.
+    created_by    INT                    NOT NULL,               -- created_by - табельный номер сотрудника.
+    updated_at    DATETIME     DEFAULT GETDATE(),                -- updated_at - дата изменения записи.
+    updated_by    INT                    NOT NULL,               -- updated_by - табельный номер сотрудника изменивший запись.
+
+    CONSTRAINT CHK_svTB_PackStation_name_not_empty CHECK (LEN(TRIM(StName)) > 0),
+    CONSTRAINT FK_svTB_PackStation_area FOREIGN KEY (extPackArea) REFERENCES dbo.svCatalogs (id),
+    CONSTRAINT FK_svTB_PackStation_printer FOREIGN KEY (extPrinter) REFERENCES dbo.svCatalogs (id),
+    CONSTRAINT FK_svTB_PackStation_sector FOREIGN KEY (extSector) REFERENCES dbo.svTB_Sector (idSector)
+);
+
+-- СОЗДАТЬ ТАБЛИЦУ СЕАНСОВ НА СТАНЦИЯХ УПАКОВКИ. Сеанс открывается сканированием бейджа (svTB_Performer.PfBarcode),
+-- на станции и у сотрудника может быть только один открытый сеанс.
+CREATE TABLE dbo.svTB_PackStationSession
+(
+    idSession      INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_PackStationSession PRIMARY KEY NONCLUSTERED, -- idSession - ид сеанса.
+    extPackStation INT                        NOT NULL,                 -- extPackStation - внешний ключ на svTB_PackStation.
+    extSector      INT                        NOT NULL,                 -- extSector - печь станции на момент открытия.
+    extPerformer   INT                        NOT NULL,                 -- extPerformer - внешний ключ на svTB_Performer.
+    OpenedAt       DATETIME DEFAULT GETDATE() NOT NULL,                 -- OpenedAt - дата открытия сеанса.
+    ClosedAt       DATETIME,                                            -- ClosedAt - дата закрытия сеанса, NULL - открыт.
+
+    CONSTRAINT FK_svTB_PackStationSession_station FOREIGN KEY (extPackStation) REFERENCES dbo.svTB_PackStation (idPackStation),
+    CONSTRAINT FK_svTB_PackStationSession_performer FOREIGN KEY (extPerformer) REFERENCES dbo.svTB_Performer (idPerformer)
+);
+
+CREATE INDEX IX_svTB_PackStationSession_open ON dbo.svTB_PackStationSession (extPackStation, ClosedAt);
+
+CREATE PROCEDURE dbo.svTB_PackStationAll -- ХП получение всех станций упаковки (только не архивных).
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT st.idPackStation,
+           st.StName,
+           st.extPackArea,
+           area.name,
+           area.dop_bit_1,
+           st.extPrinter,
+           printer.name,
+           st.extSector,
+           s.SectorName,
+           st.archive,
+           st.created_at,
+           st.created_by,
+           st.updated_at,
+           st.updated_by
+    FROM dbo.svTB_PackStation st
+             INNER JOIN dbo.svCatalogs area ON area.id = st.extPackArea
+             INNER JOIN dbo.svCatalogs printer ON printer.id = st.extPrinter
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = st.extSector
+    WHERE st.archive = 0
+    ORDER BY st.StName;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationAdd -- ХП добавляет станцию упаковки.
+    @Name VARCHAR(100),
+    @PackAreaId INT,
+    @PrinterId INT,
+    @SectorId INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_PackStation (StName, extPackArea, extPrinter, extSector, archive,
+                                      created_at, created_by, updated_at, updated_by)
+    VALUES (@Name, @PackAreaId, @PrinterId, @SectorId, 0, GETDATE(), @PerformerId, GETDATE(), @PerformerId);
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationUpdById -- ХП обновляет станцию упаковки по ид.
+    @Id INT,
+    @Name VARCHAR(100),
+    @PackAreaId INT,
+    @PrinterId INT,
+    @SectorId INT,
+    @Archive BIT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_PackStation
+    SET StName      = @Name,
+        extPackArea = @PackAreaId,
+        extPrinter  = @PrinterId,
+        extSector   = @SectorId,
+        archive     = @Archive,
+        updated_at  = GETDATE(),
+        updated_by  = @PerformerId
+    WHERE idPackStation = @Id;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationExistsById -- ХП проверяет, существует ли станция упаковки.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @Exists BIT = 0;
+
+    IF EXISTS(SELECT 1 FROM dbo.svTB_PackStation WHERE idPackStation = @Id AND archive = 0)
+        BEGIN
+            SET @Exists = 1
+        END
+
+    SELECT @Exists AS exists_flag;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationSessionActive -- ХП получает открытый сеанс станции с отметкой для печати п\п.
+@StationId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT TOP 1 ss.idSession,
+                 st.idPackStation,
+                 st.StName,
+                 ss.extSector,
+                 s.SectorName,
+                 printer.name,
+                 p.idPerformer,
+                 p.PfTabnum,
+                 p.PfName,
+                 ss.OpenedAt
+    FROM dbo.svTB_PackStationSession ss
+             INNER JOIN dbo.svTB_PackStation st ON st.idPackStation = ss.extPackStation
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = ss.extSector
+             INNER JOIN dbo.svCatalogs printer ON printer.id = st.extPrinter
+             INNER JOIN dbo.svTB_Performer p ON p.idPerformer = ss.extPerformer
+    WHERE ss.extPackStation = @StationId
+      AND ss.ClosedAt IS NULL
+    ORDER BY ss.OpenedAt DESC;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationSessionOpen -- ХП открывает сеанс на станции по штрих-коду бейджа.
+    @StationId INT,
+    @Barcode VARCHAR(20)
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SET XACT_ABORT ON; -- ошибка откатывает всю транзакцию: прежний сеанс не закрывается без нового.
+
+    DECLARE @PerformerId INT;
+    DECLARE @SectorId INT;
+
+    SELECT @PerformerId = idPerformer FROM dbo.svTB_Performer WHERE PfBarcode = @Barcode AND PfBarcode <> '';
+    SELECT @SectorId = extSector FROM dbo.svTB_PackStation WHERE idPackStation = @StationId AND archive = 0;
+
+    IF @PerformerId IS NULL OR @SectorId IS NULL
+        RETURN;
+
+    BEGIN TRANSACTION;
+
+    -- Закрываем прежний сеанс станции и сеанс сотрудника на другой станции.
+    UPDATE dbo.svTB_PackStationSession
+    SET ClosedAt = GETDATE()
+    WHERE ClosedAt IS NULL
+      AND (extPackStation = @StationId OR extPerformer = @PerformerId);
+
+    INSERT INTO dbo.svTB_PackStationSession (extPackStation, extSector, extPerformer, OpenedAt)
+    VALUES (@StationId, @SectorId, @PerformerId, GETDATE());
+
+    COMMIT TRANSACTION;
+
+    EXEC dbo.svTB_PackStationSessionActive @StationId;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PackStationSessionClose -- ХП закрывает открытый сеанс станции.
+@StationId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_PackStationSession
+    SET ClosedAt = GETDATE()
+    WHERE extPackStation = @StationId
+      AND ClosedAt IS NULL;
+END
+GO;
@@ -0,0 +1,23 @@
+CREATE PROCEDURE dbo.svPerformerFindByBC -- ХП ищет сотрудника по коду доступа (штрих-код бейджа) для входа с ТСД.
+@BC VARCHAR(13)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT id,
+           fio,
+           bc,
+           pass,
+           archive,
+           id_role_a_forms,
+           id_role_a_fgw,
+           created_at,
+           created_by,
+           updated_at,
+           updated_by
+    FROM dbo.svPerformers
+    WHERE bc = @BC
+      AND bc <> ''
+      AND archive = 0
+END
+GO;
@@ -0,0 +1,3 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionHistoryByProduction;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionHistoryAdd;
+DROP TABLE IF EXISTS dbo.svTB_ProductionHistory;
\ No newline at end of file
@@ -0,0 +1,48 @@
+-- СОЗДАТЬ ТАБЛИЦУ ИСТОРИИ ИЗМЕНЕНИЙ ПРОДУКЦИИ. На каждое обновление svTB_Production сохраняется версия со снимком
+-- записи в JSON, первой версией сохраняется состояние до первого изменения.
+CREATE TABLE dbo.svTB_ProductionHistory
+(
+    idHistory     INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_ProductionHistory PRIMARY KEY NONCLUSTERED, -- idHistory - ид версии.
+    extProduction INT                    NOT NULL,                     -- extProduction - внешний ключ на svTB_Production.
+    Version       INT                    NOT NULL,                     -- Version - номер версии продукции.
+    Snapshot      NVARCHAR(MAX)          NOT NULL,                     -- Snapshot - снимок записи продукции в JSON.
+    created_at    DATETIME     DEFAULT GETDATE(),                      -- created_at - дата создания версии.
+    created_by    INT                    NOT NULL,                     -- created_by - табельный номер сотрудника.
+
+    CONSTRAINT UQ_svTB_ProductionHistory_version UNIQUE (extProduction, Version),
+    CONSTRAINT CHK_svTB_ProductionHistory_snapshot CHECK (ISJSON(Snapshot) = 1),
+    CONSTRAINT FK_svTB_ProductionHistory_production FOREIGN KEY (extProduction) REFERENCES dbo.svTB_Production (idProduction)
+);
+
+CREATE PROCEDURE dbo.svTB_ProductionHistoryAdd -- ХП добавляет версию продукции, номер версии следующий по порядку.
+    @ProductionId INT,
+    @Snapshot NVARCHAR(MAX),
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_ProductionHistory (extProduction, Version, Snapshot, created_at, created_by)
+    SELECT @ProductionId, ISNULL(MAX(Version), 0) + 1, @Snapshot, GETDATE(), @PerformerId
+    FROM dbo.svTB_ProductionHistory WITH (UPDLOCK, HOLDLOCK)
+    WHERE extProduction = @ProductionId;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ProductionHistoryByProduction -- ХП получает версии продукции по порядку.
+@ProductionId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT extProduction,
+           Version,
+           Snapshot,
+           created_at,
+           created_by
+    FROM dbo.svTB_ProductionHistory
+    WHERE extProduction = @ProductionId
+    ORDER BY Version;
+END
+GO;
@@ -0,0 +1 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionArchiveById;
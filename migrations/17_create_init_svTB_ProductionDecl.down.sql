@@ -0,0 +1,4 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionDeclExpiring;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionDeclByProduction;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionDeclAdd;
+DROP TABLE IF EXISTS dbo.svTB_ProductionDecl;
\ No newline at end of file
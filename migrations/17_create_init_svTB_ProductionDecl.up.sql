@@ -0,0 +1,93 @@
+-- СОЗДАТЬ ТАБЛИЦУ ДЕКЛАРАЦИЙ О СООТВЕТСТВИИ ПРОДУКЦИИ. Декларации ведутся для продукции с PrDecl = 1, действующей
+-- считается последняя по дате окончания.
+CREATE TABLE dbo.svTB_ProductionDecl
+(
+    idDecl        INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_ProductionDecl PRIMARY KEY NONCLUSTERED, -- idDecl - ид декларации.
+    extProduction INT                    NOT NULL,                  -- extProduction - внешний ключ на svTB_Production.
+    DeclNumber    VARCHAR(50)            NOT NULL,                  -- DeclNumber - регистрационный номер декларации.
+    ValidFrom     DATE                   NOT NULL,                  -- ValidFrom - дата начала действия.
+    ValidTo       DATE                   NOT NULL,                  -- ValidTo - дата окончания действия.
+    created_at    DATETIME     DEFAULT GETDATE(),                   -- created_at - дата создания записи.
+    created_by    INT                    NOT NULL,                  -- created_by - табельный номер сотрудника.
+    updated_at    DATETIME     DEFAULT GETDATE(),                   -- updated_at - дата изменения записи.
+    updated_by    INT                    NOT NULL,                  -- updated_by - табельный номер сотрудника изменивший запись.
+
+    CONSTRAINT CHK_svTB_ProductionDecl_number_not_empty CHECK (LEN(TRIM(DeclNumber)) > 0),
+    CONSTRAINT CHK_svTB_ProductionDecl_dates CHECK (ValidFrom <= ValidTo),
+    CONSTRAINT FK_svTB_ProductionDecl_production FOREIGN KEY (extProduction) REFERENCES dbo.svTB_Production (idProduction)
+);
+
+CREATE INDEX IX_svTB_ProductionDecl_production ON dbo.svTB_ProductionDecl (extProduction, ValidTo);
+
+CREATE PROCEDURE dbo.svTB_ProductionDeclAdd -- ХП прикрепляет декларацию к продукции.
+    @ProductionId INT,
+    @DeclNumber VARCHAR(50),
+    @ValidFrom DATE,
+    @ValidTo DATE,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_ProductionDecl (extProduction, DeclNumber, ValidFrom, ValidTo,
+                                         created_at, created_by, updated_at, updated_by)
+    VALUES (@ProductionId, @DeclNumber, @ValidFrom, @ValidTo, GETDATE(), @PerformerId, GETDATE(), @PerformerId);
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ProductionDeclByProduction -- ХП получает декларации продукции, действующая первой.
+@ProductionId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT d.idDecl,
+           d.extProduction,
+           p.PrArticle,
+           p.PrName,
+           d.DeclNumber,
+           CONVERT(VARCHAR(10), d.ValidFrom, 23) AS ValidFrom,
+           CONVERT(VARCHAR(10), d.ValidTo, 23)   AS ValidTo,
+           DATEDIFF(DAY, CAST(GETDATE() AS DATE), d.ValidTo) AS DaysLeft,
+           d.created_at,
+           d.created_by,
+           d.updated_at,
+           d.updated_by
+    FROM dbo.svTB_ProductionDecl d
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = d.extProduction
+    WHERE d.extProduction = @ProductionId
+    ORDER BY d.ValidTo DESC;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ProductionDeclExpiring -- ХП декларированная продукция, у которой действующая декларация
+-- истекает в течение @Days дней, уже истекла или отсутствует.
+@Days INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT ISNULL(d.idDecl, 0),
+           p.idProduction,
+           p.PrArticle,
+           p.PrName,
+           ISNULL(d.DeclNumber, ''),
+           ISNULL(CONVERT(VARCHAR(10), d.ValidFrom, 23), ''),
+           ISNULL(CONVERT(VARCHAR(10), d.ValidTo, 23), ''),
+           ISNULL(DATEDIFF(DAY, CAST(GETDATE() AS DATE), d.ValidTo), -1),
+           ISNULL(d.created_at, ''),
+           ISNULL(d.created_by, 0),
+           ISNULL(d.updated_at, ''),
+           ISNULL(d.updated_by, 0)
+    FROM dbo.svTB_Production p
+             OUTER APPLY (SELECT TOP 1 *
+                          FROM dbo.svTB_ProductionDecl
+                          WHERE extProduction = p.idProduction
+                          ORDER BY ValidTo DESC) d
+    WHERE p.PrDecl = 1
+      AND p.PrArchive = 0
+      AND (d.idDecl IS NULL OR d.ValidTo <= DATEADD(DAY, @Days, CAST(GETDATE() AS DATE)))
+    ORDER BY d.ValidTo, p.PrArticle;
+END
+GO;
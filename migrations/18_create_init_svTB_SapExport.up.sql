@@ -0,0 +1,22 @@
+-- ВЫГРУЗКА В SAP. Приход готовой продукции за день по факту сменно-суточных заданий, ключ - PrSAP.
+CREATE PROCEDURE dbo.svTB_SapReceiptsByDate -- ХП получает приход продукции за дату, сгруппированный по коду SAP.
+@TaskDate DATE
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT CONVERT(VARCHAR(10), t.TaskDate, 23) AS TaskDate,
+           p.PrSAP,
+           p.PrArticle,
+           p.PrName,
+           SUM(t.FactQty)             AS Pallets, -- Pallets - кол-во п\п.
+           SUM(t.FactQty * p.PrCount * p.PrRows) AS Qty      -- Qty - штук: п\п * ряды * шт. в ряду.
+    FROM dbo.svTB_ShiftTask t
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = t.extProduction
+    WHERE t.TaskDate = @TaskDate
+      AND t.FactQty > 0
+      AND ISNULL(p.PrSAP, '') <> ''
+    GROUP BY t.TaskDate, p.PrSAP, p.PrArticle, p.PrName
+    ORDER BY p.PrSAP;
+END
+GO;
@@ -0,0 +1,157 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionCatalogUnmatched;
+DROP PROCEDURE IF EXISTS dbo.svTB_ProductionMapCatalogs;
+GO;
+
+-- Вернуть ХП продукции к версии без справочников цвета и конструкторского наименования.
+ALTER PROCEDURE dbo.svTB_ProductionAll -- ХП получение всей продукции.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idProduction,
+           PrName,
+           PrShortName,
+           PrPackName,
+           ISNULL(PrType, ''),
+           PrArticle,
+           PrColor,
+           ISNULL(PrBarCode, ''),
+           PrCount,
+           PrRows,
+           PrWeight,
+           PrHWD,
+           ISNULL(PrInfo, ''),
+           PrStatus,
+           ISNULL(PrEditDate, ''),
+           ISNULL(PrEditUser, 0),
+           PrPart,
+           PrPartLastDate,
+           PrPartAutoInc,
+           PrArchive,
+           ISNULL(PrPerGodn, 0),
+           ISNULL(PrSAP, ''),
+           PrProdType,
+           PrUmbrella,
+           PrSun,
+           PrDecl,
+           PrParty,
+           PrGL,
+           PrVP,
+           PrML,
+           ISNULL(Created_at, ''),
+           Created_by,
+           ISNULL(Updated_at, ''),
+           Updated_by
+    FROM dbo.svTB_Production
+    ORDER BY PrArticle;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionFindById -- ХП ищет продукцию по ИД.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idProduction,
+           PrName,
+           PrShortName,
+           PrPackName,
+           ISNULL(PrType, ''),
+           PrArticle,
+           PrColor,
+           ISNULL(PrBarCode, ''),
+           PrCount,
+           PrRows,
+           PrWeight,
+           PrHWD,
+           ISNULL(PrInfo, ''),
+           PrStatus,
+           ISNULL(PrEditDate, ''),
+           ISNULL(PrEditUser, 0),
+           PrPart,
+           PrPartLastDate,
+           PrPartAutoInc,
+           PrArchive,
+           ISNULL(PrPerGodn, 0),
+           ISNULL(PrSAP, ''),
+           PrProdType,
+           PrUmbrella,
+           PrSun,
+           PrDecl,
+           PrParty,
+           PrGL,
+           PrVP,
+           PrML,
+           ISNULL(Created_at, ''),
+           Created_by,
+           ISNULL(Updated_at, ''),
+           Updated_by
+    FROM dbo.svTB_Production
+    WHERE idProduction = @Id;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionUpdById -- ХП обновляет продукцию по ИД.
+    @Id INT,
+    @PrName VARCHAR(300),
+    @PrShortName VARCHAR(100),
+    @PrPackName VARCHAR(300),
+    @PrType VARCHAR(100),
+    @PrArticle VARCHAR(5),
+    @PrColor VARCHAR(20),
+    @PrBarCode VARCHAR(13),
+    @PrCount INT,
+    @PrRows INT,
+    @PrWeight DECIMAL(19, 3),
+    @PrHWD VARCHAR(100),
+    @PrInfo VARCHAR(1024),
+    @PrStatus BIT,
+    @PrPerGodn SMALLINT,
+    @PrSAP VARCHAR(15),
+    @PrProdType BIT,
+    @PrUmbrella BIT,
+    @PrSun BIT,
+    @PrDecl BIT,
+    @PrParty BIT,
+    @PrGL SMALLINT,
+    @PrVP SMALLINT,
+    @PrML SMALLINT,
+    @Updated_by INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Production
+    SET PrName      = @PrName,
+        PrShortName = @PrShortName,
+        PrPackName  = @PrPackName,
+        PrType      = @PrType,
+        PrArticle   = @PrArticle,
+        PrColor     = @PrColor,
+        PrBarCode   = @PrBarCode,
+        PrCount     = @PrCount,
+        PrRows      = @PrRows,
+        PrWeight    = @PrWeight,
+        PrHWD       = @PrHWD,
+        PrInfo      = @PrInfo,
+        PrStatus    = @PrStatus,
+        PrPerGodn   = @PrPerGodn,
+        PrSAP       = @PrSAP,
+        PrProdType  = @PrProdType,
+        PrUmbrella  = @PrUmbrella,
+        PrSun       = @PrSun,
+        PrDecl      = @PrDecl,
+        PrParty     = @PrParty,
+        PrGL        = @PrGL,
+        PrVP        = @PrVP,
+        PrML        = @PrML,
+        PrEditDate  = GETDATE(),
+        Updated_at  = GETDATE(),
+        Updated_by  = @Updated_by
+    WHERE idProduction = @Id;
+END
+GO;
+
+ALTER TABLE dbo.svTB_Production DROP CONSTRAINT FK_svTB_Production_color, FK_svTB_Production_design;
+ALTER TABLE dbo.svTB_Production DROP COLUMN extColor, extDesign;
\ No newline at end of file
@@ -0,0 +1,231 @@
+-- ПРИВЯЗКА ПРОДУКЦИИ К СПРАВОЧНИКАМ ЦВЕТОВ (kodcat = 3) И КОНСТРУКТОРСКИХ НАИМЕНОВАНИЙ (kodcat = 0).
+-- PrColor остается для совместимости, цвет и конструкторское наименование берутся из svCatalogs.
+ALTER TABLE dbo.svTB_Production
+    ADD extColor  INT NULL -- extColor - внешний ключ на запись справочника цветов svCatalogs.
+        CONSTRAINT FK_svTB_Production_color REFERENCES dbo.svCatalogs (id),
+        extDesign INT NULL -- extDesign - внешний ключ на запись справочника конструкторских наименований svCatalogs.
+        CONSTRAINT FK_svTB_Production_design REFERENCES dbo.svCatalogs (id);
+GO;
+
+CREATE PROCEDURE dbo.svTB_ProductionMapCatalogs -- ХП сопоставляет текстовые цвет и наименование с записями справочников.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @Colors INT, @Designs INT;
+
+    -- Цвет: PrColor совпадает с наименованием записи справочника цветов.
+    UPDATE p
+    SET p.extColor = c.id
+    FROM dbo.svTB_Production p
+             CROSS APPLY (SELECT TOP 1 id
+                          FROM dbo.svCatalogs
+                          WHERE kodcat = 3
+                            AND archive = 0
+                            AND LTRIM(RTRIM(name)) = LTRIM(RTRIM(p.PrColor))
+                          ORDER BY kod) c
+    WHERE p.extColor IS NULL
+      AND LTRIM(RTRIM(p.PrColor)) <> '';
+    SET @Colors = @@ROWCOUNT;
+
+    -- Конструкторское наименование: PrShortName совпадает с наименованием записи справочника.
+    UPDATE p
+    SET p.extDesign = c.id
+    FROM dbo.svTB_Production p
+             CROSS APPLY (SELECT TOP 1 id
+                          FROM dbo.svCatalogs
+                          WHERE kodcat = 0
+                            AND archive = 0
+                            AND LTRIM(RTRIM(name)) = LTRIM(RTRIM(p.PrShortName))
+                          ORDER BY kod) c
+    WHERE p.extDesign IS NULL
+      AND LTRIM(RTRIM(p.PrShortName)) <> '';
+    SET @Designs = @@ROWCOUNT;
+
+    SELECT @Colors AS colors, @Designs AS designs;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ProductionCatalogUnmatched -- ХП получает текстовые значения без записи в справочниках.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT 'color'                  AS Field,
+           LTRIM(RTRIM(PrColor))    AS Value,
+           COUNT(*)                 AS Products
+    FROM dbo.svTB_Production
+    WHERE extColor IS NULL
+      AND LTRIM(RTRIM(PrColor)) <> ''
+    GROUP BY LTRIM(RTRIM(PrColor))
+    UNION ALL
+    SELECT 'design',
+           LTRIM(RTRIM(PrShortName)),
+           COUNT(*)
+    FROM dbo.svTB_Production
+    WHERE extDesign IS NULL
+      AND LTRIM(RTRIM(PrShortName)) <> ''
+    GROUP BY LTRIM(RTRIM(PrShortName))
+    ORDER BY Field, Value;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionAll -- ХП получение всей продукции.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idProduction,
+           PrName,
+           PrShortName,
+           PrPackName,
+           ISNULL(PrType, ''),
+           PrArticle,
+           PrColor,
+           ISNULL(PrBarCode, ''),
+           PrCount,
+           PrRows,
+           PrWeight,
+           PrHWD,
+           ISNULL(PrInfo, ''),
+           PrStatus,
+           ISNULL(PrEditDate, ''),
+           ISNULL(PrEditUser, 0),
+           PrPart,
+           PrPartLastDate,
+           PrPartAutoInc,
+           PrArchive,
+           ISNULL(PrPerGodn, 0),
+           ISNULL(PrSAP, ''),
+           PrProdType,
+           PrUmbrella,
+           PrSun,
+           PrDecl,
+           PrParty,
+           PrGL,
+           PrVP,
+           PrML,
+           ISNULL(extColor, 0),
+           ISNULL(extDesign, 0),
+           ISNULL(Created_at, ''),
+           Created_by,
+           ISNULL(Updated_at, ''),
+           Updated_by
+    FROM dbo.svTB_Production
+    ORDER BY PrArticle;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionFindById -- ХП ищет продукцию по ИД.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idProduction,
+           PrName,
+           PrShortName,
+           PrPackName,
+           ISNULL(PrType, ''),
+           PrArticle,
+           PrColor,
+           ISNULL(PrBarCode, ''),
+           PrCount,
+           PrRows,
+           PrWeight,
+           PrHWD,
+           ISNULL(PrInfo, ''),
+           PrStatus,
+           ISNULL(PrEditDate, ''),
+           ISNULL(PrEditUser, 0),
+           PrPart,
+           PrPartLastDate,
+           PrPartAutoInc,
+           PrArchive,
+           ISNULL(PrPerGodn, 0),
+           ISNULL(PrSAP, ''),
+           PrProdType,
+           PrUmbrella,
+           PrSun,
+           PrDecl,
+           PrParty,
+           PrGL,
+           PrVP,
+           PrML,
+           ISNULL(extColor, 0),
+           ISNULL(extDesign, 0),
+           ISNULL(Created_at, ''),
+           Created_by,
+           ISNULL(Updated_at, ''),
+           Updated_by
+    FROM dbo.svTB_Production
+    WHERE idProduction = @Id;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionUpdById -- ХП обновляет продукцию по ИД.
+    @Id INT,
+    @PrName VARCHAR(300),
+    @PrShortName VARCHAR(100),
+    @PrPackName VARCHAR(300),
+    @PrType VARCHAR(100),
+    @PrArticle VARCHAR(5),
+    @PrColor VARCHAR(20),
+    @PrBarCode VARCHAR(13),
+    @PrCount INT,
+    @PrRows INT,
+    @PrWeight DECIMAL(19, 3),
+    @PrHWD VARCHAR(100),
+    @PrInfo VARCHAR(1024),
+    @PrStatus BIT,
+    @PrPerGodn SMALLINT,
+    @PrSAP VARCHAR(15),
+    @PrProdType BIT,
+    @PrUmbrella BIT,
+    @PrSun BIT,
+    @PrDecl BIT,
+    @PrParty BIT,
+    @PrGL SMALLINT,
+    @PrVP SMALLINT,
+    @PrML SMALLINT,
+    @ColorId INT,
+    @DesignId INT,
+    @Updated_by INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Production
+    SET PrName      = @PrName,
+        PrShortName = @PrShortName,
+        PrPackName  = @PrPackName,
+        PrType      = @PrType,
+        PrArticle   = @PrArticle,
+        PrColor     = @PrColor,
+        PrBarCode   = @PrBarCode,
+        PrCount     = @PrCount,
+        PrRows      = @PrRows,
+        PrWeight    = @PrWeight,
+        PrHWD       = @PrHWD,
+        PrInfo      = @PrInfo,
+        PrStatus    = @PrStatus,
+        PrPerGodn   = @PrPerGodn,
+        PrSAP       = @PrSAP,
+        PrProdType  = @PrProdType,
+        PrUmbrella  = @PrUmbrella,
+        PrSun       = @PrSun,
+        PrDecl      = @PrDecl,
+        PrParty     = @PrParty,
+        PrGL        = @PrGL,
+        PrVP        = @PrVP,
+        PrML        = @PrML,
+        extColor    = NULLIF(@ColorId, 0),
+        extDesign   = NULLIF(@DesignId, 0),
+        PrEditDate  = GETDATE(),
+        Updated_at  = GETDATE(),
+        Updated_by  = @Updated_by
+    WHERE idProduction = @Id;
+END
+GO;
+
+EXEC dbo.svTB_ProductionMapCatalogs;
@@ -0,0 +1,31 @@
+DROP PROCEDURE IF EXISTS dbo.svPerformerPassLegacy;
+DROP PROCEDURE IF EXISTS dbo.svPerformerUpdPassById;
+DROP PROCEDURE IF EXISTS dbo.svPerformerPassById;
+GO;
+
+-- Хеши паролей не помещаются в VARCHAR(30): перед откатом пароли нужно сбросить.
+ALTER TABLE dbo.svPerformers
+    ALTER COLUMN pass VARCHAR(30) NOT NULL;
+GO;
+
+CREATE PROCEDURE dbo.svPerformerAuth -- ХП проверяет сотрудника по табельному номеру и паролю для авторизации.
+    @Id INT,
+    @Pass VARCHAR(30)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    IF EXISTS(SELECT 1
+              FROM dbo.svPerformers
+              WHERE id = @Id
+                AND pass = @Pass
+                AND archive = 0)
+        BEGIN
+            SELECT 1 AS auth_success
+        END
+    ELSE
+        BEGIN
+            SELECT 0 AS auth_success
+        END
+END
+GO;
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- ХЕШИРОВАНИЕ ПАРОЛЕЙ СОТРУДНИКОВ. pass хранит хеш bcrypt (60 символов), незахешированный пароль заменяется хешем
+-- при первом успешном входе или командой cmd/hashpasswords.
+ALTER TABLE dbo.svPerformers
+    ALTER COLUMN pass VARCHAR(255) NOT NULL;
+GO;
+
+-- Сверка пароля в открытом виде на стороне БД не работает с хешем, пароль проверяется в приложении.
+DROP PROCEDURE IF EXISTS dbo.svPerformerAuth;
+GO;
+
+CREATE PROCEDURE dbo.svPerformerPassById -- ХП получает пароль (хеш) не архивного сотрудника для проверки при входе.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT pass
+    FROM dbo.svPerformers
+    WHERE id = @Id
+      AND archive = 0;
+END
+GO;
+
+CREATE PROCEDURE dbo.svPerformerUpdPassById -- ХП сохраняет хеш пароля сотрудника.
+    @Id INT,
+    @Pass VARCHAR(255)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svPerformers
+    SET pass       = @Pass,
+        updated_at = GETDATE()
+    WHERE id = @Id;
+END
+GO;
+
+CREATE PROCEDURE dbo.svPerformerPassLegacy -- ХП получает сотрудников с незахешированным паролем.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT id,
+           pass
+    FROM dbo.svPerformers
+    WHERE pass <> ''
+      AND pass NOT LIKE '$2_$%'
+    ORDER BY id;
+END
+GO;
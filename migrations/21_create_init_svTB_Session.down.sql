@@ -0,0 +1,6 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_SessionActive;
+DROP PROCEDURE IF EXISTS dbo.svTB_SessionRevokeByPerformer;
+DROP PROCEDURE IF EXISTS dbo.svTB_SessionRevoke;
+DROP PROCEDURE IF EXISTS dbo.svTB_SessionTouch;
+DROP PROCEDURE IF EXISTS dbo.svTB_SessionAdd;
+DROP TABLE IF EXISTS dbo.svTB_Session;
\ No newline at end of file
@@ -0,0 +1,107 @@
+-- СОЗДАТЬ ТАБЛИЦУ РЕЕСТРА СЕССИЙ. Сессия действует, пока запись не отозвана и не истекла. Хранится SHA-256 токена.
+CREATE TABLE dbo.svTB_Session
+(
+    idSession    CHAR(64)                  NOT NULL
+        CONSTRAINT PK_svTB_Session PRIMARY KEY, -- idSession - SHA-256 токена сессии (hex).
+    PerformerId  INT                       NOT NULL, -- PerformerId - табельный номер сотрудника.
+    RoleId       INT                       NOT NULL, -- RoleId - роль сессии.
+    DeviceType   VARCHAR(30)  DEFAULT ''   NOT NULL, -- DeviceType - тип устройства входа по бейджу.
+    RemoteAddr   VARCHAR(64)  DEFAULT ''   NOT NULL, -- RemoteAddr - адрес клиента при входе.
+    UserAgent    VARCHAR(255) DEFAULT ''   NOT NULL, -- UserAgent - браузер клиента при входе.
+    CreatedAt    DATETIME     DEFAULT GETDATE() NOT NULL, -- CreatedAt - время входа.
+    ExpiresAt    DATETIME                  NOT NULL, -- ExpiresAt - время окончания действия.
+    LastActivity DATETIME     DEFAULT GETDATE() NOT NULL, -- LastActivity - время последнего запроса.
+    RevokedAt    DATETIME                  NULL      -- RevokedAt - время отзыва, NULL - действует.
+);
+
+CREATE INDEX IX_svTB_Session_performer ON dbo.svTB_Session (PerformerId, RevokedAt);
+
+CREATE PROCEDURE dbo.svTB_SessionAdd -- ХП регистрирует сессию, заодно удаляет истекшие и отозванные.
+    @Id CHAR(64),
+    @PerformerId INT,
+    @RoleId INT,
+    @DeviceType VARCHAR(30),
+    @RemoteAddr VARCHAR(64),
+    @UserAgent VARCHAR(255),
+    @CreatedAt DATETIME,
+    @ExpiresAt DATETIME
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DELETE FROM dbo.svTB_Session WHERE ExpiresAt < GETDATE() OR RevokedAt < DATEADD(DAY, -1, GETDATE());
+
+    INSERT INTO dbo.svTB_Session (idSession, PerformerId, RoleId, DeviceType, RemoteAddr, UserAgent, CreatedAt,
+                                  ExpiresAt, LastActivity)
+    VALUES (@Id, @PerformerId, @RoleId, @DeviceType, @RemoteAddr, @UserAgent, @CreatedAt, @ExpiresAt, @CreatedAt);
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SessionTouch -- ХП отмечает активность сессии, возвращает 1, если сессия действует.
+    @Id CHAR(64),
+    @Now DATETIME
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Session
+    SET LastActivity = @Now
+    WHERE idSession = @Id
+      AND RevokedAt IS NULL
+      AND ExpiresAt > @Now;
+
+    SELECT CAST(CASE WHEN @@ROWCOUNT > 0 THEN 1 ELSE 0 END AS BIT) AS active;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SessionRevoke -- ХП отзывает сессию, возвращает отозвана ли она этим вызовом.
+@Id CHAR(64)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Session
+    SET RevokedAt = GETDATE()
+    WHERE idSession = @Id
+      AND RevokedAt IS NULL;
+
+    SELECT CAST(@@ROWCOUNT AS BIT) AS revoked;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SessionRevokeByPerformer -- ХП отзывает все сессии сотрудника, возвращает их кол-во.
+@PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Session
+    SET RevokedAt = GETDATE()
+    WHERE PerformerId = @PerformerId
+      AND RevokedAt IS NULL
+      AND ExpiresAt > GETDATE();
+
+    SELECT @@ROWCOUNT AS revoked;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SessionActive -- ХП получает действующие сессии.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idSession,
+           PerformerId,
+           RoleId,
+           DeviceType,
+           RemoteAddr,
+           UserAgent,
+           CreatedAt,
+           ExpiresAt,
+           LastActivity
+    FROM dbo.svTB_Session
+    WHERE RevokedAt IS NULL
+      AND ExpiresAt > GETDATE()
+    ORDER BY LastActivity DESC;
+END
+GO;
@@ -0,0 +1,8 @@
+DROP PROCEDURE IF EXISTS dbo.svPermissionsVersionGet;
+DROP PROCEDURE IF EXISTS dbo.svRolePermissionsSet;
+DROP PROCEDURE IF EXISTS dbo.svRolePermissionsByRole;
+DROP PROCEDURE IF EXISTS dbo.svRolePermissionsAll;
+DROP PROCEDURE IF EXISTS dbo.svPermissionAll;
+DROP TABLE IF EXISTS dbo.svPermissionsVersion;
+DROP TABLE IF EXISTS dbo.svRolePermissions;
+DROP TABLE IF EXISTS dbo.svPermissions;
\ No newline at end of file
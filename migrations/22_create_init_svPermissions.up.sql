@@ -0,0 +1,113 @@
+-- СОЗДАТЬ ТАБЛИЦУ ПРАВ ДОСТУПА. Код права проверяется в коде (RequirePermission), роли получают права через svRolePermissions.
+CREATE TABLE dbo.svPermissions
+(
+    code        VARCHAR(50)             NOT NULL
+        CONSTRAINT PK_svPermissions_code PRIMARY KEY, -- code - код права.
+    description VARCHAR(300) DEFAULT '' NOT NULL      -- description - описание права.
+);
+
+-- СОЗДАТЬ ТАБЛИЦУ ПРАВ РОЛЕЙ
+CREATE TABLE dbo.svRolePermissions
+(
+    idRole     INT                        NOT NULL
+        CONSTRAINT FK_svRolePermissions_role REFERENCES dbo.svRoles (id) ON DELETE CASCADE, -- idRole - ид роли.
+    code       VARCHAR(50)                NOT NULL
+        CONSTRAINT FK_svRolePermissions_code REFERENCES dbo.svPermissions (code),           -- code - код права.
+    created_at DATETIME DEFAULT GETDATE() NOT NULL,                                          -- created_at - дата выдачи права.
+    created_by INT                        NOT NULL,                                          -- created_by - табельный номер сотрудника.
+
+    CONSTRAINT PK_svRolePermissions PRIMARY KEY (idRole, code)
+);
+
+-- СОЗДАТЬ ТАБЛИЦУ ВЕРСИИ ПРАВ РОЛЕЙ. Версия растет при каждом изменении прав ролей, сессии всех экземпляров приложения
+-- сверяют с ней права, закешированные при входе.
+CREATE TABLE dbo.svPermissionsVersion
+(
+    id         TINYINT DEFAULT 1          NOT NULL
+        CONSTRAINT PK_svPermissionsVersion PRIMARY KEY
+        CONSTRAINT CK_svPermissionsVersion_single CHECK (id = 1), -- id - единственная строка.
+    version    BIGINT  DEFAULT 1          NOT NULL,              -- version - версия прав ролей.
+    updated_at DATETIME DEFAULT GETDATE() NOT NULL               -- updated_at - дата последнего изменения прав.
+);
+
+INSERT INTO dbo.svPermissionsVersion (id) VALUES (1);
+
+INSERT INTO dbo.svPermissions (code, description)
+VALUES ('admin.access', N'Вход в панель администратора'),
+       ('performers.edit', N'Сотрудники: изменение ролей, импорт из Галактики'),
+       ('roles.edit', N'Роли и права ролей'),
+       ('sectors.edit', N'Печи: закрепление сотрудников'),
+       ('products.edit', N'Продукция: архив, история, справочники'),
+       ('declarations.edit', N'Декларации о соответствии продукции'),
+       ('pack_stations.edit', N'Станции упаковки'),
+       ('sessions.manage', N'Активные сессии и блокировки входа'),
+       ('shift_tasks.edit', N'Сменно-суточные задания: добавление');
+
+-- Права, которые до сих пор давались ролями в коде: администратор (3) - все, диспетчер (5) - сменно-суточные задания.
+INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+SELECT r.id, p.code, 0
+FROM dbo.svRoles r
+         CROSS JOIN dbo.svPermissions p
+WHERE r.id = 3
+   OR (r.id = 5 AND p.code = 'shift_tasks.edit');
+
+CREATE PROCEDURE dbo.svPermissionAll -- ХП получение списка прав.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT code, description FROM dbo.svPermissions ORDER BY code;
+END
+GO;
+
+CREATE PROCEDURE dbo.svRolePermissionsAll -- ХП получение прав всех ролей.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idRole, code FROM dbo.svRolePermissions ORDER BY idRole, code;
+END
+GO;
+
+CREATE PROCEDURE dbo.svRolePermissionsByRole -- ХП получение кодов прав роли.
+@RoleId INT -- ид роли
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT code FROM dbo.svRolePermissions WHERE idRole = @RoleId ORDER BY code;
+END
+GO;
+
+CREATE PROCEDURE dbo.svRolePermissionsSet -- ХП заменяет права роли и увеличивает версию прав.
+    @RoleId INT, -- ид роли
+    @Codes VARCHAR(MAX), -- коды прав через запятую
+    @PerformerId INT -- ид сотрудника
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SET XACT_ABORT ON;
+
+    BEGIN TRANSACTION;
+
+    DELETE FROM dbo.svRolePermissions WHERE idRole = @RoleId;
+
+    INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+    SELECT DISTINCT @RoleId, LTRIM(RTRIM(value)), @PerformerId
+    FROM STRING_SPLIT(@Codes, ',')
+    WHERE LTRIM(RTRIM(value)) <> '';
+
+    UPDATE dbo.svPermissionsVersion SET version = version + 1, updated_at = GETDATE() WHERE id = 1;
+
+    COMMIT TRANSACTION;
+END
+GO;
+
+CREATE PROCEDURE dbo.svPermissionsVersionGet -- ХП получает версию прав ролей.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT version FROM dbo.svPermissionsVersion WHERE id = 1;
+END
+GO;
@@ -0,0 +1,8 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_ApiTokenTouch;
+DROP PROCEDURE IF EXISTS dbo.svTB_ApiTokenRevoke;
+DROP PROCEDURE IF EXISTS dbo.svTB_ApiTokenAll;
+DROP PROCEDURE IF EXISTS dbo.svTB_ApiTokenByHash;
+DROP PROCEDURE IF EXISTS dbo.svTB_ApiTokenAdd;
+DELETE FROM dbo.svRolePermissions WHERE code = 'api_tokens.manage';
+DELETE FROM dbo.svPermissions WHERE code = 'api_tokens.manage';
+DROP TABLE IF EXISTS dbo.svTB_ApiToken;
\ No newline at end of file
@@ -0,0 +1,112 @@
+-- СОЗДАТЬ ТАБЛИЦУ API-ТОКЕНОВ. Токен выдается сотруднику с набором областей доступа, хранится только его SHA-256.
+CREATE TABLE dbo.svTB_ApiToken
+(
+    idToken     INT IDENTITY (1, 1)       NOT NULL
+        CONSTRAINT PK_svTB_ApiToken PRIMARY KEY, -- idToken - ид токена.
+    Name        NVARCHAR(100)             NOT NULL, -- Name - назначение токена.
+    PerformerId INT                       NOT NULL, -- PerformerId - табельный номер сотрудника, от имени которого работает токен.
+    Scopes      VARCHAR(500) DEFAULT ''   NOT NULL, -- Scopes - области доступа через запятую.
+    TokenHash   CHAR(64)                  NOT NULL
+        CONSTRAINT UQ_svTB_ApiToken_hash UNIQUE, -- TokenHash - SHA-256 токена (hex).
+    CreatedAt   DATETIME     DEFAULT GETDATE() NOT NULL, -- CreatedAt - дата выдачи.
+    CreatedBy   INT                       NOT NULL, -- CreatedBy - табельный номер администратора.
+    ExpiresAt   DATETIME                  NULL, -- ExpiresAt - окончание действия, NULL - бессрочный.
+    LastUsedAt  DATETIME                  NULL, -- LastUsedAt - время последнего запроса.
+    RevokedAt   DATETIME                  NULL  -- RevokedAt - время отзыва, NULL - действует.
+);
+
+INSERT INTO dbo.svPermissions (code, description)
+VALUES ('api_tokens.manage', N'API-токены: выдача и отзыв');
+
+INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+SELECT id, 'api_tokens.manage', 0
+FROM dbo.svRoles
+WHERE id = 3;
+
+CREATE PROCEDURE dbo.svTB_ApiTokenAdd -- ХП выдает токен, возвращает его ид.
+    @Name NVARCHAR(100),
+    @PerformerId INT,
+    @Scopes VARCHAR(500),
+    @TokenHash CHAR(64),
+    @CreatedBy INT,
+    @ExpiresAt DATETIME
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_ApiToken (Name, PerformerId, Scopes, TokenHash, CreatedBy, ExpiresAt)
+    VALUES (@Name, @PerformerId, @Scopes, @TokenHash, @CreatedBy, @ExpiresAt);
+
+    SELECT CAST(SCOPE_IDENTITY() AS INT) AS idToken;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ApiTokenByHash -- ХП получает действующий токен по хешу с ролями сотрудника, токен сотрудника в архиве не действует.
+@TokenHash CHAR(64)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT t.idToken,
+           t.Name,
+           t.PerformerId,
+           t.Scopes,
+           t.CreatedAt,
+           t.CreatedBy,
+           t.ExpiresAt,
+           t.LastUsedAt,
+           t.RevokedAt,
+           p.id_role_a_forms,
+           p.id_role_a_fgw
+    FROM dbo.svTB_ApiToken t
+             INNER JOIN dbo.svPerformers p ON p.id = t.PerformerId AND p.archive = 0
+    WHERE t.TokenHash = @TokenHash
+      AND t.RevokedAt IS NULL
+      AND (t.ExpiresAt IS NULL OR t.ExpiresAt > GETDATE());
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ApiTokenAll -- ХП получает список токенов, хеши токенов не возвращаются.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT idToken,
+           Name,
+           PerformerId,
+           Scopes,
+           CreatedAt,
+           CreatedBy,
+           ExpiresAt,
+           LastUsedAt,
+           RevokedAt
+    FROM dbo.svTB_ApiToken
+    ORDER BY CASE WHEN RevokedAt IS NULL THEN 0 ELSE 1 END, CreatedAt DESC;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ApiTokenRevoke -- ХП отзывает токен.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_ApiToken
+    SET RevokedAt = GETDATE()
+    WHERE idToken = @Id
+      AND RevokedAt IS NULL;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_ApiTokenTouch -- ХП отмечает время последнего запроса по токену.
+    @Id INT,
+    @Now DATETIME
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_ApiToken
+    SET LastUsedAt = @Now
+    WHERE idToken = @Id;
+END
+GO;
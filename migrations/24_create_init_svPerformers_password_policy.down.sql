@@ -0,0 +1,13 @@
+DROP PROCEDURE IF EXISTS dbo.svPerformerPassMustChangeById;
+DROP PROCEDURE IF EXISTS dbo.svPerformerPassHistoryById;
+DROP PROCEDURE IF EXISTS dbo.svPerformerSetPassById;
+DELETE FROM dbo.svRolePermissions WHERE code = 'performers.password_reset';
+DELETE FROM dbo.svPermissions WHERE code = 'performers.password_reset';
+DROP TABLE IF EXISTS dbo.svTB_PerformerPassHistory;
+GO;
+
+ALTER TABLE dbo.svPerformers
+    DROP CONSTRAINT DF_svPerformers_pass_must_change;
+
+ALTER TABLE dbo.svPerformers
+    DROP COLUMN pass_must_change, pass_changed_at;
\ No newline at end of file
@@ -0,0 +1,80 @@
+-- СМЕНА И СБРОС ПАРОЛЕЙ СОТРУДНИКОВ. pass_must_change - пароль временный (выдан администратором при сбросе) и
+-- должен быть сменен при следующем входе, svTB_PerformerPassHistory - хеши прежних паролей для запрета повторов.
+ALTER TABLE dbo.svPerformers
+    ADD pass_must_change BIT NOT NULL
+        CONSTRAINT DF_svPerformers_pass_must_change DEFAULT 0, -- pass_must_change - пароль временный, сменить при входе.
+        pass_changed_at  DATETIME NULL;                        -- pass_changed_at - дата последней смены пароля.
+GO;
+
+CREATE TABLE dbo.svTB_PerformerPassHistory
+(
+    id          INT IDENTITY (1, 1)           NOT NULL
+        CONSTRAINT PK_svTB_PerformerPassHistory PRIMARY KEY, -- id - ид записи.
+    PerformerId INT                           NOT NULL, -- PerformerId - табельный номер сотрудника.
+    PassHash    VARCHAR(255)                  NOT NULL, -- PassHash - хеш прежнего пароля.
+    CreatedAt   DATETIME DEFAULT GETDATE()    NOT NULL, -- CreatedAt - дата смены пароля.
+    CreatedBy   INT                           NOT NULL  -- CreatedBy - табельный номер сменившего пароль.
+);
+
+CREATE INDEX IX_svTB_PerformerPassHistory_performer
+    ON dbo.svTB_PerformerPassHistory (PerformerId, CreatedAt DESC);
+
+INSERT INTO dbo.svPermissions (code, description)
+VALUES ('performers.password_reset', N'Сотрудники: сброс пароля');
+
+INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+SELECT id, 'performers.password_reset', 0
+FROM dbo.svRoles
+WHERE id = 3;
+GO;
+
+CREATE PROCEDURE dbo.svPerformerSetPassById -- ХП сохраняет новый хеш пароля сотрудника, прежний хеш уходит в историю.
+    @Id INT,
+    @Pass VARCHAR(255),
+    @MustChange BIT,
+    @UpdatedBy INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    INSERT INTO dbo.svTB_PerformerPassHistory (PerformerId, PassHash, CreatedBy)
+    SELECT id, pass, @UpdatedBy
+    FROM dbo.svPerformers
+    WHERE id = @Id
+      AND pass LIKE '$2_$%';
+
+    UPDATE dbo.svPerformers
+    SET pass             = @Pass,
+        pass_must_change = @MustChange,
+        pass_changed_at  = GETDATE(),
+        updated_at       = GETDATE(),
+        updated_by       = @UpdatedBy
+    WHERE id = @Id;
+END
+GO;
+
+CREATE PROCEDURE dbo.svPerformerPassHistoryById -- ХП получает хеши последних прежних паролей сотрудника.
+    @Id INT,
+    @Limit INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT TOP (@Limit) PassHash
+    FROM dbo.svTB_PerformerPassHistory
+    WHERE PerformerId = @Id
+    ORDER BY CreatedAt DESC, id DESC;
+END
+GO;
+
+CREATE PROCEDURE dbo.svPerformerPassMustChangeById -- ХП проверяет, должен ли сотрудник сменить пароль.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT pass_must_change
+    FROM dbo.svPerformers
+    WHERE id = @Id;
+END
+GO;
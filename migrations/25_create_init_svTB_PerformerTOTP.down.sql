@@ -0,0 +1,10 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerRecoveryCodesLeft;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerRecoveryCodeUse;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerRecoveryCodesSet;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPDel;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPUseStep;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPConfirm;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPSet;
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPByPerformer;
+DROP TABLE IF EXISTS dbo.svTB_PerformerRecoveryCode;
+DROP TABLE IF EXISTS dbo.svTB_PerformerTOTP;
\ No newline at end of file
@@ -0,0 +1,145 @@
+-- СОЗДАТЬ ТАБЛИЦЫ ДВУХФАКТОРНОЙ АУТЕНТИФИКАЦИИ (TOTP). Секрет хранится зашифрованным ключом TOTP_KEY, коды
+-- восстановления - только их SHA-256.
+CREATE TABLE dbo.svTB_PerformerTOTP
+(
+    PerformerId INT                            NOT NULL
+        CONSTRAINT PK_svTB_PerformerTOTP PRIMARY KEY, -- PerformerId - табельный номер сотрудника.
+    Secret      VARCHAR(255)                   NOT NULL, -- Secret - секрет TOTP.
+    CreatedAt   DATETIME DEFAULT GETDATE()     NOT NULL, -- CreatedAt - дата начала подключения.
+    ConfirmedAt DATETIME                       NULL, -- ConfirmedAt - дата подтверждения кодом, NULL - не подключена.
+    LastStep    BIGINT   DEFAULT 0             NOT NULL  -- LastStep - интервал последнего принятого кода, повторно код не принимается.
+);
+
+CREATE TABLE dbo.svTB_PerformerRecoveryCode
+(
+    id          INT IDENTITY (1, 1)            NOT NULL
+        CONSTRAINT PK_svTB_PerformerRecoveryCode PRIMARY KEY, -- id - ид записи.
+    PerformerId INT                            NOT NULL, -- PerformerId - табельный номер сотрудника.
+    CodeHash    CHAR(64)                       NOT NULL, -- CodeHash - SHA-256 кода восстановления (hex).
+    CreatedAt   DATETIME DEFAULT GETDATE()     NOT NULL, -- CreatedAt - дата выдачи.
+    UsedAt      DATETIME                       NULL      -- UsedAt - дата использования, NULL - не использован.
+);
+
+CREATE INDEX IX_svTB_PerformerRecoveryCode_performer ON dbo.svTB_PerformerRecoveryCode (PerformerId, CodeHash);
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerTOTPByPerformer -- ХП получает секрет TOTP сотрудника.
+@PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT PerformerId,
+           Secret,
+           ConfirmedAt,
+           LastStep
+    FROM dbo.svTB_PerformerTOTP
+    WHERE PerformerId = @PerformerId;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerTOTPSet -- ХП сохраняет новый секрет TOTP, до подтверждения 2FA не подключена.
+    @PerformerId INT,
+    @Secret VARCHAR(255)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DELETE FROM dbo.svTB_PerformerTOTP WHERE PerformerId = @PerformerId;
+
+    INSERT INTO dbo.svTB_PerformerTOTP (PerformerId, Secret)
+    VALUES (@PerformerId, @Secret);
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerTOTPConfirm -- ХП подключает 2FA после проверки первого кода.
+    @PerformerId INT,
+    @Step BIGINT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_PerformerTOTP
+    SET ConfirmedAt = GETDATE(),
+        LastStep    = @Step
+    WHERE PerformerId = @PerformerId;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerTOTPUseStep -- ХП принимает код интервала @Step, возвращает 0, если код уже использован.
+    @PerformerId INT,
+    @Step BIGINT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_PerformerTOTP
+    SET LastStep = @Step
+    WHERE PerformerId = @PerformerId
+      AND LastStep < @Step;
+
+    SELECT CAST(CASE WHEN @@ROWCOUNT > 0 THEN 1 ELSE 0 END AS BIT) AS accepted;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerTOTPDel -- ХП отключает 2FA: удаляет секрет и коды восстановления.
+@PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DELETE FROM dbo.svTB_PerformerRecoveryCode WHERE PerformerId = @PerformerId;
+    DELETE FROM dbo.svTB_PerformerTOTP WHERE PerformerId = @PerformerId;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerRecoveryCodesSet -- ХП заменяет коды восстановления, @Hashes - SHA-256 через запятую.
+    @PerformerId INT,
+    @Hashes VARCHAR(MAX)
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SET XACT_ABORT ON; -- ошибка откатывает всю транзакцию: прежние коды не удаляются без новых.
+
+    BEGIN TRANSACTION;
+
+    DELETE FROM dbo.svTB_PerformerRecoveryCode WHERE PerformerId = @PerformerId;
+
+    INSERT INTO dbo.svTB_PerformerRecoveryCode (PerformerId, CodeHash)
+    SELECT @PerformerId, value
+    FROM STRING_SPLIT(@Hashes, ',')
+    WHERE value <> '';
+
+    COMMIT TRANSACTION;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerRecoveryCodeUse -- ХП использует код восстановления, возвращает 0, если кода нет или он использован.
+    @PerformerId INT,
+    @CodeHash CHAR(64)
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE TOP (1) dbo.svTB_PerformerRecoveryCode
+    SET UsedAt = GETDATE()
+    WHERE PerformerId = @PerformerId
+      AND CodeHash = @CodeHash
+      AND UsedAt IS NULL;
+
+    SELECT CAST(CASE WHEN @@ROWCOUNT > 0 THEN 1 ELSE 0 END AS BIT) AS accepted;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PerformerRecoveryCodesLeft -- ХП считает неиспользованные коды восстановления.
+@PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT COUNT(*)
+    FROM dbo.svTB_PerformerRecoveryCode
+    WHERE PerformerId = @PerformerId
+      AND UsedAt IS NULL;
+END
+GO;
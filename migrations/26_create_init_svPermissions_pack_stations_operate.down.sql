@@ -0,0 +1,4 @@
+DELETE FROM dbo.svRolePermissions WHERE code = 'pack_stations.operate';
+DELETE FROM dbo.svPermissions WHERE code = 'pack_stations.operate';
+UPDATE dbo.svPermissionsVersion SET version = version + 1, updated_at = GETDATE() WHERE id = 1;
+GO;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- ПРАВО РАБОТЫ НА СТАНЦИИ УПАКОВКИ. Открытие и закрытие сеанса станции и печать штампа через JSON API по сессии
+-- сотрудника, проверяется по роли FGW. Выдается кладовщику, мастеру и администратору.
+INSERT INTO dbo.svPermissions (code, description)
+VALUES ('pack_stations.operate', N'Станции упаковки: сеанс и печать штампа');
+
+INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+SELECT id, 'pack_stations.operate', 0
+FROM dbo.svRoles
+WHERE id IN (1, 2, 3);
+
+UPDATE dbo.svPermissionsVersion SET version = version + 1, updated_at = GETDATE() WHERE id = 1;
+GO;
@@ -0,0 +1,3 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_PalletPrint;
+DROP PROCEDURE IF EXISTS dbo.svTB_PalletById;
+DROP TABLE IF EXISTS dbo.svTB_Pallet;
\ No newline at end of file
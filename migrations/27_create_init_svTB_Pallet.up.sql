@@ -0,0 +1,89 @@
+-- СОЗДАТЬ ТАБЛИЦУ НАПЕЧАТАННЫХ П\П. П\П печатается только в открытом сеансе станции упаковки и штампуется станцией,
+-- печкой и сотрудником сеанса.
+CREATE TABLE dbo.svTB_Pallet
+(
+    idPallet       INT IDENTITY (1,1)
+        CONSTRAINT PK_svTB_Pallet PRIMARY KEY NONCLUSTERED, -- idPallet - ид п\п.
+    extSession     INT                        NOT NULL,     -- extSession - сеанс станции, в котором напечатан п\п.
+    extPackStation INT                        NOT NULL,     -- extPackStation - внешний ключ на svTB_PackStation.
+    extSector      INT                        NOT NULL,     -- extSector - печь сеанса.
+    extPerformer   INT                        NOT NULL,     -- extPerformer - сотрудник сеанса.
+    extProduction  INT                        NOT NULL,     -- extProduction - внешний ключ на svTB_Production.
+    Qty            INT                        NOT NULL,     -- Qty - штук на п\п: ряды * шт. в ряду на момент печати.
+    PrintedAt      DATETIME DEFAULT GETDATE() NOT NULL,     -- PrintedAt - дата печати.
+    created_by     INT                        NOT NULL,     -- created_by - ид сотрудника, отправившего п\п на печать.
+
+    CONSTRAINT FK_svTB_Pallet_session FOREIGN KEY (extSession) REFERENCES dbo.svTB_PackStationSession (idSession),
+    CONSTRAINT FK_svTB_Pallet_station FOREIGN KEY (extPackStation) REFERENCES dbo.svTB_PackStation (idPackStation),
+    CONSTRAINT FK_svTB_Pallet_sector FOREIGN KEY (extSector) REFERENCES dbo.svTB_Sector (idSector),
+    CONSTRAINT FK_svTB_Pallet_performer FOREIGN KEY (extPerformer) REFERENCES dbo.svTB_Performer (idPerformer),
+    CONSTRAINT FK_svTB_Pallet_production FOREIGN KEY (extProduction) REFERENCES dbo.svTB_Production (idProduction)
+);
+
+CREATE INDEX IX_svTB_Pallet_printed ON dbo.svTB_Pallet (PrintedAt);
+
+CREATE PROCEDURE dbo.svTB_PalletById -- ХП получает п\п с отметкой сеанса.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT pl.idPallet,
+           pl.extSession,
+           st.idPackStation,
+           st.StName,
+           pl.extSector,
+           s.SectorName,
+           pf.idPerformer,
+           pf.PfTabnum,
+           pf.PfName,
+           pr.idProduction,
+           pr.PrArticle,
+           pr.PrName,
+           pl.Qty,
+           pl.PrintedAt
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_PackStation st ON st.idPackStation = pl.extPackStation
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = pl.extSector
+             INNER JOIN dbo.svTB_Performer pf ON pf.idPerformer = pl.extPerformer
+             INNER JOIN dbo.svTB_Production pr ON pr.idProduction = pl.extProduction
+    WHERE pl.idPallet = @Id;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PalletPrint -- ХП регистрирует печать п\п в открытом сеансе станции.
+    @StationId INT,
+    @ProductionId INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @SessionId INT;
+    DECLARE @SectorId INT;
+    DECLARE @SessionPerformerId INT;
+    DECLARE @Qty INT;
+
+    -- Отметка берется из открытого сеанса станции, без сеанса п\п не печатается.
+    SELECT TOP 1 @SessionId = idSession,
+                 @SectorId = extSector,
+                 @SessionPerformerId = extPerformer
+    FROM dbo.svTB_PackStationSession
+    WHERE extPackStation = @StationId
+      AND ClosedAt IS NULL
+    ORDER BY OpenedAt DESC;
+
+    SELECT @Qty = PrCount * PrRows FROM dbo.svTB_Production WHERE idProduction = @ProductionId;
+
+    IF @SessionId IS NULL OR @Qty IS NULL
+        RETURN;
+
+    INSERT INTO dbo.svTB_Pallet (extSession, extPackStation, extSector, extPerformer, extProduction, Qty,
+                                 PrintedAt, created_by)
+    VALUES (@SessionId, @StationId, @SectorId, @SessionPerformerId, @ProductionId, @Qty, GETDATE(), @PerformerId);
+
+    DECLARE @Id INT = SCOPE_IDENTITY();
+
+    EXEC dbo.svTB_PalletById @Id;
+END
+GO;
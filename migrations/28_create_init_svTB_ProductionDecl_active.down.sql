@@ -0,0 +1,56 @@
+-- Вернуть ХП деклараций к версии, где действующей считается последняя по дате окончания.
+ALTER PROCEDURE dbo.svTB_ProductionDeclByProduction -- ХП получает декларации продукции, действующая первой.
+@ProductionId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT d.idDecl,
+           d.extProduction,
+           p.PrArticle,
+           p.PrName,
+           d.DeclNumber,
+           CONVERT(VARCHAR(10), d.ValidFrom, 23) AS ValidFrom,
+           CONVERT(VARCHAR(10), d.ValidTo, 23)   AS ValidTo,
+           DATEDIFF(DAY, CAST(GETDATE() AS DATE), d.ValidTo) AS DaysLeft,
+           d.created_at,
+           d.created_by,
+           d.updated_at,
+           d.updated_by
+    FROM dbo.svTB_ProductionDecl d
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = d.extProduction
+    WHERE d.extProduction = @ProductionId
+    ORDER BY d.ValidTo DESC;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_ProductionDeclExpiring -- ХП декларированная продукция, у которой действующая декларация
+-- истекает в течение @Days дней, уже истекла или отсутствует.
+@Days INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT ISNULL(d.idDecl, 0),
+           p.idProduction,
+           p.PrArticle,
+           p.PrName,
+           ISNULL(d.DeclNumber, ''),
+           ISNULL(CONVERT(VARCHAR(10), d.ValidFrom, 23), ''),
+           ISNULL(CONVERT(VARCHAR(10), d.ValidTo, 23), ''),
+           ISNULL(DATEDIFF(DAY, CAST(GETDATE() AS DATE), d.ValidTo), -1),
+           ISNULL(d.created_at, ''),
+           ISNULL(d.created_by, 0),
+           ISNULL(d.updated_at, ''),
+           ISNULL(d.updated_by, 0)
+    FROM dbo.svTB_Production p
+             OUTER APPLY (SELECT TOP 1 *
+                          FROM dbo.svTB_ProductionDecl
+                          WHERE extProduction = p.idProduction
+                          ORDER BY ValidTo DESC) d
+    WHERE p.PrDecl = 1
+      AND p.PrArchive = 0
+      AND (d.idDecl IS NULL OR d.ValidTo <= DATEADD(DAY, @Days, CAST(GETDATE() AS DATE)))
+    ORDER BY d.ValidTo, p.PrArticle;
+END
+GO;
\ No newline at end of file
@@ -0,0 +1,36 @@
+DELETE FROM dbo.svRolePermissions WHERE code = 'pallets.move';
+DELETE FROM dbo.svPermissions WHERE code = 'pallets.move';
+UPDATE dbo.svPermissionsVersion SET version = version + 1, updated_at = GETDATE() WHERE id = 1;
+GO;
+
+DROP PROCEDURE IF EXISTS dbo.svTB_SapStockByDate;
+DROP PROCEDURE IF EXISTS dbo.svTB_SapShipmentsByDate;
+DROP PROCEDURE IF EXISTS dbo.svTB_PalletShip;
+GO;
+
+-- Вернуть приход к факту сменно-суточных заданий.
+ALTER PROCEDURE dbo.svTB_SapReceiptsByDate -- ХП получает приход продукции за дату, сгруппированный по коду SAP.
+@TaskDate DATE
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT CONVERT(VARCHAR(10), t.TaskDate, 23) AS TaskDate,
+           p.PrSAP,
+           p.PrArticle,
+           p.PrName,
+           SUM(t.FactQty)             AS Pallets, -- Pallets - кол-во п\п.
+           SUM(t.FactQty * p.PrCount * p.PrRows) AS Qty      -- Qty - штук: п\п * ряды * шт. в ряду.
+    FROM dbo.svTB_ShiftTask t
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = t.extProduction
+    WHERE t.TaskDate = @TaskDate
+      AND t.FactQty > 0
+      AND ISNULL(p.PrSAP, '') <> ''
+    GROUP BY t.TaskDate, p.PrSAP, p.PrArticle, p.PrName
+    ORDER BY p.PrSAP;
+END
+GO;
+
+DROP INDEX IF EXISTS IX_svTB_Pallet_shipped ON dbo.svTB_Pallet;
+ALTER TABLE dbo.svTB_Pallet DROP COLUMN ShippedAt, ShippedBy;
+GO;
\ No newline at end of file
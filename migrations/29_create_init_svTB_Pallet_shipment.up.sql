@@ -0,0 +1,104 @@
+-- ОТГРУЗКА П\П И ВЫГРУЗКА В SAP ПО П\П. Приход - п\п, напечатанные за день; отгрузка - п\п, отгруженные за день;
+-- остаток - п\п, напечатанные и не отгруженные на конец дня. Продукция без кода SAP не выгружается.
+ALTER TABLE dbo.svTB_Pallet
+    ADD ShippedAt DATETIME NULL, -- ShippedAt - дата отгрузки, NULL - п\п на складе.
+        ShippedBy INT      NULL; -- ShippedBy - ид сотрудника, отгрузившего п\п.
+GO;
+
+CREATE INDEX IX_svTB_Pallet_shipped ON dbo.svTB_Pallet (ShippedAt);
+GO;
+
+CREATE PROCEDURE dbo.svTB_PalletShip -- ХП отгружает п\п со склада, 0 - п\п не найден или уже отгружен.
+    @Id INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Pallet
+    SET ShippedAt = GETDATE(),
+        ShippedBy = @PerformerId
+    WHERE idPallet = @Id
+      AND ShippedAt IS NULL;
+
+    SELECT @@ROWCOUNT AS result;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_SapReceiptsByDate -- ХП получает приход продукции за дату, сгруппированный по коду SAP.
+@TaskDate DATE
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT CONVERT(VARCHAR(10), @TaskDate, 23) AS TaskDate,
+           p.PrSAP,
+           p.PrArticle,
+           p.PrName,
+           COUNT(*)    AS Pallets, -- Pallets - кол-во п\п.
+           SUM(pl.Qty) AS Qty      -- Qty - штук на п\п на момент печати.
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = pl.extProduction
+    WHERE pl.PrintedAt >= @TaskDate
+      AND pl.PrintedAt < DATEADD(DAY, 1, @TaskDate)
+      AND ISNULL(p.PrSAP, '') <> ''
+    GROUP BY p.PrSAP, p.PrArticle, p.PrName
+    ORDER BY p.PrSAP;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SapShipmentsByDate -- ХП получает отгрузку продукции за дату, сгруппированную по коду SAP.
+@Date DATE
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT CONVERT(VARCHAR(10), @Date, 23) AS ShipDate,
+           p.PrSAP,
+           p.PrArticle,
+           p.PrName,
+           COUNT(*)    AS Pallets,
+           SUM(pl.Qty) AS Qty
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = pl.extProduction
+    WHERE pl.ShippedAt >= @Date
+      AND pl.ShippedAt < DATEADD(DAY, 1, @Date)
+      AND ISNULL(p.PrSAP, '') <> ''
+    GROUP BY p.PrSAP, p.PrArticle, p.PrName
+    ORDER BY p.PrSAP;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_SapStockByDate -- ХП получает остаток продукции на конец даты, сгруппированный по коду SAP.
+@Date DATE
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT CONVERT(VARCHAR(10), @Date, 23) AS StockDate,
+           p.PrSAP,
+           p.PrArticle,
+           p.PrName,
+           COUNT(*)    AS Pallets,
+           SUM(pl.Qty) AS Qty
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_Production p ON p.idProduction = pl.extProduction
+    WHERE pl.PrintedAt < DATEADD(DAY, 1, @Date)
+      AND (pl.ShippedAt IS NULL OR pl.ShippedAt >= DATEADD(DAY, 1, @Date))
+      AND ISNULL(p.PrSAP, '') <> ''
+    GROUP BY p.PrSAP, p.PrArticle, p.PrName
+    ORDER BY p.PrSAP;
+END
+GO;
+
+-- ПРАВО ОТГРУЗКИ П\П. Проверяется по роли FGW, выдается кладовщику, мастеру и администратору.
+INSERT INTO dbo.svPermissions (code, description)
+VALUES ('pallets.move', N'П\п: отгрузка со склада');
+
+INSERT INTO dbo.svRolePermissions (idRole, code, created_by)
+SELECT id, 'pallets.move', 0
+FROM dbo.svRoles
+WHERE id IN (1, 2, 3);
+
+UPDATE dbo.svPermissionsVersion SET version = version + 1, updated_at = GETDATE() WHERE id = 1;
+GO;
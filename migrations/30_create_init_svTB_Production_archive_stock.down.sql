@@ -0,0 +1,30 @@
+-- Вернуть проверку только невыполненных сменно-суточных заданий.
+ALTER PROCEDURE dbo.svTB_ProductionArchiveById -- ХП архивирует или возвращает из архива продукцию.
+    @Id INT,
+    @Archive BIT,
+    @Updated_by INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    -- Продукцию нельзя архивировать, пока по ней есть невыполненные сменно-суточные задания с текущего дня.
+    IF @Archive = 1 AND EXISTS(SELECT 1
+                               FROM dbo.svTB_ShiftTask
+                               WHERE extProduction = @Id
+                                 AND TaskDate >= CAST(GETDATE() AS DATE)
+                                 AND FactQty < PlanQty)
+        BEGIN
+            SELECT 1 AS result;
+            RETURN;
+        END
+
+    UPDATE dbo.svTB_Production
+    SET PrArchive  = @Archive,
+        PrEditDate = GETDATE(),
+        Updated_at = GETDATE(),
+        Updated_by = @Updated_by
+    WHERE idProduction = @Id;
+
+    SELECT 0 AS result;
+END
+GO;
\ No newline at end of file
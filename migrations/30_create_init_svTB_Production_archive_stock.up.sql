@@ -0,0 +1,39 @@
+-- АРХИВ ПРОДУКЦИИ: ОСТАТОК И ОТКРЫТЫЕ ЗАЯВКИ. Продукцию нельзя архивировать, пока она есть на складе (напечатанные и
+-- не отгруженные п\п) или по ней есть открытые заявки - невыполненные сменно-суточные задания с текущего дня.
+ALTER PROCEDURE dbo.svTB_ProductionArchiveById -- ХП архивирует или возвращает из архива продукцию.
+    @Id INT,
+    @Archive BIT,
+    @Updated_by INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    IF @Archive = 1 AND EXISTS(SELECT 1
+                               FROM dbo.svTB_ShiftTask
+                               WHERE extProduction = @Id
+                                 AND TaskDate >= CAST(GETDATE() AS DATE)
+                                 AND FactQty < PlanQty)
+        BEGIN
+            SELECT 1 AS result;
+            RETURN;
+        END
+
+    IF @Archive = 1 AND EXISTS(SELECT 1
+                               FROM dbo.svTB_Pallet
+                               WHERE extProduction = @Id
+                                 AND ShippedAt IS NULL)
+        BEGIN
+            SELECT 2 AS result;
+            RETURN;
+        END
+
+    UPDATE dbo.svTB_Production
+    SET PrArchive  = @Archive,
+        PrEditDate = GETDATE(),
+        Updated_at = GETDATE(),
+        Updated_by = @Updated_by
+    WHERE idProduction = @Id;
+
+    SELECT 0 AS result;
+END
+GO;
@@ -0,0 +1,86 @@
+-- Вернуть печать п\п без задания и ручную отметку факта.
+ALTER PROCEDURE dbo.svTB_PalletPrint -- ХП регистрирует печать п\п в открытом сеансе станции.
+    @StationId INT,
+    @ProductionId INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    DECLARE @SessionId INT;
+    DECLARE @SectorId INT;
+    DECLARE @SessionPerformerId INT;
+    DECLARE @Qty INT;
+
+    -- Отметка берется из открытого сеанса станции, без сеанса п\п не печатается.
+    SELECT TOP 1 @SessionId = idSession,
+                 @SectorId = extSector,
+                 @SessionPerformerId = extPerformer
+    FROM dbo.svTB_PackStationSession
+    WHERE extPackStation = @StationId
+      AND ClosedAt IS NULL
+    ORDER BY OpenedAt DESC;
+
+    SELECT @Qty = PrCount * PrRows FROM dbo.svTB_Production WHERE idProduction = @ProductionId;
+
+    IF @SessionId IS NULL OR @Qty IS NULL
+        RETURN;
+
+    INSERT INTO dbo.svTB_Pallet (extSession, extPackStation, extSector, extPerformer, extProduction, Qty,
+                                 PrintedAt, created_by)
+    VALUES (@SessionId, @StationId, @SectorId, @SessionPerformerId, @ProductionId, @Qty, GETDATE(), @PerformerId);
+
+    DECLARE @Id INT = SCOPE_IDENTITY();
+
+    EXEC dbo.svTB_PalletById @Id;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_PalletById -- ХП получает п\п с отметкой сеанса.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT pl.idPallet,
+           pl.extSession,
+           st.idPackStation,
+           st.StName,
+           pl.extSector,
+           s.SectorName,
+           pf.idPerformer,
+           pf.PfTabnum,
+           pf.PfName,
+           pr.idProduction,
+           pr.PrArticle,
+           pr.PrName,
+           pl.Qty,
+           pl.PrintedAt
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_PackStation st ON st.idPackStation = pl.extPackStation
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = pl.extSector
+             INNER JOIN dbo.svTB_Performer pf ON pf.idPerformer = pl.extPerformer
+             INNER JOIN dbo.svTB_Production pr ON pr.idProduction = pl.extProduction
+    WHERE pl.idPallet = @Id;
+END
+GO;
+
+ALTER TABLE dbo.svTB_Pallet DROP CONSTRAINT FK_svTB_Pallet_shift_task;
+ALTER TABLE dbo.svTB_Pallet DROP COLUMN extShiftTask;
+GO;
+
+CREATE PROCEDURE dbo.svTB_ShiftTaskAddFact -- ХП увеличивает факт задания при печати п\п.
+    @Id INT,
+    @Qty INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_ShiftTask
+    SET FactQty    = FactQty + @Qty,
+        updated_at = GETDATE(),
+        updated_by = @PerformerId
+    WHERE idShiftTask = @Id;
+END
+GO;
\ No newline at end of file
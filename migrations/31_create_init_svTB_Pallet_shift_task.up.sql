@@ -0,0 +1,100 @@
+-- ФАКТ СМЕННО-СУТОЧНОГО ЗАДАНИЯ ПО ПЕЧАТИ П\П. Факт задания увеличивается только печатью п\п на станции в той же
+-- транзакции, ручная отметка факта удалена. Задание должно быть на печь сеанса и продукцию п\п, на текущие или
+-- прошлые сутки (ночная смена).
+DROP PROCEDURE IF EXISTS dbo.svTB_ShiftTaskAddFact;
+GO;
+
+ALTER TABLE dbo.svTB_Pallet
+    ADD extShiftTask INT NULL -- extShiftTask - задание, в факт которого засчитан п\п, NULL - печать без задания.
+        CONSTRAINT FK_svTB_Pallet_shift_task REFERENCES dbo.svTB_ShiftTask (idShiftTask);
+GO;
+
+ALTER PROCEDURE dbo.svTB_PalletById -- ХП получает п\п с отметкой сеанса.
+@Id INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT pl.idPallet,
+           pl.extSession,
+           st.idPackStation,
+           st.StName,
+           pl.extSector,
+           s.SectorName,
+           pf.idPerformer,
+           pf.PfTabnum,
+           pf.PfName,
+           pr.idProduction,
+           pr.PrArticle,
+           pr.PrName,
+           pl.Qty,
+           pl.PrintedAt,
+           ISNULL(pl.extShiftTask, 0) AS ShiftTaskId
+    FROM dbo.svTB_Pallet pl
+             INNER JOIN dbo.svTB_PackStation st ON st.idPackStation = pl.extPackStation
+             INNER JOIN dbo.svTB_Sector s ON s.idSector = pl.extSector
+             INNER JOIN dbo.svTB_Performer pf ON pf.idPerformer = pl.extPerformer
+             INNER JOIN dbo.svTB_Production pr ON pr.idProduction = pl.extProduction
+    WHERE pl.idPallet = @Id;
+END
+GO;
+
+ALTER PROCEDURE dbo.svTB_PalletPrint -- ХП регистрирует печать п\п в открытом сеансе станции.
+    @StationId INT,
+    @ProductionId INT,
+    @ShiftTaskId INT,
+    @PerformerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SET XACT_ABORT ON; -- ошибка откатывает всю транзакцию: факт задания не растет без п\п.
+
+    DECLARE @SessionId INT;
+    DECLARE @SectorId INT;
+    DECLARE @SessionPerformerId INT;
+    DECLARE @Qty INT;
+
+    -- Отметка берется из открытого сеанса станции, без сеанса п\п не печатается.
+    SELECT TOP 1 @SessionId = idSession,
+                 @SectorId = extSector,
+                 @SessionPerformerId = extPerformer
+    FROM dbo.svTB_PackStationSession
+    WHERE extPackStation = @StationId
+      AND ClosedAt IS NULL
+    ORDER BY OpenedAt DESC;
+
+    SELECT @Qty = PrCount * PrRows FROM dbo.svTB_Production WHERE idProduction = @ProductionId;
+
+    IF @SessionId IS NULL OR @Qty IS NULL
+        RETURN;
+
+    IF @ShiftTaskId > 0 AND NOT EXISTS(SELECT 1
+                                       FROM dbo.svTB_ShiftTask
+                                       WHERE idShiftTask = @ShiftTaskId
+                                         AND extSector = @SectorId
+                                         AND extProduction = @ProductionId
+                                         AND TaskDate BETWEEN DATEADD(DAY, -1, CAST(GETDATE() AS DATE))
+                                             AND CAST(GETDATE() AS DATE))
+        RETURN;
+
+    BEGIN TRANSACTION;
+
+    INSERT INTO dbo.svTB_Pallet (extSession, extPackStation, extSector, extPerformer, extProduction, extShiftTask,
+                                 Qty, PrintedAt, created_by)
+    VALUES (@SessionId, @StationId, @SectorId, @SessionPerformerId, @ProductionId, NULLIF(@ShiftTaskId, 0),
+            @Qty, GETDATE(), @PerformerId);
+
+    DECLARE @Id INT = SCOPE_IDENTITY();
+
+    IF @ShiftTaskId > 0
+        UPDATE dbo.svTB_ShiftTask
+        SET FactQty    = FactQty + 1,
+            updated_at = GETDATE(),
+            updated_by = @PerformerId
+        WHERE idShiftTask = @ShiftTaskId;
+
+    COMMIT TRANSACTION;
+
+    EXEC dbo.svTB_PalletById @Id;
+END
+GO;
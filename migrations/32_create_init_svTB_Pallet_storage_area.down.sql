@@ -0,0 +1,13 @@
+UPDATE dbo.svPermissions
+SET description = N'П\п: отгрузка со склада'
+WHERE code = 'pallets.move';
+GO;
+
+DROP PROCEDURE IF EXISTS dbo.svTB_PalletCountByStorageArea;
+DROP PROCEDURE IF EXISTS dbo.svTB_PalletPlace;
+GO;
+
+DROP INDEX IF EXISTS IX_svTB_Pallet_storage_area ON dbo.svTB_Pallet;
+ALTER TABLE dbo.svTB_Pallet DROP CONSTRAINT FK_svTB_Pallet_storage_area;
+ALTER TABLE dbo.svTB_Pallet DROP COLUMN extStorageArea;
+GO;
\ No newline at end of file
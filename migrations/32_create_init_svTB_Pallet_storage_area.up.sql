@@ -0,0 +1,44 @@
+-- РАЗМЕЩЕНИЕ П\П НА УЧАСТКАХ ХРАНЕНИЯ (svCatalogs kodcat=10). Заполненность участка - п\п на складе, размещенные на
+-- участке, к вместимости участка с учетом процента использования.
+ALTER TABLE dbo.svTB_Pallet
+    ADD extStorageArea INT NULL -- extStorageArea - участок хранения, svCatalogs kodcat=10, NULL - не размещен.
+        CONSTRAINT FK_svTB_Pallet_storage_area REFERENCES dbo.svCatalogs (id);
+GO;
+
+CREATE INDEX IX_svTB_Pallet_storage_area ON dbo.svTB_Pallet (extStorageArea, ShippedAt);
+GO;
+
+CREATE PROCEDURE dbo.svTB_PalletPlace -- ХП размещает п\п на участке хранения, 0 - п\п не найден или уже отгружен.
+    @Id INT,
+    @StorageAreaId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    UPDATE dbo.svTB_Pallet
+    SET extStorageArea = @StorageAreaId
+    WHERE idPallet = @Id
+      AND ShippedAt IS NULL;
+
+    SELECT @@ROWCOUNT AS result;
+END
+GO;
+
+CREATE PROCEDURE dbo.svTB_PalletCountByStorageArea -- ХП получает кол-во п\п на складе по участкам хранения.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT extStorageArea,
+           COUNT(*) AS Pallets
+    FROM dbo.svTB_Pallet
+    WHERE ShippedAt IS NULL
+      AND extStorageArea IS NOT NULL
+    GROUP BY extStorageArea;
+END
+GO;
+
+UPDATE dbo.svPermissions
+SET description = N'П\п: отгрузка со склада и размещение на участке хранения'
+WHERE code = 'pallets.move';
+GO;
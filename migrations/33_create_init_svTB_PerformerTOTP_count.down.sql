@@ -0,0 +1,2 @@
+DROP PROCEDURE IF EXISTS dbo.svTB_PerformerTOTPCount;
+GO;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- СЧИТАТЬ ПОДКЛЮЧЕНИЯ 2FA. Без TOTP_KEY приложение не запускается, пока в БД есть секреты TOTP.
+CREATE PROCEDURE dbo.svTB_PerformerTOTPCount -- ХП считает секреты TOTP сотрудников, в том числе неподтвержденные.
+AS
+BEGIN
+    SET NOCOUNT ON;
+
+    SELECT COUNT(*)
+    FROM dbo.svTB_PerformerTOTP;
+END
+GO;
@@ -35,6 +35,28 @@ const (
 	E3210 = "E3210 Ошибка: не удалось подтвердить логин или пароль, проверьте корректность данных."
 	E3212 = "E3212 Ошибка: не удалось найти объект."
 	E3213 = "E3213 Ошибка: не удалось провести валидацию полей."
+	E3219 = "E3219 Ошибка: не удалось прочитать файл импорта."
+	E3220 = "E3220 Ошибка: машинная линия продукции не принадлежит печи."
+	E3221 = "E3221 Ошибка: машинная линия не принадлежит печи задания."
+	E3222 = "E3222 Ошибка: сотрудник с таким штрих-кодом бейджа не найден."
+	E3223 = "E3223 Ошибка: на станции упаковки нет открытого сеанса."
+	E3224 = "E3224 Ошибка: запись не найдена в справочнике."
+	E3225 = "E3225 Ошибка: продукция используется в невыполненных сменно-суточных заданиях."
+	E3226 = "E3226 Ошибка: продукция в архиве."
+	E3227 = "E3227 Ошибка: у продукции нет действующей декларации о соответствии."
+	E3228 = "E3228 Ошибка: продукция не декларируемая."
+	E3229 = "E3229 Ошибка: не удалось записать файл выгрузки."
+	E3230 = "E3230 Ошибка: пароль не соответствует требованиям."
+	E3231 = "E3231 Ошибка: временный пароль, требуется смена пароля."
+	E3232 = "E3232 Ошибка: неверный код двухфакторной аутентификации."
+	E3233 = "E3233 Ошибка: требуется код двухфакторной аутентификации."
+	E3234 = "E3234 Ошибка: для роли обязательна двухфакторная аутентификация, подключите ее в веб-интерфейсе."
+	E3235 = "E3235 Ошибка: печать этикетки продукции запрещена."
+	E3236 = "E3236 Ошибка: продукция есть на складе, есть не отгруженные п\\п."
+	E3237 = "E3237 Ошибка: задание не на печь сеанса, другую продукцию или другие сутки."
+	E3238 = "E3238 Ошибка: двухфакторная аутентификация недоступна, не задан ключ шифрования TOTP_KEY."
+	E3239 = "E3239 Ошибка: пароль проверяется не по FGW_WEB (AUTH_PROVIDERS без db), смените его в службе каталогов."
+	E3240 = "E3240 Ошибка: участок хранения закрыт или на нем нет свободного места."
 
 	E3200 = "E3200 Ошибка: не удалось подключиться к БД."
 	E3201 = "E3201 Ошибка: не удалось закрыть соединение с БД."
@@ -11,4 +11,7 @@ const (
 	H7007 = "H7007 Ошибка: не удалось обработать форму шаблона. "
 	H7008 = "H7008 Ошибка: 404, не найден. "
 	H7009 = "H7009 Ошибка: 204, нет контента."
+	H7010 = "H7010 Ошибка: 403, доступ запрещен. "
+	H7011 = "H7011 Ошибка: 429, слишком много попыток. "
+	H7012 = "H7012 Ошибка: 403, неверный CSRF-токен. "
 )
@@ -1 +1,10 @@
 package msg
+
+// Предупреждения связанные с авторизацией
+// 4000-4099
+const (
+	W4000 = "W4000 Предупреждение: учетная запись заблокирована после неудачных попыток входа."
+	W4001 = "W4001 Предупреждение: адрес клиента заблокирован после неудачных попыток входа."
+	W4002 = "W4002 Предупреждение: блокировка входа снята администратором."
+	W4003 = "W4003 Предупреждение: слишком частые попытки входа, повторите позже."
+)
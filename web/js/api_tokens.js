@@ -0,0 +1,109 @@
+/**
+ * API Tokens Module
+ * @module ApiTokensManager
+ * @description Выдача и отзыв API-токенов для доступа к JSON API
+ */
+
+const API_TOKENS_CONFIG = {
+    API: {
+        ADD_URL: '/admin/api-tokens/add',
+        REVOKE_URL: '/admin/api-tokens/revoke'
+    },
+    SELECTORS: {
+        PANEL: '#apiTokensPanel',
+        FORM: '#apiTokenForm',
+        SCOPE: '.api-token-scope',
+        ADD_BTN: '.api-token-add-btn',
+        REVOKE_BTN: '.api-token-revoke-btn',
+        CREATED: '#apiTokenCreated',
+        VALUE: '#apiTokenValue'
+    },
+    MESSAGES: {
+        CONFIRM_REVOKE: 'Отозвать токен? Запросы с ним сразу перестанут проходить.',
+        ACTION_ERROR: 'Ошибка при выполнении действия'
+    }
+};
+
+class ApiTokensManager {
+    constructor() {
+        if (!document.querySelector(API_TOKENS_CONFIG.SELECTORS.PANEL)) return;
+
+        this.bindEvents();
+    }
+
+    bindEvents() {
+        const form = document.querySelector(API_TOKENS_CONFIG.SELECTORS.FORM);
+        form.addEventListener('submit', (event) => {
+            event.preventDefault();
+            this.handleAdd(form);
+        });
+
+        document.addEventListener('click', (event) => {
+            const revokeBtn = event.target.closest(API_TOKENS_CONFIG.SELECTORS.REVOKE_BTN);
+            if (revokeBtn) {
+                this.handleRevoke(revokeBtn);
+            }
+        });
+    }
+
+    async handleAdd(form) {
+        const button = form.querySelector(API_TOKENS_CONFIG.SELECTORS.ADD_BTN);
+        const scopes = Array.from(form.querySelectorAll(`${API_TOKENS_CONFIG.SELECTORS.SCOPE}:checked`))
+            .map(checkbox => checkbox.value);
+
+        button.disabled = true;
+
+        try {
+            const result = await this.post(API_TOKENS_CONFIG.API.ADD_URL, {
+                name: form.elements.name.value,
+                performerId: parseInt(form.elements.performerId.value, 10),
+                expiresDays: parseInt(form.elements.expiresDays.value || '0', 10),
+                scopes: scopes
+            });
+
+            // Токен показывается один раз: список обновится при следующем открытии страницы.
+            document.querySelector(API_TOKENS_CONFIG.SELECTORS.VALUE).textContent = result.token;
+            document.querySelector(API_TOKENS_CONFIG.SELECTORS.CREATED).classList.remove('d-none');
+            form.reset();
+        } catch (error) {
+            console.error('Api token add error:', error);
+            alert(`${API_TOKENS_CONFIG.MESSAGES.ACTION_ERROR}: ${error.message}`);
+        } finally {
+            button.disabled = false;
+        }
+    }
+
+    async handleRevoke(button) {
+        if (!confirm(API_TOKENS_CONFIG.MESSAGES.CONFIRM_REVOKE)) return;
+
+        button.disabled = true;
+
+        try {
+            await this.post(`${API_TOKENS_CONFIG.API.REVOKE_URL}?tokenId=${encodeURIComponent(button.dataset.id)}`);
+            window.location.reload();
+        } catch (error) {
+            console.error('Api token revoke error:', error);
+            alert(`${API_TOKENS_CONFIG.MESSAGES.ACTION_ERROR}: ${error.message}`);
+            button.disabled = false;
+        }
+    }
+
+    async post(url, data) {
+        const response = await fetch(url, {
+            method: 'POST',
+            headers: CSRF.headers({'Content-Type': 'application/json'}),
+            body: data ? JSON.stringify(data) : undefined
+        });
+
+        const result = await response.json();
+        if (!response.ok) {
+            throw new Error(result.message || result.error || `HTTP ${response.status}`);
+        }
+
+        return result;
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    window.apiTokensManager = new ApiTokensManager();
+});
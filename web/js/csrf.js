@@ -0,0 +1,20 @@
+/**
+ * CSRF Module
+ * @module CSRF
+ * @description CSRF-токен сессии для изменяющих fetch-запросов, берется из <meta name="csrf-token">
+ */
+
+const CSRF = {
+    HEADER: 'X-CSRF-Token',
+
+    token() {
+        const meta = document.querySelector('meta[name="csrf-token"]');
+        return meta ? meta.content : '';
+    },
+
+    headers(headers = {}) {
+        return {...headers, [CSRF.HEADER]: CSRF.token()};
+    }
+};
+
+window.CSRF = CSRF;
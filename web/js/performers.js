@@ -9,19 +9,23 @@ const PERFORMERS_CONFIG = {
     API: {
         BASE_URL: '/admin/performers',
         ENDPOINTS: {
-            UPDATE: '/upd'
-        }
+            UPDATE: '/upd',
+            PASSWORD_RESET: '/password-reset'
+        },
+        IMPORT_URL: '/admin/aforms-performers/import'
     },
     SELECTORS: {
         EDIT_BTN: '.edit-btn',
         CANCEL_BTN: '.cancel-btn',
         SAVE_BTN: '.save-btn',
+        PASSWORD_RESET_BTN: '.password-reset-btn',
         SEARCH_INPUT: '#searchInput',
         SEARCH_FORM: '#searchForm',
         PERFORMER_ROW: 'tr[data-id]',
         FORM_SELECT: 'select.role-forms-select, .role-forms-select',
         FGW_SELECT: 'select.role-fgw-select, .role-fgw-select',
-        EDIT_BUTTONS: '.edit-buttons'
+        EDIT_BUTTONS: '.edit-buttons',
+        IMPORT_FORM: '#galaktikaImportForm'
     },
     CLASSES: {
         EDITING: 'editing',
@@ -36,7 +40,10 @@ const PERFORMERS_CONFIG = {
     MESSAGES: {
         SAVE_SUCCESS: 'Изменения успешно сохранены',
         SAVE_ERROR: 'Ошибка при сохранении',
-        SEARCH_ERROR: 'Ошибка при поиске'
+        SEARCH_ERROR: 'Ошибка при поиске',
+        IMPORT_ERROR: 'Ошибка импорта',
+        PASSWORD_RESET_CONFIRM: 'Сбросить пароль сотрудника {id}? Сотрудник будет выведен из системы на всех устройствах.',
+        PASSWORD_RESET_ERROR: 'Ошибка сброса пароля'
     }
 };
 
@@ -230,13 +237,46 @@ class PerformersAPI {
         });
     }
 
+    static async resetPassword(performerId) {
+        const url = `${PERFORMERS_CONFIG.API.BASE_URL}${PERFORMERS_CONFIG.API.ENDPOINTS.PASSWORD_RESET}` +
+            `?performerId=${encodeURIComponent(performerId)}`;
+        const response = await fetch(url, {
+            method: 'POST',
+            headers: CSRF.headers({
+                'Accept': 'application/json'
+            })
+        });
+
+        if (!response.ok) {
+            await this._handleError(response);
+        }
+
+        return await response.json();
+    }
+
+    static async importGalaktika(formData) {
+        const response = await fetch(PERFORMERS_CONFIG.API.IMPORT_URL, {
+            method: 'POST',
+            headers: CSRF.headers({
+                'Accept': 'application/json'
+            }),
+            body: formData
+        });
+
+        if (!response.ok) {
+            await this._handleError(response);
+        }
+
+        return await response.json();
+    }
+
     static async _makeRequest(endpoint, data) {
         const response = await fetch(`${PERFORMERS_CONFIG.API.BASE_URL}${endpoint}`, {
             method: 'POST',
-            headers: {
+            headers: CSRF.headers({
                 'Content-Type': 'application/json',
                 'Accept': 'application/json'
-            },
+            }),
             body: JSON.stringify(data)
         });
 
@@ -263,13 +303,16 @@ class PerformersAPI {
  * Класс для управления уведомлениями
  */
 class PerformersNotificationManager {
-    static show(message, type = 'info') {
+    static show(message, type = 'info', persistent = false) {
         this.clear();
 
         const notification = this._createNotificationElement(message, type);
         document.body.appendChild(notification);
 
-        this._setupAutoDismiss(notification);
+        // Временный пароль остается на экране, пока администратор не закроет уведомление.
+        if (!persistent) {
+            this._setupAutoDismiss(notification);
+        }
     }
 
     static _createNotificationElement(message, type) {
@@ -395,6 +438,40 @@ class PerformersManager {
 
     bindEvents() {
         document.addEventListener('click', this.handleClick.bind(this));
+
+        const importForm = document.querySelector(PERFORMERS_CONFIG.SELECTORS.IMPORT_FORM);
+        if (importForm) {
+            importForm.addEventListener('submit', this.handleImportSubmit.bind(this));
+        }
+    }
+
+    async handleImportSubmit(event) {
+        event.preventDefault();
+
+        const form = event.target;
+        const submitBtn = form.querySelector('button[type="submit"]');
+        if (submitBtn) submitBtn.disabled = true;
+
+        try {
+            const result = await PerformersAPI.importGalaktika(new FormData(form));
+            const report = result.report || {};
+
+            PerformersNotificationManager.show(
+                `${result.message}: добавлено ${report.created || 0}, обновлено ${report.updated || 0}, пропущено ${report.skipped || 0}`,
+                report.errors && report.errors.length ? 'warning' : 'success'
+            );
+
+            if (report.errors && report.errors.length) {
+                console.warn('Пропущенные строки импорта:', report.errors);
+            }
+
+            form.reset();
+        } catch (error) {
+            console.error('Import error:', error);
+            PerformersNotificationManager.show(`${PERFORMERS_CONFIG.MESSAGES.IMPORT_ERROR}: ${error.message}`, 'danger');
+        } finally {
+            if (submitBtn) submitBtn.disabled = false;
+        }
     }
 
     handleClick(event) {
@@ -426,6 +503,41 @@ class PerformersManager {
                 this.handleSaveClick(row);
             }
         }
+
+        // Сброс пароля
+        else if (event.target.closest(PERFORMERS_CONFIG.SELECTORS.PASSWORD_RESET_BTN)) {
+            const btn = event.target.closest(PERFORMERS_CONFIG.SELECTORS.PASSWORD_RESET_BTN);
+            const row = btn.closest(PERFORMERS_CONFIG.SELECTORS.PERFORMER_ROW);
+            if (row) {
+                this.handlePasswordResetClick(row, btn);
+            }
+        }
+    }
+
+    async handlePasswordResetClick(row, btn) {
+        const performerId = PerformerRowManager.getPerformerId(row);
+        if (!performerId || btn.disabled) return;
+
+        if (!confirm(PERFORMERS_CONFIG.MESSAGES.PASSWORD_RESET_CONFIRM.replace('{id}', performerId))) {
+            return;
+        }
+
+        btn.disabled = true;
+
+        try {
+            const result = await PerformersAPI.resetPassword(performerId);
+
+            PerformersNotificationManager.show(
+                `${result.message}. Сотрудник ${result.performerId}, временный пароль: ${result.tempPassword}`,
+                'success',
+                true
+            );
+        } catch (error) {
+            console.error('Password reset error:', error);
+            PerformersNotificationManager.show(`${PERFORMERS_CONFIG.MESSAGES.PASSWORD_RESET_ERROR}: ${error.message}`, 'danger');
+        } finally {
+            btn.disabled = false;
+        }
     }
 
     handleEditClick(row) {
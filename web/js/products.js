@@ -0,0 +1,117 @@
+/**
+ * Products Archive Module
+ * @module ProductsManager
+ * @description Архивирование и возврат продукции из архива, привязка к справочникам
+ */
+
+const PRODUCTS_CONFIG = {
+    API: {
+        ARCHIVE_URL: '/admin/products/archive',
+        UNARCHIVE_URL: '/admin/products/unarchive',
+        MAP_CATALOGS_URL: '/admin/products/catalogs/map'
+    },
+    SELECTORS: {
+        PANEL: '#productsArchivePanel',
+        ARCHIVE_BTN: '.product-archive-btn',
+        UNARCHIVE_BTN: '.product-unarchive-btn',
+        MAP_CATALOGS_BTN: '.product-map-catalogs-btn'
+    },
+    MESSAGES: {
+        CONFIRM_ARCHIVE: 'Перенести продукцию в архив?',
+        CONFIRM_UNARCHIVE: 'Вернуть продукцию из архива?',
+        ARCHIVE_ERROR: 'Ошибка при изменении архива',
+        CONFIRM_MAP: 'Привязать продукцию к справочникам цветов и конструкторских наименований по текстовым значениям?',
+        MAP_ERROR: 'Ошибка при сопоставлении со справочниками'
+    }
+};
+
+class ProductsManager {
+    constructor() {
+        if (!document.querySelector(PRODUCTS_CONFIG.SELECTORS.PANEL)) return;
+
+        this.bindEvents();
+    }
+
+    bindEvents() {
+        document.addEventListener('click', (event) => {
+            const archiveBtn = event.target.closest(PRODUCTS_CONFIG.SELECTORS.ARCHIVE_BTN);
+            if (archiveBtn) {
+                this.handleArchive(archiveBtn, PRODUCTS_CONFIG.API.ARCHIVE_URL, PRODUCTS_CONFIG.MESSAGES.CONFIRM_ARCHIVE);
+                return;
+            }
+
+            const unarchiveBtn = event.target.closest(PRODUCTS_CONFIG.SELECTORS.UNARCHIVE_BTN);
+            if (unarchiveBtn) {
+                this.handleArchive(unarchiveBtn, PRODUCTS_CONFIG.API.UNARCHIVE_URL, PRODUCTS_CONFIG.MESSAGES.CONFIRM_UNARCHIVE);
+                return;
+            }
+
+            const mapBtn = event.target.closest(PRODUCTS_CONFIG.SELECTORS.MAP_CATALOGS_BTN);
+            if (mapBtn) {
+                this.handleMapCatalogs(mapBtn);
+            }
+        });
+    }
+
+    async handleArchive(button, url, confirmMessage) {
+        if (!confirm(confirmMessage)) return;
+
+        button.disabled = true;
+
+        try {
+            const response = await fetch(`${url}?productId=${encodeURIComponent(button.dataset.id)}`, {
+                method: 'POST',
+                headers: CSRF.headers({'Content-Type': 'application/json'})
+            });
+
+            const result = await response.json();
+            if (!response.ok) {
+                throw new Error(result.message || result.error || `HTTP ${response.status}`);
+            }
+
+            window.location.reload();
+        } catch (error) {
+            console.error('Archive error:', error);
+            alert(`${PRODUCTS_CONFIG.MESSAGES.ARCHIVE_ERROR}: ${error.message}`);
+            button.disabled = false;
+        }
+    }
+
+    async handleMapCatalogs(button) {
+        if (!confirm(PRODUCTS_CONFIG.MESSAGES.CONFIRM_MAP)) return;
+
+        button.disabled = true;
+
+        try {
+            const response = await fetch(PRODUCTS_CONFIG.API.MAP_CATALOGS_URL, {
+                method: 'POST',
+                headers: CSRF.headers({'Content-Type': 'application/json'})
+            });
+
+            const result = await response.json();
+            if (!response.ok) {
+                throw new Error(result.message || result.error || `HTTP ${response.status}`);
+            }
+
+            const lines = [`Привязано к цвету: ${result.colors}`, `Привязано к наименованию: ${result.designs}`];
+            if (result.unmatched.length > 0) {
+                lines.push('', 'Нет в справочниках:');
+                result.unmatched.forEach(item => {
+                    const field = item.field === 'color' ? 'цвет' : 'наименование';
+                    lines.push(`  ${field} "${item.value}" — продукции: ${item.products}`);
+                });
+            }
+
+            alert(lines.join('\n'));
+            window.location.reload();
+        } catch (error) {
+            console.error('Map catalogs error:', error);
+            alert(`${PRODUCTS_CONFIG.MESSAGES.MAP_ERROR}: ${error.message}`);
+            button.disabled = false;
+        }
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    window.productsManager = new ProductsManager();
+});
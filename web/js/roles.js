@@ -11,7 +11,8 @@ const CONFIG = {
         ENDPOINTS: {
             ADD: '/add',
             UPDATE: '/upd',
-            DELETE: '/del'
+            DELETE: '/del',
+            PERMISSIONS: '/permissions'
         }
     },
     SELECTORS: {
@@ -20,6 +21,8 @@ const CONFIG = {
         SAVE_BTN: '.save-role-btn',
         ADD_BTN: '.add-role-btn',
         DEL_BTN: '.del-btn',
+        SAVE_PERMISSIONS_BTN: '.save-permissions-btn',
+        ROLE_PERMISSION: '.role-permission',
         ROLE_ROW: 'tr[data-id]',
         ADD_MODAL: '#addRoleModal',
         ROLES_TABLE: '#rolesTable',
@@ -385,13 +388,20 @@ class RoleAPI {
         }, 'DELETE');
     }
 
+    static async setPermissions(data) {
+        return this._makeRequest(CONFIG.API.ENDPOINTS.PERMISSIONS, {
+            roleId: data.roleId,
+            codes: data.codes
+        });
+    }
+
     static async _makeRequest(endpoint, data, method = 'POST') {
         const response = await fetch(`${CONFIG.API.BASE_URL}${endpoint}`, {
             method: method,
-            headers: {
+            headers: CSRF.headers({
                 'Content-Type': 'application/json',
                 'Accept': 'application/json'
-            },
+            }),
             body: JSON.stringify(data)
         });
 
@@ -554,6 +564,29 @@ class RoleManager {
             const row = btn.closest(CONFIG.SELECTORS.ROLE_ROW);
             this.handleDeleteClick(row);
         }
+        else if (event.target.closest(CONFIG.SELECTORS.SAVE_PERMISSIONS_BTN)) {
+            const btn = event.target.closest(CONFIG.SELECTORS.SAVE_PERMISSIONS_BTN);
+            this.handleSavePermissionsClick(btn);
+        }
+    }
+
+    async handleSavePermissionsClick(button) {
+        const roleId = parseInt(button.dataset.roleId, 10);
+        const codes = Array.from(document.querySelectorAll(
+            `${CONFIG.SELECTORS.ROLE_PERMISSION}[data-role-id="${roleId}"]:checked`
+        )).map(checkbox => checkbox.value);
+
+        RoleRowManager.setLoadingState(button, true);
+
+        try {
+            const result = await RoleAPI.setPermissions({ roleId, codes });
+            NotificationManager.show(result.message || 'Права роли обновлены', 'success');
+        } catch (error) {
+            console.error('Set permissions error:', error);
+            NotificationManager.show(error.message || 'Ошибка при сохранении прав роли', 'danger');
+        } finally {
+            RoleRowManager.setLoadingState(button, false);
+        }
     }
 
     handleEditClick(row) {
@@ -0,0 +1,122 @@
+/**
+ * Sectors Assignment Module
+ * @module SectorsManager
+ * @description Массовая привязка операторов AForms к печам
+ */
+
+const SECTORS_CONFIG = {
+    API: {
+        ASSIGN_URL: '/admin/sectors/assign'
+    },
+    SELECTORS: {
+        PANEL: '#sectorAssignPanel',
+        SECTOR_SELECT: '#sectorSelect',
+        ASSIGN_BTN: '.assign-sector-btn',
+        SELECT_ALL: '#selectAllPerformers',
+        PERFORMER_CHECK: '.performer-check',
+        SELECTED_COUNT: '.selected-count'
+    },
+    MESSAGES: {
+        ASSIGN_ERROR: 'Ошибка при назначении печи'
+    }
+};
+
+class SectorsManager {
+    constructor() {
+        this.panel = document.querySelector(SECTORS_CONFIG.SELECTORS.PANEL);
+        if (!this.panel) return;
+
+        this.sectorSelect = document.querySelector(SECTORS_CONFIG.SELECTORS.SECTOR_SELECT);
+        this.assignBtn = document.querySelector(SECTORS_CONFIG.SELECTORS.ASSIGN_BTN);
+        this.selectAll = document.querySelector(SECTORS_CONFIG.SELECTORS.SELECT_ALL);
+
+        this.bindEvents();
+    }
+
+    bindEvents() {
+        document.addEventListener('change', (event) => {
+            if (event.target.matches(SECTORS_CONFIG.SELECTORS.PERFORMER_CHECK) ||
+                event.target === this.sectorSelect) {
+                this.refreshState();
+            }
+        });
+
+        if (this.selectAll) {
+            this.selectAll.addEventListener('change', () => {
+                this.getChecks().forEach(check => {
+                    check.checked = this.selectAll.checked;
+                });
+                this.refreshState();
+            });
+        }
+
+        this.assignBtn.addEventListener('click', this.handleAssign.bind(this));
+    }
+
+    getChecks() {
+        return document.querySelectorAll(SECTORS_CONFIG.SELECTORS.PERFORMER_CHECK);
+    }
+
+    getSelectedIds() {
+        return Array.from(this.getChecks())
+            .filter(check => check.checked)
+            .map(check => parseInt(check.value, 10));
+    }
+
+    refreshState() {
+        const count = this.getSelectedIds().length;
+        this.panel.querySelector(SECTORS_CONFIG.SELECTORS.SELECTED_COUNT).textContent = count.toString();
+        this.assignBtn.disabled = count === 0 || !this.sectorSelect.value;
+    }
+
+    async handleAssign() {
+        const performerIds = this.getSelectedIds();
+        const sectorId = parseInt(this.sectorSelect.value, 10);
+        const sectorName = this.sectorSelect.options[this.sectorSelect.selectedIndex]?.text?.trim() || '';
+
+        this.assignBtn.disabled = true;
+
+        try {
+            const response = await fetch(SECTORS_CONFIG.API.ASSIGN_URL, {
+                method: 'POST',
+                headers: CSRF.headers({
+                    'Content-Type': 'application/json',
+                    'Accept': 'application/json'
+                }),
+                body: JSON.stringify({performerIds, sectorId})
+            });
+
+            const result = await response.json();
+            if (!response.ok) {
+                throw new Error(result.error || `HTTP ${response.status}`);
+            }
+
+            performerIds.forEach(id => {
+                const cell = document.querySelector(`tr[data-id="${id}"] .sector-name`);
+                if (cell) {
+                    cell.innerHTML = '';
+                    const badge = document.createElement('span');
+                    badge.className = 'badge bg-secondary';
+                    badge.textContent = sectorName;
+                    cell.appendChild(badge);
+                }
+            });
+
+            this.getChecks().forEach(check => {
+                check.checked = false;
+            });
+            if (this.selectAll) this.selectAll.checked = false;
+
+            alert(result.message);
+        } catch (error) {
+            console.error('Assign error:', error);
+            alert(`${SECTORS_CONFIG.MESSAGES.ASSIGN_ERROR}: ${error.message}`);
+        } finally {
+            this.refreshState();
+        }
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    window.sectorsManager = new SectorsManager();
+});
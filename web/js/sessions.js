@@ -0,0 +1,88 @@
+/**
+ * Sessions Module
+ * @module SessionsManager
+ * @description Принудительное завершение сессий сотрудников и снятие блокировок входа
+ */
+
+const SESSIONS_CONFIG = {
+    API: {
+        REVOKE_URL: '/admin/sessions/revoke',
+        REVOKE_PERFORMER_URL: '/admin/sessions/revoke-performer',
+        UNLOCK_URL: '/admin/sessions/unlock'
+    },
+    SELECTORS: {
+        PANEL: '#sessionsPanel',
+        REVOKE_BTN: '.session-revoke-btn',
+        REVOKE_PERFORMER_BTN: '.session-revoke-performer-btn',
+        UNLOCK_BTN: '.login-unlock-btn'
+    },
+    MESSAGES: {
+        CONFIRM_REVOKE: 'Завершить сессию? Сотруднику потребуется войти заново.',
+        CONFIRM_REVOKE_PERFORMER: 'Завершить все сессии сотрудника? Ему потребуется войти заново на всех устройствах.',
+        ACTION_ERROR: 'Ошибка при выполнении действия',
+        CONFIRM_UNLOCK: 'Снять блокировку входа?'
+    }
+};
+
+class SessionsManager {
+    constructor() {
+        if (!document.querySelector(SESSIONS_CONFIG.SELECTORS.PANEL)) return;
+
+        this.bindEvents();
+    }
+
+    bindEvents() {
+        document.addEventListener('click', (event) => {
+            const revokeBtn = event.target.closest(SESSIONS_CONFIG.SELECTORS.REVOKE_BTN);
+            if (revokeBtn) {
+                this.handlePost(revokeBtn,
+                    `${SESSIONS_CONFIG.API.REVOKE_URL}?sessionId=${encodeURIComponent(revokeBtn.dataset.id)}`,
+                    SESSIONS_CONFIG.MESSAGES.CONFIRM_REVOKE);
+                return;
+            }
+
+            const performerBtn = event.target.closest(SESSIONS_CONFIG.SELECTORS.REVOKE_PERFORMER_BTN);
+            if (performerBtn) {
+                this.handlePost(performerBtn,
+                    `${SESSIONS_CONFIG.API.REVOKE_PERFORMER_URL}?performerId=${encodeURIComponent(performerBtn.dataset.performerId)}`,
+                    SESSIONS_CONFIG.MESSAGES.CONFIRM_REVOKE_PERFORMER);
+                return;
+            }
+
+            const unlockBtn = event.target.closest(SESSIONS_CONFIG.SELECTORS.UNLOCK_BTN);
+            if (unlockBtn) {
+                const params = new URLSearchParams({kind: unlockBtn.dataset.kind, value: unlockBtn.dataset.value});
+                this.handlePost(unlockBtn, `${SESSIONS_CONFIG.API.UNLOCK_URL}?${params}`,
+                    SESSIONS_CONFIG.MESSAGES.CONFIRM_UNLOCK);
+            }
+        });
+    }
+
+    async handlePost(button, url, confirmMessage) {
+        if (!confirm(confirmMessage)) return;
+
+        button.disabled = true;
+
+        try {
+            const response = await fetch(url, {
+                method: 'POST',
+                headers: CSRF.headers({'Content-Type': 'application/json'})
+            });
+
+            const result = await response.json();
+            if (!response.ok) {
+                throw new Error(result.message || result.error || `HTTP ${response.status}`);
+            }
+
+            window.location.reload();
+        } catch (error) {
+            console.error('Session action error:', error);
+            alert(`${SESSIONS_CONFIG.MESSAGES.ACTION_ERROR}: ${error.message}`);
+            button.disabled = false;
+        }
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    window.sessionsManager = new SessionsManager();
+});
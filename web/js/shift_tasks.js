@@ -0,0 +1,67 @@
+/**
+ * Shift Tasks Module
+ * @module ShiftTasksManager
+ * @description Добавление сменно-суточных заданий диспетчером
+ */
+
+const SHIFT_TASKS_CONFIG = {
+    API: {
+        ADD_URL: '/fgw/shift-tasks/add'
+    },
+    SELECTORS: {
+        ADD_FORM: '#shiftTaskAddForm',
+        ADD_ERROR: '#shiftTaskAddError'
+    },
+    MESSAGES: {
+        ADD_ERROR: 'Ошибка при добавлении задания'
+    }
+};
+
+class ShiftTasksManager {
+    constructor() {
+        this.form = document.querySelector(SHIFT_TASKS_CONFIG.SELECTORS.ADD_FORM);
+        if (!this.form) return;
+
+        this.errorBox = document.querySelector(SHIFT_TASKS_CONFIG.SELECTORS.ADD_ERROR);
+        this.form.addEventListener('submit', this.handleAdd.bind(this));
+    }
+
+    getTask() {
+        const data = new FormData(this.form);
+
+        return {
+            taskDate: data.get('taskDate'),
+            shiftNum: parseInt(data.get('shiftNum'), 10),
+            sectorId: parseInt(data.get('sectorId'), 10),
+            lineNum: parseInt(data.get('lineNum'), 10),
+            productId: parseInt(data.get('productId'), 10),
+            planQty: parseInt(data.get('planQty'), 10)
+        };
+    }
+
+    async handleAdd(event) {
+        event.preventDefault();
+        this.errorBox.textContent = '';
+
+        try {
+            const response = await fetch(SHIFT_TASKS_CONFIG.API.ADD_URL, {
+                method: 'POST',
+                headers: CSRF.headers({'Content-Type': 'application/json'}),
+                body: JSON.stringify(this.getTask())
+            });
+
+            if (!response.ok) {
+                const result = await response.json().catch(() => ({}));
+                throw new Error(result.message || result.error || SHIFT_TASKS_CONFIG.MESSAGES.ADD_ERROR);
+            }
+
+            window.location.reload();
+        } catch (error) {
+            this.errorBox.textContent = error.message;
+        }
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    new ShiftTasksManager();
+});